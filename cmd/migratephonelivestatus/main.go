@@ -0,0 +1,59 @@
+// Command migratephonelivestatus copies the jobs of the retired legacy phone
+// live status pipeline into the shared job and transaction logs, so they are
+// listed and exported with the jobs of the new pipeline.
+//
+//	go run ./cmd/migratephonelivestatus -company 12 -member 34
+//
+// The member must be an admin of the company. Migrated jobs are marked in the
+// legacy store and skipped by later runs. Each copy records the legacy job id
+// so the legacy routes keep finding the job by it.
+package main
+
+import (
+	"flag"
+	"front-office/configs/application"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/product"
+	"front-office/internal/datahub/identity/oldphonelivestatus"
+	"front-office/internal/datahub/job"
+	"front-office/pkg/httpclient"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	companyId := flag.String("company", "", "company whose legacy jobs are migrated")
+	memberId := flag.String("member", "", "admin member of the company")
+	flag.Parse()
+
+	if *companyId == "" || *memberId == "" {
+		flag.Usage()
+		log.Fatal().Msg("company and member are required")
+	}
+
+	time.Local = time.FixedZone("Asia/Jakarta", 25200)
+
+	cfg := application.GetConfig()
+	client := httpclient.NewDefaultClient(10 * time.Second)
+
+	service := oldphonelivestatus.NewService(
+		oldphonelivestatus.NewRepository(&cfg, client, nil),
+		product.NewRepository(&cfg, client),
+		job.NewRepository(&cfg, client, nil),
+		transaction.NewRepository(&cfg, client, nil),
+	)
+
+	result, err := service.MigrateCompany(*memberId, *companyId)
+	if result != nil {
+		log.Info().
+			Str("company_id", *companyId).
+			Int("jobs", result.Jobs).
+			Int("rows", result.Rows).
+			Int("skipped", result.Skipped).
+			Msg("legacy phone live status jobs migrated")
+	}
+	if err != nil {
+		log.Fatal().Err(err).Str("company_id", *companyId).Msg("failed to migrate legacy phone live status jobs")
+	}
+}
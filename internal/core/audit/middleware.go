@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"front-office/internal/core/log/operation"
+	"front-office/pkg/common/constant"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Export records every successful download of the route under action. An
+// empty productSlug takes the product from the :product_slug parameter. It
+// must come after middleware.GetJWTPayloadFromCookie.
+func Export(svc Service, action, productSlug string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if c.Response().StatusCode() == fiber.StatusOK {
+			svc.Record(newAccess(c, action, productSlug, countRows(c)))
+		}
+
+		return nil
+	}
+}
+
+// UnmaskedView records successful views of personal data that the masking
+// policy left unmasked. It must come after masking.Resolve.
+func UnmaskedView(svc Service, productSlug string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if masked, ok := c.Locals(constant.Masked).(bool); ok && !masked && c.Response().StatusCode() == fiber.StatusOK {
+			svc.Record(newAccess(c, constant.EventViewUnmaskedData, productSlug, 0))
+		}
+
+		return nil
+	}
+}
+
+func newAccess(c *fiber.Ctx, action, productSlug string, rows int) *Access {
+	if productSlug == "" {
+		productSlug = c.Params("product_slug")
+	}
+
+	detail := &operation.AccessDetail{
+		ProductSlug: productSlug,
+		JobId:       firstParam(c, "job_id", "id", "bundle_job_id", "invoice_id"),
+		StartDate:   c.Query(constant.StartDate),
+		EndDate:     c.Query(constant.EndDate),
+		Rows:        rows,
+	}
+
+	if masked, ok := c.Locals(constant.Masked).(bool); ok {
+		detail.Masked = &masked
+	}
+
+	memberId, _ := c.Locals(constant.UserId).(uint)
+	companyId, _ := c.Locals(constant.CompanyId).(uint)
+
+	return &Access{
+		MemberId:  memberId,
+		CompanyId: companyId,
+		Action:    action,
+		ClientIP:  c.IP(),
+		Detail:    detail,
+	}
+}
+
+func firstParam(c *fiber.Ctx, names ...string) string {
+	for _, name := range names {
+		if v := c.Params(name); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// countRows counts the records of a CSV response, header excluded.
+func countRows(c *fiber.Ctx) int {
+	if !strings.HasPrefix(string(c.Response().Header.ContentType()), constant.TextOrCSVContentType) {
+		return 0
+	}
+
+	r := csv.NewReader(bytes.NewReader(c.Response().Body()))
+	r.FieldsPerRecord = -1
+
+	rows := 0
+	for {
+		if _, err := r.Read(); err == io.EOF {
+			break
+		} else if err != nil {
+			return rows
+		}
+		rows++
+	}
+
+	if rows > 0 {
+		rows--
+	}
+
+	return rows
+}
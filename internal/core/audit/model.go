@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"front-office/internal/core/log/operation"
+	"time"
+)
+
+// Export volume above either limit within one window alerts the company
+// admins, once per window.
+const (
+	volumeWindow      = time.Hour
+	volumeExportLimit = 20
+	volumeRowLimit    = 50000
+)
+
+// adminRoleId is the role of company admins, see middleware.AdminAuth.
+const adminRoleId = "1"
+
+// Access is one export or unmasked view by a member.
+type Access struct {
+	MemberId  uint
+	CompanyId uint
+	Action    string
+	ClientIP  string
+	Detail    *operation.AccessDetail
+}
+
+// volume counts the exports of a company in the current window.
+type volume struct {
+	start   time.Time
+	exports int
+	rows    int
+	alerted bool
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/member"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/mailjet"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func NewService(operationRepo operation.Repository, memberRepo member.Repository) Service {
+	return &service{
+		operationRepo: operationRepo,
+		memberRepo:    memberRepo,
+		volumes:       defaultVolumes,
+		notify:        mailjet.SendEmailExportVolumeAlert,
+		now:           time.Now,
+	}
+}
+
+type service struct {
+	operationRepo operation.Repository
+	memberRepo    member.Repository
+	volumes       *volumes
+	notify        func(email, name string, exports, rows int) error
+	now           func() time.Time
+}
+
+type Service interface {
+	Record(access *Access)
+}
+
+// volumes is shared by every service so that exports from all routes count
+// towards the same company window.
+type volumes struct {
+	mu        sync.Mutex
+	companies map[uint]*volume
+}
+
+var defaultVolumes = &volumes{companies: map[uint]*volume{}}
+
+// Record writes access to the operation log. Exports also count towards the
+// company's export volume. Failures are logged and never fail the request.
+func (svc *service) Record(access *Access) {
+	if err := svc.operationRepo.AddLogOperation(&operation.AddLogRequest{
+		MemberId:  access.MemberId,
+		CompanyId: access.CompanyId,
+		Action:    access.Action,
+		ClientIP:  access.ClientIP,
+		Detail:    access.Detail,
+	}); err != nil {
+		log.Warn().Err(err).Str("action", access.Action).Msg("failed to log data access")
+	}
+
+	if access.Action == constant.EventViewUnmaskedData {
+		return
+	}
+
+	if exports, rows, exceeded := svc.count(access.CompanyId, access.Detail.Rows); exceeded {
+		go svc.alert(access.CompanyId, exports, rows)
+	}
+}
+
+// count adds an export to the company window and reports whether the window
+// just went over a limit.
+func (svc *service) count(companyId uint, rows int) (int, int, bool) {
+	svc.volumes.mu.Lock()
+	defer svc.volumes.mu.Unlock()
+
+	now := svc.now()
+
+	v, ok := svc.volumes.companies[companyId]
+	if !ok || now.Sub(v.start) >= volumeWindow {
+		v = &volume{start: now}
+		svc.volumes.companies[companyId] = v
+	}
+
+	v.exports++
+	v.rows += rows
+
+	if v.alerted || (v.exports <= volumeExportLimit && v.rows <= volumeRowLimit) {
+		return v.exports, v.rows, false
+	}
+
+	v.alerted = true
+
+	return v.exports, v.rows, true
+}
+
+func (svc *service) alert(companyId uint, exports, rows int) {
+	admins, _, err := svc.memberRepo.GetMemberListAPI(&member.MemberFilter{
+		CompanyID: helper.ConvertUintToString(companyId),
+		RoleID:    adminRoleId,
+		Page:      "1",
+		Limit:     constant.SizeUnlimited,
+	})
+	if err != nil {
+		log.Error().Err(err).Uint("company_id", companyId).Msg("failed to fetch admins for export volume alert")
+		return
+	}
+
+	for _, admin := range admins {
+		if err := svc.notify(admin.Email, admin.Name, exports, rows); err != nil {
+			log.Warn().Err(err).Uint("company_id", companyId).Msg("failed to send export volume alert")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/member"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOperationRepository struct {
+	operation.Repository
+	mock.Mock
+}
+
+func (m *MockOperationRepository) AddLogOperation(req *operation.AddLogRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+type MockMemberRepository struct {
+	member.Repository
+	mock.Mock
+}
+
+func (m *MockMemberRepository) GetMemberListAPI(filter *member.MemberFilter) ([]*member.MstMember, *model.Meta, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*member.MstMember), nil, args.Error(1)
+}
+
+func newTestService(operationRepo *MockOperationRepository, now *time.Time) *service {
+	return &service{
+		operationRepo: operationRepo,
+		memberRepo:    new(MockMemberRepository),
+		volumes:       &volumes{companies: map[uint]*volume{}},
+		notify:        func(email, name string, exports, rows int) error { return nil },
+		now:           func() time.Time { return *now },
+	}
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("should log the access with its detail", func(t *testing.T) {
+		now := time.Now()
+		operationRepo := new(MockOperationRepository)
+		operationRepo.On("AddLogOperation", mock.MatchedBy(func(req *operation.AddLogRequest) bool {
+			return req.Action == constant.EventExportData && req.ClientIP == "10.0.0.1" && req.Detail.Rows == 3
+		})).Return(nil)
+
+		svc := newTestService(operationRepo, &now)
+		svc.Record(&Access{
+			MemberId:  1,
+			CompanyId: 1,
+			Action:    constant.EventExportData,
+			ClientIP:  "10.0.0.1",
+			Detail:    &operation.AccessDetail{Rows: 3},
+		})
+
+		operationRepo.AssertExpectations(t)
+		assert.Equal(t, 1, svc.volumes.companies[1].exports)
+	})
+
+	t.Run("should not count unmasked views as exports", func(t *testing.T) {
+		now := time.Now()
+		operationRepo := new(MockOperationRepository)
+		operationRepo.On("AddLogOperation", mock.Anything).Return(nil)
+
+		svc := newTestService(operationRepo, &now)
+		svc.Record(&Access{CompanyId: 1, Action: constant.EventViewUnmaskedData, Detail: &operation.AccessDetail{}})
+
+		assert.Empty(t, svc.volumes.companies)
+	})
+}
+
+func TestCount(t *testing.T) {
+	t.Run("should report once when exports go over the limit", func(t *testing.T) {
+		now := time.Now()
+		svc := newTestService(new(MockOperationRepository), &now)
+
+		for i := 0; i < volumeExportLimit; i++ {
+			_, _, exceeded := svc.count(1, 1)
+			assert.False(t, exceeded)
+		}
+
+		exports, _, exceeded := svc.count(1, 1)
+		assert.True(t, exceeded)
+		assert.Equal(t, volumeExportLimit+1, exports)
+
+		_, _, exceeded = svc.count(1, 1)
+		assert.False(t, exceeded)
+	})
+
+	t.Run("should report when rows go over the limit", func(t *testing.T) {
+		now := time.Now()
+		svc := newTestService(new(MockOperationRepository), &now)
+
+		_, rows, exceeded := svc.count(1, volumeRowLimit+1)
+
+		assert.True(t, exceeded)
+		assert.Equal(t, volumeRowLimit+1, rows)
+	})
+
+	t.Run("should start a new window once the window passes", func(t *testing.T) {
+		now := time.Now()
+		svc := newTestService(new(MockOperationRepository), &now)
+
+		svc.count(1, volumeRowLimit+1)
+		now = now.Add(volumeWindow)
+		_, rows, exceeded := svc.count(1, 1)
+
+		assert.False(t, exceeded)
+		assert.Equal(t, 1, rows)
+	})
+}
@@ -4,11 +4,17 @@ import (
 	"front-office/configs/application"
 	"front-office/internal/core/auth"
 	"front-office/internal/core/grade"
+	"front-office/internal/core/invoice"
 	"front-office/internal/core/log/operation"
 	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/masking"
 	"front-office/internal/core/member"
+	"front-office/internal/core/quota"
 	"front-office/internal/core/role"
 	"front-office/internal/core/template"
+	"front-office/internal/core/topup"
+	"front-office/internal/core/usage"
+	"front-office/internal/core/webhook"
 	"front-office/internal/datahub"
 	"front-office/internal/scoreezy/genretail"
 	"front-office/pkg/httpclient"
@@ -38,6 +44,24 @@ func SetupInit(routeGroup fiber.Router, cfg *application.Config) {
 	transaction.SetupInit(logGroup, cfg, client)
 	operation.SetupInit(logGroup, cfg, client)
 
+	webhookGroup := routeGroup.Group("webhooks")
+	webhook.SetupInit(webhookGroup, cfg, client)
+
+	usageGroup := routeGroup.Group("usage")
+	usage.SetupInit(usageGroup, cfg, client)
+
+	invoiceGroup := routeGroup.Group("invoices")
+	invoice.SetupInit(invoiceGroup, cfg, client)
+
+	topupGroup := routeGroup.Group("topups")
+	topup.SetupInit(topupGroup, cfg, client)
+
+	quotaGroup := routeGroup.Group("quotas")
+	quota.SetupInit(quotaGroup, cfg, client)
+
+	maskingGroup := routeGroup.Group("masking")
+	masking.SetupInit(maskingGroup, cfg, client)
+
 	productGroup := routeGroup.Group("products")
 	datahub.SetupInit(productGroup, cfg)
 
@@ -0,0 +1,78 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	GenerateInvoice(c *fiber.Ctx) error
+	GetInvoices(c *fiber.Ctx) error
+	DownloadInvoice(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) GenerateInvoice(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+
+	reqBody, ok := c.Locals(constant.Request).(*generateInvoiceRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.GenerateInvoice(companyId, memberId, reqBody.Month)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to generate invoice",
+		result,
+	))
+}
+
+func (ctrl *controller) GetInvoices(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetInvoices(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get invoices",
+		result,
+	))
+}
+
+func (ctrl *controller) DownloadInvoice(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	format := c.Params("format")
+
+	content, filename, err := ctrl.svc.DownloadInvoice(companyId, c.Params("invoice_id"), format)
+	if err != nil {
+		return err
+	}
+
+	contentType := constant.TextOrCSVContentType
+	if format == FormatPDF {
+		contentType = constant.PDFContentType
+	}
+
+	c.Set(constant.HeaderContentType, contentType)
+	c.Set(constant.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filename))
+	return c.SendStream(bytes.NewReader(content))
+}
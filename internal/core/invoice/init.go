@@ -0,0 +1,31 @@
+package invoice
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/audit"
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/usage"
+	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	productRepo := product.NewRepository(cfg, client)
+	transactionRepo := transaction.NewRepository(cfg, client, nil)
+	usageService := usage.NewService(transactionRepo, productRepo)
+	service := NewService(repository, memberRepo, productRepo, usageService)
+	controller := NewController(service)
+	auditService := audit.NewService(operation.NewRepository(cfg, client, nil), memberRepo)
+
+	apiGroup.Post("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(generateInvoiceRequest{}), controller.GenerateInvoice)
+	apiGroup.Get("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetInvoices)
+	apiGroup.Get("/:invoice_id/:format", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), audit.Export(auditService, constant.EventExportData, ""), controller.DownloadInvoice)
+}
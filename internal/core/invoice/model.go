@@ -0,0 +1,48 @@
+package invoice
+
+import "time"
+
+// Formats an invoice can be downloaded in.
+const (
+	FormatPDF = "pdf"
+	FormatCSV = "csv"
+)
+
+// Invoice is the usage statement of a company for one month. Paid calls are
+// charged at the company's price for the product, or at the company's base
+// pricing for products without one. Pdf and Csv hold the rendered statement
+// and are only filled when a single invoice is fetched.
+type Invoice struct {
+	Id          uint      `json:"id"`
+	CompanyId   uint      `json:"company_id"`
+	Number      string    `json:"number"`
+	Period      string    `json:"period"`
+	Lines       []*Line   `json:"lines"`
+	TotalAmount float64   `json:"total_amount"`
+	CreatedAt   time.Time `json:"created_at"`
+	Pdf         []byte    `json:"pdf,omitempty"`
+	Csv         []byte    `json:"csv,omitempty"`
+}
+
+// Line is the usage of one product over the invoiced month.
+type Line struct {
+	ProductSlug string  `json:"product_slug"`
+	PaidCalls   int     `json:"paid_calls"`
+	FreeCalls   int     `json:"free_calls"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+type createInvoicePayload struct {
+	CompanyId   uint    `json:"company_id"`
+	Number      string  `json:"number"`
+	Period      string  `json:"period"`
+	Lines       []*Line `json:"lines"`
+	TotalAmount float64 `json:"total_amount"`
+	Pdf         []byte  `json:"pdf"`
+	Csv         []byte  `json:"csv"`
+}
+
+type generateInvoiceRequest struct {
+	Month string `json:"month" validate:"required~Month cannot be empty"`
+}
@@ -0,0 +1,78 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"front-office/pkg/utility/pdf"
+	"strconv"
+	"strings"
+)
+
+func renderCSV(inv *Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"Invoice", inv.Number},
+		{"Period", inv.Period},
+		{},
+		{"Product", "Paid Calls", "Free Calls", "Unit Price", "Amount"},
+	}
+
+	for _, line := range inv.Lines {
+		rows = append(rows, []string{
+			line.ProductSlug,
+			strconv.Itoa(line.PaidCalls),
+			strconv.Itoa(line.FreeCalls),
+			formatAmount(line.UnitPrice),
+			formatAmount(line.Amount),
+		})
+	}
+
+	rows = append(rows, []string{"Total", "", "", "", formatAmount(inv.TotalAmount)})
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderPDF(inv *Invoice, companyName string) []byte {
+	doc := pdf.New()
+	rule := strings.Repeat("-", 86)
+	row := "%-36s %10s %10s %12s %14s"
+
+	doc.AddLines(
+		"USAGE STATEMENT",
+		"",
+		fmt.Sprintf("Invoice : %s", inv.Number),
+		fmt.Sprintf("Company : %s", companyName),
+		fmt.Sprintf("Period  : %s", inv.Period),
+		"",
+		fmt.Sprintf(row, "Product", "Paid", "Free", "Unit Price", "Amount"),
+		rule,
+	)
+
+	for _, line := range inv.Lines {
+		doc.AddLine(fmt.Sprintf(row,
+			line.ProductSlug,
+			strconv.Itoa(line.PaidCalls),
+			strconv.Itoa(line.FreeCalls),
+			formatAmount(line.UnitPrice),
+			formatAmount(line.Amount),
+		))
+	}
+
+	doc.AddLines(
+		rule,
+		fmt.Sprintf(row, "Total", "", "", "", formatAmount(inv.TotalAmount)),
+	)
+
+	return doc.Bytes()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
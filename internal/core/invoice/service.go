@@ -0,0 +1,206 @@
+package invoice
+
+import (
+	"fmt"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/usage"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"sort"
+	"time"
+)
+
+func NewService(repo Repository, memberRepo member.Repository, productRepo product.Repository, usageSvc usage.Service) Service {
+	return &service{
+		repo:        repo,
+		memberRepo:  memberRepo,
+		productRepo: productRepo,
+		usageSvc:    usageSvc,
+		now:         time.Now,
+	}
+}
+
+type service struct {
+	repo        Repository
+	memberRepo  member.Repository
+	productRepo product.Repository
+	usageSvc    usage.Service
+	now         func() time.Time
+}
+
+type Service interface {
+	GenerateInvoice(companyId, memberId, month string) (*Invoice, error)
+	GetInvoices(companyId string) ([]*Invoice, error)
+	DownloadInvoice(companyId, invoiceId, format string) ([]byte, string, error)
+}
+
+// GenerateInvoice prices the billable calls a company made in month, given
+// as YYYY-MM, renders the statement as PDF and CSV and stores it. A month
+// can only be invoiced once it has ended, and only once.
+func (svc *service) GenerateInvoice(companyId, memberId, month string) (*Invoice, error) {
+	start, err := time.ParseInLocation("2006-01", month, svc.now().Location())
+	if err != nil {
+		return nil, apperror.BadRequest("month must be formatted as YYYY-MM")
+	}
+
+	end := start.AddDate(0, 1, 0)
+	if end.After(svc.now()) {
+		return nil, apperror.BadRequest("month has not ended yet")
+	}
+
+	invoices, err := svc.repo.GetInvoicesAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch invoices")
+	}
+
+	for _, inv := range invoices {
+		if inv.Period == month {
+			return nil, apperror.Conflict(fmt.Sprintf("invoice for %s already exists", month))
+		}
+	}
+
+	m, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{Id: memberId, CompanyId: companyId})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchMember)
+	}
+	if m == nil || m.MemberId == 0 {
+		return nil, apperror.NotFound(constant.UserNotFound)
+	}
+
+	prices, err := svc.productRepo.GetProductPricesAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch product pricing")
+	}
+
+	priceBySlug := make(map[string]float64, len(prices))
+	for _, p := range prices {
+		priceBySlug[p.ProductSlug] = p.Price
+	}
+
+	report, err := svc.usageSvc.GetUsage(&usage.Filter{
+		CompanyId: companyId,
+		StartDate: start.Format(constant.FormatYYYYMMDD),
+		EndDate:   end.AddDate(0, 0, -1).Format(constant.FormatYYYYMMDD),
+		Interval:  usage.IntervalMonth,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := buildLines(report.Rows, priceBySlug, m.MstCompany.BasePricing)
+
+	inv := &Invoice{
+		CompanyId:   m.CompanyId,
+		Number:      fmt.Sprintf("INV/%s/%s", start.Format("200601"), companyId),
+		Period:      month,
+		Lines:       lines,
+		TotalAmount: totalAmount(lines),
+	}
+
+	csvBytes, err := renderCSV(inv)
+	if err != nil {
+		return nil, apperror.Internal("failed to write CSV", err)
+	}
+
+	result, err := svc.repo.CreateInvoiceAPI(&createInvoicePayload{
+		CompanyId:   inv.CompanyId,
+		Number:      inv.Number,
+		Period:      inv.Period,
+		Lines:       inv.Lines,
+		TotalAmount: inv.TotalAmount,
+		Pdf:         renderPDF(inv, m.MstCompany.CompanyName),
+		Csv:         csvBytes,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to store invoice")
+	}
+
+	result.Pdf, result.Csv = nil, nil
+
+	return result, nil
+}
+
+func (svc *service) GetInvoices(companyId string) ([]*Invoice, error) {
+	result, err := svc.repo.GetInvoicesAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch invoices")
+	}
+
+	for _, inv := range result {
+		inv.Pdf, inv.Csv = nil, nil
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Period > result[j].Period
+	})
+
+	return result, nil
+}
+
+// DownloadInvoice returns the stored statement in format with its file name.
+func (svc *service) DownloadInvoice(companyId, invoiceId, format string) ([]byte, string, error) {
+	inv, err := svc.repo.GetInvoiceAPI(companyId, invoiceId)
+	if err != nil {
+		return nil, "", apperror.MapRepoError(err, "failed to fetch invoice")
+	}
+
+	if inv == nil || helper.ConvertUintToString(inv.CompanyId) != companyId {
+		return nil, "", apperror.NotFound("invoice not found")
+	}
+
+	filename := fmt.Sprintf("invoice_%s.%s", inv.Period, format)
+
+	switch format {
+	case FormatPDF:
+		return inv.Pdf, filename, nil
+	case FormatCSV:
+		return inv.Csv, filename, nil
+	}
+
+	return nil, "", apperror.BadRequest(fmt.Sprintf("unsupported format %s", format))
+}
+
+// buildLines totals usage rows by product and prices their paid calls.
+// Products without a company price fall back to basePricing.
+func buildLines(rows []*usage.Row, prices map[string]float64, basePricing float64) []*Line {
+	bySlug := map[string]*Line{}
+	var lines []*Line
+
+	for _, row := range rows {
+		line, ok := bySlug[row.ProductSlug]
+		if !ok {
+			price, priced := prices[row.ProductSlug]
+			if !priced {
+				price = basePricing
+			}
+
+			line = &Line{ProductSlug: row.ProductSlug, UnitPrice: price}
+			bySlug[row.ProductSlug] = line
+			lines = append(lines, line)
+		}
+
+		line.PaidCalls += row.Paid
+		line.FreeCalls += row.Free
+	}
+
+	for _, line := range lines {
+		line.Amount = float64(line.PaidCalls) * line.UnitPrice
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].ProductSlug < lines[j].ProductSlug
+	})
+
+	return lines
+}
+
+func totalAmount(lines []*Line) float64 {
+	total := 0.0
+	for _, line := range lines {
+		total += line.Amount
+	}
+
+	return total
+}
@@ -0,0 +1,36 @@
+package invoice
+
+import (
+	"front-office/internal/core/usage"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLines(t *testing.T) {
+	rows := []*usage.Row{
+		{ProductSlug: "b", MemberId: 1, Paid: 2, Free: 1},
+		{ProductSlug: "a", MemberId: 1, Paid: 3},
+		{ProductSlug: "b", MemberId: 2, Paid: 1},
+	}
+
+	result := buildLines(rows, map[string]float64{"b": 1000}, 500)
+
+	assert.Equal(t, []*Line{
+		{ProductSlug: "a", PaidCalls: 3, UnitPrice: 500, Amount: 1500},
+		{ProductSlug: "b", PaidCalls: 3, FreeCalls: 1, UnitPrice: 1000, Amount: 3000},
+	}, result)
+	assert.Equal(t, float64(4500), totalAmount(result))
+}
+
+func TestRenderCSV(t *testing.T) {
+	result, err := renderCSV(&Invoice{
+		Number:      "INV/202401/1",
+		Period:      "2024-01",
+		Lines:       []*Line{{ProductSlug: "a", PaidCalls: 3, UnitPrice: 500, Amount: 1500}},
+		TotalAmount: 1500,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Invoice,INV/202401/1\nPeriod,2024-01\n\nProduct,Paid Calls,Free Calls,Unit Price,Amount\na,3,0,500.00,1500.00\nTotal,,,,1500.00\n", string(result))
+}
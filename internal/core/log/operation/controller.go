@@ -29,6 +29,8 @@ func (ctrl *controller) GetList(c *fiber.Ctx) error {
 	role := strings.ToLower(c.Query("role"))
 	eventQuery := c.Query("event")
 	name := strings.ToLower(c.Query("name", ""))
+	productSlug := c.Query("product_slug")
+	jobId := c.Query(constant.JobId)
 	startDate := c.Query(constant.StartDate)
 	endDate := c.Query(constant.EndDate)
 
@@ -38,14 +40,16 @@ func (ctrl *controller) GetList(c *fiber.Ctx) error {
 	}
 
 	filter := &LogOperationFilter{
-		CompanyId: companyId,
-		Page:      page,
-		Size:      size,
-		Role:      role,
-		Event:     mappedEvent,
-		Name:      name,
-		StartDate: startDate,
-		EndDate:   endDate,
+		CompanyId:   companyId,
+		Page:        page,
+		Size:        size,
+		Role:        role,
+		Event:       mappedEvent,
+		Name:        name,
+		ProductSlug: productSlug,
+		JobId:       jobId,
+		StartDate:   startDate,
+		EndDate:     endDate,
 	}
 
 	result, err := ctrl.svc.GetLogsOperation(filter)
@@ -100,6 +104,8 @@ func mapEventKeyword(input string) (string, bool) {
 		"change_billing_information":  constant.EventChangeBillingInformation,
 		"topup_balance":               constant.EventTopupBalance,
 		"submit_payment_confirmation": constant.EventSubmitPaymentConfirmation,
+		"export_data":                 constant.EventExportData,
+		"view_unmasked_data":          constant.EventViewUnmaskedData,
 	}
 
 	normalized := strings.ToLower(strings.ReplaceAll(input, " ", "_"))
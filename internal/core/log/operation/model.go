@@ -6,14 +6,26 @@ import (
 )
 
 type LogOperation struct {
-	LogOpsID  uint      `json:"log_ops_id" gorm:"primaryKey;autoIncrement"`
-	MemberId  uint      `json:"member_id"`
-	Member    MstMember `json:"member" gorm:"foreignKey:MemberId"`
-	CompanyId uint      `json:"company_id"`
-	Module    string    `json:"module"`
-	Action    string    `json:"action"`
-	ClientIP  string    `json:"ip_address"`
-	CreatedAt time.Time `json:"created_at"`
+	LogOpsID  uint          `json:"log_ops_id" gorm:"primaryKey;autoIncrement"`
+	MemberId  uint          `json:"member_id"`
+	Member    MstMember     `json:"member" gorm:"foreignKey:MemberId"`
+	CompanyId uint          `json:"company_id"`
+	Module    string        `json:"module"`
+	Action    string        `json:"action"`
+	ClientIP  string        `json:"ip_address"`
+	Detail    *AccessDetail `json:"detail,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// AccessDetail records what data an export or unmasked view exposed. Rows is
+// only counted for CSV exports.
+type AccessDetail struct {
+	ProductSlug string `json:"product_slug,omitempty"`
+	JobId       string `json:"job_id,omitempty"`
+	StartDate   string `json:"start_date,omitempty"`
+	EndDate     string `json:"end_date,omitempty"`
+	Masked      *bool  `json:"masked,omitempty"`
+	Rows        int    `json:"rows"`
 }
 
 type MstMember struct {
@@ -29,14 +41,16 @@ type MstRole struct {
 }
 
 type LogOperationFilter struct {
-	CompanyId string
-	Page      string
-	Size      string
-	Role      string
-	Event     string
-	Name      string
-	StartDate string
-	EndDate   string
+	CompanyId   string
+	Page        string
+	Size        string
+	Role        string
+	Event       string
+	Name        string
+	ProductSlug string
+	JobId       string
+	StartDate   string
+	EndDate     string
 }
 
 type LogRangeFilter struct {
@@ -48,9 +62,11 @@ type LogRangeFilter struct {
 }
 
 type AddLogRequest struct {
-	MemberId  uint   `json:"member_id" validate:"required~Field Member ID is required"`
-	CompanyId uint   `json:"company_id" validate:"required~Field Company ID is required"`
-	Action    string `json:"action" validate:"required~Field Action is required"`
+	MemberId  uint          `json:"member_id" validate:"required~Field Member ID is required"`
+	CompanyId uint          `json:"company_id" validate:"required~Field Company ID is required"`
+	Action    string        `json:"action" validate:"required~Field Action is required"`
+	ClientIP  string        `json:"ip_address,omitempty"`
+	Detail    *AccessDetail `json:"detail,omitempty"`
 }
 
 type logOperationAPIResponse struct {
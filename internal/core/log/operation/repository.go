@@ -53,6 +53,8 @@ func (repo *repository) GetLogsOperationAPI(filter *LogOperationFilter) (*model.
 	q.Add("name", filter.Name)
 	q.Add("role", filter.Role)
 	q.Add("event", filter.Event)
+	q.Add("product_slug", filter.ProductSlug)
+	q.Add(constant.JobId, filter.JobId)
 	q.Add(constant.StartDate, filter.StartDate)
 	q.Add(constant.EndDate, filter.EndDate)
 	req.URL.RawQuery = q.Encode()
@@ -43,6 +43,8 @@ type LogTransProductCatalog struct {
 	Message         string         `json:"message"`
 	PricingStrategy string         `json:"pricing_strategy"` //PAY , FREE
 	Notes           string         `json:"notes"`
+	RowNumber       int            `json:"row_number"`
+	FileName        string         `json:"file_name"`
 	Duration        time.Duration  `json:"duration" format:"duration" example:"2h30m"`
 	RequestTime     time.Time      `json:"request_time" format:"date-time"`
 	ResponseTime    time.Time      `json:"response_time" format:"date-time"`
@@ -65,6 +67,8 @@ type LogTransProCatRequest struct {
 	Message         string        `json:"message"`
 	PricingStrategy string        `json:"pricing_strategy"` //PAY , FREE
 	Notes           string        `json:"notes"`
+	RowNumber       int           `json:"row_number,omitempty"` // source CSV row, header is row 1
+	FileName        string        `json:"file_name,omitempty"`
 	Duration        time.Duration `json:"duration" format:"duration" example:"2h30m"`
 	RequestTime     time.Time     `json:"request_time" format:"date-time"`
 	ResponseTime    time.Time     `json:"response_time" format:"date-time"`
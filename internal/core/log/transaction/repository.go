@@ -36,6 +36,7 @@ type Repository interface {
 	// product catalog
 	CreateLogTransAPI(req *LogTransProCatRequest) error
 	GetLogTransByJobIdAPI(jobId, companyId string) ([]*LogTransProductCatalog, error)
+	GetLogsTransByDateRangeAPI(companyId, startDate, endDate string) ([]*LogTransProductCatalog, error)
 	ProcessedLogCountAPI(jobId string) (*getProcessedCountResp, error)
 	UpdateLogTransAPI(transId string, req map[string]interface{}) error
 }
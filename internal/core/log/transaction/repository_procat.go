@@ -94,6 +94,40 @@ func (repo *repository) GetLogTransByJobIdAPI(jobId, companyId string) ([]*LogTr
 	return apiResp.Data, nil
 }
 
+func (repo *repository) GetLogsTransByDateRangeAPI(companyId, startDate, endDate string) ([]*LogTransProductCatalog, error) {
+	url := fmt.Sprintf("%s/api/core/logging/transaction/product-catalog/range", repo.cfg.Env.AifcoreHost)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XCompanyId, companyId)
+
+	q := req.URL.Query()
+	q.Add("date_start", startDate)
+	q.Add("date_end", endDate)
+	q.Add("company_id", companyId)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[[]*LogTransProductCatalog](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
 func (repo *repository) UpdateLogTransAPI(transId string, payload map[string]interface{}) error {
 	url := fmt.Sprintf("%s/api/core/logging/transaction/product-catalog/%s", repo.cfg.Env.AifcoreHost, transId)
 
@@ -80,6 +80,58 @@ func TestGetLogTransByJobIdAPI(t *testing.T) {
 	})
 }
 
+func TestGetLogsTransByDateRangeAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		mockData := model.AifcoreAPIResponse[[]*LogTransProductCatalog]{
+			Success: true,
+			Data:    []*LogTransProductCatalog{{ProductID: 1, PricingStrategy: constant.PricingStrategyPay}},
+		}
+		body, err := json.Marshal(mockData)
+		require.NoError(t, err)
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}
+
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetLogsTransByDateRangeAPI(constant.DummyCompanyId, "2024-01-01", "2024-01-31")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		expectedErr := errors.New(constant.ErrHTTPReqFailed)
+
+		repo, mockClient := setupMockRepo(t, nil, expectedErr)
+
+		result, err := repo.GetLogsTransByDateRangeAPI(constant.DummyCompanyId, "2024-01-01", "2024-01-31")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetLogsTransByDateRangeAPI(constant.DummyCompanyId, "2024-01-01", "2024-01-31")
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestProcessedLogCountAPI(t *testing.T) {
 	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
 		mockData := model.AifcoreAPIResponse[*getProcessedCountResp]{
@@ -0,0 +1,55 @@
+package masking
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	GetPolicy(c *fiber.Ctx) error
+	SavePolicy(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) GetPolicy(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetPolicy(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get masking policy",
+		result,
+	))
+}
+
+func (ctrl *controller) SavePolicy(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*policyRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	if err := ctrl.svc.SavePolicy(companyId, reqBody); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to save masking policy",
+		nil,
+	))
+}
@@ -0,0 +1,20 @@
+package masking
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/role"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	roleRepo := role.NewRepository(cfg, client)
+	service := NewService(repository, roleRepo)
+	controller := NewController(service)
+
+	apiGroup.Get("/policy", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetPolicy)
+	apiGroup.Put("/policy", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(policyRequest{}), controller.SavePolicy)
+}
@@ -0,0 +1,41 @@
+package masking
+
+import (
+	"fmt"
+	"front-office/pkg/common/constant"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Resolve decides with the company's masking policy whether the request sees
+// personal data masked, taking the masked query parameter into account only
+// where allowed. It must come after middleware.GetJWTPayloadFromCookie.
+func Resolve(svc Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var requested *bool
+		if v, err := strconv.ParseBool(c.Query("masked")); err == nil {
+			requested = &v
+		}
+
+		masked, err := svc.ShouldMask(
+			fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+			fmt.Sprintf("%v", c.Locals(constant.RoleId)),
+			requested,
+		)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(constant.Masked, masked)
+
+		return c.Next()
+	}
+}
+
+// IsMasked reports whether Resolve decided to mask the request. Requests it
+// did not see are masked.
+func IsMasked(c *fiber.Ctx) bool {
+	masked, ok := c.Locals(constant.Masked).(bool)
+	return !ok || masked
+}
@@ -0,0 +1,22 @@
+package masking
+
+// PermissionViewUnmasked is the role permission needed to see personal data
+// unmasked.
+const PermissionViewUnmasked = "view-unmasked-data"
+
+// Policy is how a company masks personal data. MaskByDefault applies when the
+// request does not ask otherwise; AllowOverride lets members permitted to see
+// unmasked data choose per request with the masked query parameter.
+type Policy struct {
+	CompanyId     uint `json:"company_id"`
+	MaskByDefault bool `json:"mask_by_default"`
+	AllowOverride bool `json:"allow_override"`
+}
+
+type policyRequest struct {
+	MaskByDefault *bool `json:"mask_by_default" validate:"required~Field mask by default is required"`
+	AllowOverride *bool `json:"allow_override" validate:"required~Field allow override is required"`
+}
+
+// defaultPolicy applies to companies that never set a policy.
+var defaultPolicy = Policy{MaskByDefault: true, AllowOverride: true}
@@ -0,0 +1,125 @@
+package masking
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallGetPolicyAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Policy{CompanyId: 1, AllowOverride: true}), nil)
+
+		result, err := repo.GetPolicyAPI(constant.DummyCompanyId)
+
+		assert.NoError(t, err)
+		assert.True(t, result.AllowOverride)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetPolicyAPI(constant.DummyCompanyId)
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetPolicyAPI(constant.DummyCompanyId)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallSavePolicyAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.SavePolicyAPI(constant.DummyCompanyId, &Policy{MaskByDefault: true})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		err := repo.SavePolicyAPI(constant.DummyCompanyId, &Policy{})
+
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.SavePolicyAPI(constant.DummyCompanyId, &Policy{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
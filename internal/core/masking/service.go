@@ -0,0 +1,94 @@
+package masking
+
+import (
+	"front-office/internal/core/role"
+	"front-office/pkg/apperror"
+	"strconv"
+)
+
+func NewService(repo Repository, roleRepo role.Repository) Service {
+	return &service{repo, roleRepo}
+}
+
+type service struct {
+	repo     Repository
+	roleRepo role.Repository
+}
+
+type Service interface {
+	ShouldMask(companyId, roleId string, requested *bool) (bool, error)
+	GetPolicy(companyId string) (*Policy, error)
+	SavePolicy(companyId string, req *policyRequest) error
+}
+
+// ShouldMask decides whether personal data is masked for a member of the
+// role. requested is what the client asked for, nil when it did not ask.
+func (svc *service) ShouldMask(companyId, roleId string, requested *bool) (bool, error) {
+	r, err := svc.roleRepo.GetRoleByIdAPI(roleId)
+	if err != nil {
+		return true, apperror.MapRepoError(err, "failed to fetch role")
+	}
+
+	policy, err := svc.GetPolicy(companyId)
+	if err != nil {
+		return true, err
+	}
+
+	return decide(hasPermission(r, PermissionViewUnmasked), policy, requested), nil
+}
+
+func (svc *service) GetPolicy(companyId string) (*Policy, error) {
+	policy, err := svc.repo.GetPolicyAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch masking policy")
+	}
+
+	if policy == nil {
+		p := defaultPolicy
+		if id, err := strconv.ParseUint(companyId, 10, 64); err == nil {
+			p.CompanyId = uint(id)
+		}
+		return &p, nil
+	}
+
+	return policy, nil
+}
+
+func (svc *service) SavePolicy(companyId string, req *policyRequest) error {
+	if err := svc.repo.SavePolicyAPI(companyId, &Policy{
+		MaskByDefault: *req.MaskByDefault,
+		AllowOverride: *req.AllowOverride,
+	}); err != nil {
+		return apperror.MapRepoError(err, "failed to save masking policy")
+	}
+
+	return nil
+}
+
+// decide masks always for members not permitted to see unmasked data. Others
+// get what they asked for when the policy allows it, else the policy default.
+func decide(permitted bool, policy *Policy, requested *bool) bool {
+	if !permitted {
+		return true
+	}
+
+	if requested != nil && policy.AllowOverride {
+		return *requested
+	}
+
+	return policy.MaskByDefault
+}
+
+func hasPermission(r *role.MstRole, slug string) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, p := range r.Permissions {
+		if p.Slug == slug {
+			return true
+		}
+	}
+
+	return false
+}
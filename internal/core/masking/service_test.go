@@ -0,0 +1,72 @@
+package masking
+
+import (
+	"front-office/internal/core/role"
+	"front-office/pkg/common/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetPolicyAPI(companyId string) (*Policy, error) {
+	args := m.Called(companyId)
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) SavePolicyAPI(companyId string, policy *Policy) error {
+	args := m.Called(companyId, policy)
+	return args.Error(0)
+}
+
+type MockRoleRepository struct {
+	role.Repository
+	mock.Mock
+}
+
+func (m *MockRoleRepository) GetRoleByIdAPI(id string) (*role.MstRole, error) {
+	args := m.Called(id)
+	return args.Get(0).(*role.MstRole), args.Error(1)
+}
+
+func TestShouldMask(t *testing.T) {
+	unmasked := false
+	permitted := &role.MstRole{Permissions: []role.MstPermission{{Slug: PermissionViewUnmasked}}}
+
+	t.Run("should ignore the request of members without permission", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetPolicyAPI", constant.DummyCompanyId).Return(&Policy{AllowOverride: true}, nil)
+		roleRepo := new(MockRoleRepository)
+		roleRepo.On("GetRoleByIdAPI", "2").Return(&role.MstRole{}, nil)
+
+		masked, err := NewService(repo, roleRepo).ShouldMask(constant.DummyCompanyId, "2", &unmasked)
+
+		assert.NoError(t, err)
+		assert.True(t, masked)
+	})
+
+	t.Run("should follow the request of permitted members when allowed", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetPolicyAPI", constant.DummyCompanyId).Return((*Policy)(nil), nil)
+		roleRepo := new(MockRoleRepository)
+		roleRepo.On("GetRoleByIdAPI", "1").Return(permitted, nil)
+
+		masked, err := NewService(repo, roleRepo).ShouldMask(constant.DummyCompanyId, "1", &unmasked)
+
+		assert.NoError(t, err)
+		assert.False(t, masked)
+	})
+}
+
+func TestDecide(t *testing.T) {
+	unmasked := false
+
+	assert.True(t, decide(false, &Policy{MaskByDefault: false}, nil))
+	assert.False(t, decide(true, &Policy{MaskByDefault: false}, nil))
+	assert.True(t, decide(true, &Policy{MaskByDefault: true, AllowOverride: false}, &unmasked))
+	assert.False(t, decide(true, &Policy{MaskByDefault: true, AllowOverride: true}, &unmasked))
+}
@@ -6,3 +6,10 @@ type productResponseData struct {
 	ProductName    string `json:"product_name"`
 	ProductSlug    string `json:"product_slug_name"`
 }
+
+// productPriceData is the price a company pays per billable call of a product.
+type productPriceData struct {
+	ProductId   uint    `json:"product_id"`
+	ProductSlug string  `json:"product_slug_name"`
+	Price       float64 `json:"price"`
+}
@@ -25,6 +25,7 @@ type repository struct {
 
 type Repository interface {
 	GetProductAPI(slug string) (*productResponseData, error)
+	GetProductPricesAPI(companyId string) ([]*productPriceData, error)
 }
 
 func (repo *repository) GetProductAPI(slug string) (*productResponseData, error) {
@@ -53,3 +54,31 @@ func (repo *repository) GetProductAPI(slug string) (*productResponseData, error)
 
 	return apiResp.Data, nil
 }
+
+func (repo *repository) GetProductPricesAPI(companyId string) ([]*productPriceData, error) {
+	url := fmt.Sprintf("%s/api/core/product/pricing", repo.cfg.Env.AifcoreHost)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XCompanyId, companyId)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[[]*productPriceData](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
@@ -0,0 +1,71 @@
+package quota
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	GetOwnRemaining(c *fiber.Ctx) error
+	GetMemberRemaining(c *fiber.Ctx) error
+	SetProductQuota(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) GetOwnRemaining(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+
+	result, err := ctrl.svc.GetRemaining(companyId, memberId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get remaining quota",
+		result,
+	))
+}
+
+func (ctrl *controller) GetMemberRemaining(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetRemaining(companyId, c.Params("member_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get remaining quota",
+		result,
+	))
+}
+
+func (ctrl *controller) SetProductQuota(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*productQuotaRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	if err := ctrl.svc.SetProductQuota(companyId, c.Params("member_id"), c.Params("product_id"), *reqBody.Quota); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to set product quota",
+		nil,
+	))
+}
@@ -0,0 +1,21 @@
+package quota
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/member"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	service := NewService(repository, memberRepo)
+	controller := NewController(service)
+
+	apiGroup.Get("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetOwnRemaining)
+	apiGroup.Get("/members/:member_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetMemberRemaining)
+	apiGroup.Put("/members/:member_id/products/:product_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(productQuotaRequest{}), controller.SetProductQuota)
+}
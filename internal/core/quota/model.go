@@ -0,0 +1,66 @@
+package quota
+
+// Quota types of a member, as stored in member.MstMember.QuotaType.
+const (
+	TypeNone       int8 = 0
+	TypeTotal      int8 = 1
+	TypePerProduct int8 = 2
+)
+
+// Usage is a member's ledger for one product. Reserved counts rows of
+// unfinished jobs not yet charged or released. Quota is the product's limit
+// and only applies to members with a per product quota.
+type Usage struct {
+	MemberId  uint `json:"member_id"`
+	ProductId uint `json:"product_id"`
+	Quota     int  `json:"quota"`
+	Used      int  `json:"used"`
+	Reserved  int  `json:"reserved"`
+}
+
+// usagePayload moves a member's ledger by the given deltas.
+type usagePayload struct {
+	MemberId  uint `json:"member_id"`
+	CompanyId uint `json:"company_id"`
+	ProductId uint `json:"product_id"`
+	JobId     uint `json:"job_id"`
+	Reserved  int  `json:"reserved"`
+	Used      int  `json:"used"`
+}
+
+// Reservation is quota held for a job about to be created. The job takes it
+// over when it is created with the reservation's Id.
+type Reservation struct {
+	Id string `json:"id"`
+}
+
+// reservePayload holds Reserved units of a member's quota. The ledger refuses
+// it when the units used and reserved, summed over every product for a total
+// quota or for the product alone for a per product quota, would exceed Quota.
+// Members with QuotaType TypeNone are not limited.
+type reservePayload struct {
+	MemberId  uint `json:"member_id"`
+	CompanyId uint `json:"company_id"`
+	ProductId uint `json:"product_id"`
+	Reserved  int  `json:"reserved"`
+	QuotaType int8 `json:"quota_type"`
+	Quota     int  `json:"quota"`
+}
+
+// Remaining is what is left of a member's quota. ProductId is empty for a
+// total quota, which is shared by every product, and Unlimited is set for
+// members without a quota.
+type Remaining struct {
+	MemberId  uint `json:"member_id"`
+	QuotaType int8 `json:"quota_type"`
+	ProductId uint `json:"product_id,omitempty"`
+	Quota     int  `json:"quota"`
+	Used      int  `json:"used"`
+	Reserved  int  `json:"reserved"`
+	Remaining int  `json:"remaining"`
+	Unlimited bool `json:"unlimited"`
+}
+
+type productQuotaRequest struct {
+	Quota *int `json:"quota" validate:"required~Quota cannot be empty"`
+}
@@ -0,0 +1,225 @@
+package quota
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallGetUsagesAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Usage{{ProductId: 1, Used: 3}}), nil)
+
+		result, err := repo.GetUsagesAPI(constant.DummyMemberId)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 3, result[0].Used)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetUsagesAPI(constant.DummyMemberId)
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetUsagesAPI(constant.DummyMemberId)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallReserveAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Reservation{Id: "r-1"}), nil)
+
+		result, err := repo.ReserveAPI(&reservePayload{MemberId: 1, Reserved: 5, QuotaType: TypeTotal, Quota: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "r-1", result.Id)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a reservation the quota cannot cover", func(t *testing.T) {
+		body, err := json.Marshal(model.AifcoreAPIResponse[any]{Message: "quota exhausted"})
+		require.NoError(t, err)
+
+		repo, mockClient := setupMockRepo(t, &http.Response{
+			StatusCode: http.StatusForbidden,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil)
+
+		_, err = repo.ReserveAPI(&reservePayload{MemberId: 1, Reserved: 5, QuotaType: TypeTotal, Quota: 10})
+
+		var apiErr *apperror.ExternalAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		_, err := repo.ReserveAPI(&reservePayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallCancelReservationAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.CancelReservationAPI("r-1")
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.CancelReservationAPI("r-1")
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallUpdateUsageAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.UpdateUsageAPI(&usagePayload{MemberId: 1, Reserved: -1, Used: 1})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		err := repo.UpdateUsageAPI(&usagePayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.UpdateUsageAPI(&usagePayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallReleaseJobAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.ReleaseJobAPI("1")
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.ReleaseJobAPI("1")
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallSetProductQuotaAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.SetProductQuotaAPI(constant.DummyMemberId, "1", 100)
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.SetProductQuotaAPI(constant.DummyMemberId, "1", 100)
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
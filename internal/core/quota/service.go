@@ -0,0 +1,255 @@
+package quota
+
+import (
+	"fmt"
+	"front-office/internal/core/member"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"strconv"
+	"time"
+)
+
+const (
+	writeAttempts = 3
+	writeBackoff  = 200 * time.Millisecond
+)
+
+func NewService(repo Repository, memberRepo member.Repository) Service {
+	return &service{
+		repo:       repo,
+		memberRepo: memberRepo,
+		sleep:      time.Sleep,
+	}
+}
+
+type service struct {
+	repo       Repository
+	memberRepo member.Repository
+	sleep      func(time.Duration)
+}
+
+type Service interface {
+	Reserve(memberId, companyId string, productId uint, count int) (*Reservation, error)
+	CancelReservation(reservationId string) error
+	Record(memberId, companyId string, productId, jobId uint, calls, paid int) error
+	Release(jobId string) error
+	GetRemaining(companyId, memberId string) ([]*Remaining, error)
+	SetProductQuota(companyId, memberId, productId string, quota int) error
+}
+
+// Reserve holds count units of the member's quota for a job about to be
+// created, refusing when the member's quota settings leave fewer. Units
+// reserved by unfinished jobs count as spent. The ledger applies the same
+// limit when it takes the reservation, so concurrent jobs cannot both take
+// the last units.
+func (svc *service) Reserve(memberId, companyId string, productId uint, count int) (*Reservation, error) {
+	m, err := svc.getMember(memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	companyIdUint, _ := strconv.ParseUint(companyId, 10, 64)
+	payload := &reservePayload{
+		MemberId:  m.MemberId,
+		CompanyId: uint(companyIdUint),
+		ProductId: productId,
+		Reserved:  count,
+		QuotaType: m.QuotaType,
+	}
+
+	if m.QuotaType != TypeNone {
+		usages, err := svc.repo.GetUsagesAPI(memberId)
+		if err != nil {
+			return nil, apperror.MapRepoError(err, "failed to fetch quota usage")
+		}
+
+		left := &Remaining{}
+		for _, r := range remaining(m, usages) {
+			if r.ProductId == 0 || r.ProductId == productId {
+				left = r
+				break
+			}
+		}
+
+		if count > left.Remaining {
+			return nil, apperror.Forbidden(fmt.Sprintf("quota exhausted: %d requests remaining, %d requested", left.Remaining, count))
+		}
+		payload.Quota = left.Quota
+	}
+
+	reservation, err := svc.repo.ReserveAPI(payload)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to reserve quota")
+	}
+
+	return reservation, nil
+}
+
+// CancelReservation gives back a reservation no job was created with.
+func (svc *service) CancelReservation(reservationId string) error {
+	if err := svc.retry(func() error { return svc.repo.CancelReservationAPI(reservationId) }); err != nil {
+		return apperror.MapRepoError(err, "failed to cancel quota reservation")
+	}
+
+	return nil
+}
+
+// Record settles the calls reserved by one row of a job: paid calls are
+// charged to the quota, free and failed calls are given back. It must only be
+// called for rows of a job whose reservation succeeded.
+func (svc *service) Record(memberId, companyId string, productId, jobId uint, calls, paid int) error {
+	payload := newUsagePayload(memberId, companyId, productId, jobId)
+	payload.Reserved = -calls
+	payload.Used = paid
+
+	if err := svc.retry(func() error { return svc.repo.UpdateUsageAPI(payload) }); err != nil {
+		return apperror.MapRepoError(err, "failed to record quota usage")
+	}
+
+	return nil
+}
+
+// Release gives back the rows a finished job still holds, such as the rows
+// of a job that failed part way.
+func (svc *service) Release(jobId string) error {
+	if err := svc.retry(func() error { return svc.repo.ReleaseJobAPI(jobId) }); err != nil {
+		return apperror.MapRepoError(err, "failed to release quota reservation")
+	}
+
+	return nil
+}
+
+func (svc *service) GetRemaining(companyId, memberId string) ([]*Remaining, error) {
+	m, err := svc.getCompanyMember(companyId, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := svc.repo.GetUsagesAPI(memberId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch quota usage")
+	}
+
+	return remaining(m, usages), nil
+}
+
+func (svc *service) SetProductQuota(companyId, memberId, productId string, quota int) error {
+	if quota < 0 {
+		return apperror.BadRequest("quota cannot be negative")
+	}
+
+	if _, err := svc.getCompanyMember(companyId, memberId); err != nil {
+		return err
+	}
+
+	if err := svc.repo.SetProductQuotaAPI(memberId, productId, quota); err != nil {
+		return apperror.MapRepoError(err, "failed to set product quota")
+	}
+
+	return nil
+}
+
+// retry runs a ledger write until it succeeds or writeAttempts is reached,
+// doubling the wait between attempts. A lost write leaves usage out of step
+// with the reservations.
+func (svc *service) retry(write func() error) error {
+	backoff := writeBackoff
+
+	var err error
+	for attempt := 1; attempt <= writeAttempts; attempt++ {
+		if err = write(); err == nil {
+			return nil
+		}
+
+		if attempt < writeAttempts {
+			svc.sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return err
+}
+
+func newUsagePayload(memberId, companyId string, productId, jobId uint) *usagePayload {
+	memberIdUint, _ := strconv.ParseUint(memberId, 10, 64)
+	companyIdUint, _ := strconv.ParseUint(companyId, 10, 64)
+
+	return &usagePayload{
+		MemberId:  uint(memberIdUint),
+		CompanyId: uint(companyIdUint),
+		ProductId: productId,
+		JobId:     jobId,
+	}
+}
+
+func (svc *service) getMember(memberId string) (*member.MstMember, error) {
+	m, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{Id: memberId})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchMember)
+	}
+
+	if m == nil || m.MemberId == 0 {
+		return nil, apperror.NotFound(constant.UserNotFound)
+	}
+
+	return m, nil
+}
+
+func (svc *service) getCompanyMember(companyId, memberId string) (*member.MstMember, error) {
+	m, err := svc.getMember(memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	if helper.ConvertUintToString(m.CompanyId) != companyId {
+		return nil, apperror.NotFound(constant.UserNotFound)
+	}
+
+	return m, nil
+}
+
+// remaining works out a member's quota from its ledger. A total quota is
+// shared by every product and yields one entry without a product; a per
+// product quota yields one entry per product with a limit. Members without a
+// quota get one unlimited entry.
+func remaining(m *member.MstMember, usages []*Usage) []*Remaining {
+	if m.QuotaType == TypePerProduct {
+		result := make([]*Remaining, 0, len(usages))
+		for _, u := range usages {
+			result = append(result, newRemaining(m, u.ProductId, u.Quota, u.Used, u.Reserved))
+		}
+
+		return result
+	}
+
+	used, reserved := 0, 0
+	for _, u := range usages {
+		used += u.Used
+		reserved += u.Reserved
+	}
+
+	r := newRemaining(m, 0, m.Quota, used, reserved)
+	if m.QuotaType == TypeNone {
+		r.Quota, r.Remaining, r.Unlimited = 0, 0, true
+	}
+
+	return []*Remaining{r}
+}
+
+func newRemaining(m *member.MstMember, productId uint, quota, used, reserved int) *Remaining {
+	left := quota - used - reserved
+	if left < 0 {
+		left = 0
+	}
+
+	return &Remaining{
+		MemberId:  m.MemberId,
+		QuotaType: m.QuotaType,
+		ProductId: productId,
+		Quota:     quota,
+		Used:      used,
+		Reserved:  reserved,
+		Remaining: left,
+	}
+}
@@ -0,0 +1,159 @@
+package quota
+
+import (
+	"errors"
+	"front-office/internal/core/member"
+	"front-office/pkg/apperror"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRepository struct {
+	Repository
+	mock.Mock
+}
+
+func (m *MockRepository) GetUsagesAPI(memberId string) ([]*Usage, error) {
+	args := m.Called(memberId)
+	return args.Get(0).([]*Usage), args.Error(1)
+}
+
+func (m *MockRepository) ReserveAPI(payload *reservePayload) (*Reservation, error) {
+	args := m.Called(payload)
+	return args.Get(0).(*Reservation), args.Error(1)
+}
+
+func (m *MockRepository) UpdateUsageAPI(payload *usagePayload) error {
+	args := m.Called(payload)
+	return args.Error(0)
+}
+
+type MockMemberRepository struct {
+	member.Repository
+	mock.Mock
+}
+
+func (m *MockMemberRepository) GetMemberAPI(query *member.FindUserQuery) (*member.MstMember, error) {
+	args := m.Called(query)
+	return args.Get(0).(*member.MstMember), args.Error(1)
+}
+
+func newTestService(repo *MockRepository, memberRepo *MockMemberRepository, slept *[]time.Duration) *service {
+	return &service{
+		repo:       repo,
+		memberRepo: memberRepo,
+		sleep:      func(d time.Duration) { *slept = append(*slept, d) },
+	}
+}
+
+func TestReserve(t *testing.T) {
+	query := &member.FindUserQuery{Id: "1"}
+
+	t.Run("should reserve within the member's quota with its limit", func(t *testing.T) {
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 1, QuotaType: TypeTotal, Quota: 10}, nil)
+		repo := new(MockRepository)
+		repo.On("GetUsagesAPI", "1").Return([]*Usage{{ProductId: 3, Used: 4, Reserved: 2}}, nil)
+		repo.On("ReserveAPI", &reservePayload{MemberId: 1, CompanyId: 2, ProductId: 3, Reserved: 4, QuotaType: TypeTotal, Quota: 10}).
+			Return(&Reservation{Id: "r-1"}, nil)
+
+		var slept []time.Duration
+		result, err := newTestService(repo, memberRepo, &slept).Reserve("1", "2", 3, 4)
+
+		require.NoError(t, err)
+		assert.Equal(t, "r-1", result.Id)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse more than the member's quota leaves", func(t *testing.T) {
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 1, QuotaType: TypePerProduct}, nil)
+		repo := new(MockRepository)
+		repo.On("GetUsagesAPI", "1").Return([]*Usage{{ProductId: 3, Quota: 5, Used: 4}}, nil)
+
+		var slept []time.Duration
+		_, err := newTestService(repo, memberRepo, &slept).Reserve("1", "2", 3, 2)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+		repo.AssertNotCalled(t, "ReserveAPI", mock.Anything)
+	})
+
+	t.Run("should not limit members without a quota", func(t *testing.T) {
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 1, QuotaType: TypeNone}, nil)
+		repo := new(MockRepository)
+		repo.On("ReserveAPI", mock.Anything).Return(&Reservation{Id: "r-2"}, nil)
+
+		var slept []time.Duration
+		_, err := newTestService(repo, memberRepo, &slept).Reserve("1", "2", 3, 1000)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "GetUsagesAPI", mock.Anything)
+	})
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("should retry a lost usage write", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("UpdateUsageAPI", mock.Anything).Return(errors.New("timeout")).Once()
+		repo.On("UpdateUsageAPI", mock.Anything).Return(nil).Once()
+
+		var slept []time.Duration
+		err := newTestService(repo, new(MockMemberRepository), &slept).Record("1", "2", 3, 4, 3, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Duration{writeBackoff}, slept)
+		repo.AssertNumberOfCalls(t, "UpdateUsageAPI", 2)
+	})
+
+	t.Run("should report a write that keeps failing", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("UpdateUsageAPI", mock.Anything).Return(errors.New("timeout"))
+
+		var slept []time.Duration
+		err := newTestService(repo, new(MockMemberRepository), &slept).Record("1", "2", 3, 4, 1, 1)
+
+		assert.Error(t, err)
+		repo.AssertNumberOfCalls(t, "UpdateUsageAPI", writeAttempts)
+	})
+}
+
+func TestRemaining(t *testing.T) {
+	usages := []*Usage{
+		{ProductId: 1, Quota: 10, Used: 4, Reserved: 2},
+		{ProductId: 2, Quota: 5, Used: 5, Reserved: 1},
+	}
+
+	t.Run("should share a total quota across products", func(t *testing.T) {
+		result := remaining(&member.MstMember{MemberId: 1, QuotaType: TypeTotal, Quota: 20}, usages)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, uint(0), result[0].ProductId)
+		assert.Equal(t, 9, result[0].Used)
+		assert.Equal(t, 3, result[0].Reserved)
+		assert.Equal(t, 8, result[0].Remaining)
+	})
+
+	t.Run("should limit each product on a per product quota", func(t *testing.T) {
+		result := remaining(&member.MstMember{MemberId: 1, QuotaType: TypePerProduct}, usages)
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, 4, result[0].Remaining)
+		assert.Equal(t, 0, result[1].Remaining)
+	})
+
+	t.Run("should report members without a quota as unlimited", func(t *testing.T) {
+		result := remaining(&member.MstMember{MemberId: 1, QuotaType: TypeNone}, usages)
+
+		assert.Len(t, result, 1)
+		assert.True(t, result[0].Unlimited)
+		assert.Equal(t, 9, result[0].Used)
+	})
+}
@@ -0,0 +1,46 @@
+package subscription
+
+import (
+	"fmt"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireProduct only lets through members allowed to use the product with
+// catalog slug productSlug. It reads the session set by
+// middleware.GetJWTPayloadFromCookie and must come after it.
+func RequireProduct(svc Service, productSlug string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := authorize(c, svc, productSlug); err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireRouteProduct is RequireProduct for the product served under the
+// :product_slug route parameter. Unknown products are left to the handler.
+func RequireRouteProduct(svc Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		handler, ok := registry.GetByRouteSlug(c.Params("product_slug"))
+		if !ok {
+			return c.Next()
+		}
+
+		if err := authorize(c, svc, handler.Slug); err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+func authorize(c *fiber.Ctx, svc Service, productSlug string) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+
+	return svc.Authorize(companyId, memberId, productSlug)
+}
@@ -0,0 +1,13 @@
+package subscription
+
+import "time"
+
+// cacheTTL is how long the subscribed products of a company are trusted
+// before they are fetched again.
+const cacheTTL = 5 * time.Minute
+
+// subscribedProduct is a product a company has a contract for.
+type subscribedProduct struct {
+	ProductId   uint   `json:"product_id"`
+	ProductSlug string `json:"product_slug_name"`
+}
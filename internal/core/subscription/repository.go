@@ -0,0 +1,56 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"front-office/pkg/httpclient"
+	"net/http"
+	"time"
+)
+
+func NewRepository(cfg *application.Config, client httpclient.HTTPClient) Repository {
+	return &repository{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+type repository struct {
+	cfg    *application.Config
+	client httpclient.HTTPClient
+}
+
+type Repository interface {
+	GetSubscribedProductsAPI(companyId string) ([]*subscribedProduct, error)
+}
+
+func (repo *repository) GetSubscribedProductsAPI(companyId string) ([]*subscribedProduct, error) {
+	url := fmt.Sprintf("%s/api/core/company/%s/subscribed-products", repo.cfg.Env.AifcoreHost, companyId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XCompanyId, companyId)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[[]*subscribedProduct](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
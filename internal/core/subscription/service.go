@@ -0,0 +1,115 @@
+package subscription
+
+import (
+	"front-office/internal/core/member"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"strings"
+	"sync"
+	"time"
+)
+
+func NewService(repo Repository, memberRepo member.Repository) Service {
+	return &service{
+		repo:       repo,
+		memberRepo: memberRepo,
+		cache:      defaultCache,
+		now:        time.Now,
+	}
+}
+
+type service struct {
+	repo       Repository
+	memberRepo member.Repository
+	cache      *cache
+	now        func() time.Time
+}
+
+type Service interface {
+	Authorize(companyId, memberId, productSlug string) error
+}
+
+// cache holds the subscribed product slugs of each company. It is shared by
+// every service so that all product routes see the same entries.
+type cache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	slugs     map[string]bool
+	expiresAt time.Time
+}
+
+var defaultCache = &cache{entries: map[string]*cacheEntry{}}
+
+// Authorize rejects with 403 a member whose company is not subscribed to the
+// product, or who is not permitted to use it.
+func (svc *service) Authorize(companyId, memberId, productSlug string) error {
+	slugs, err := svc.subscribedSlugs(companyId)
+	if err != nil {
+		return err
+	}
+
+	if !slugs[productSlug] {
+		return apperror.Forbidden(constant.ProductNotSubscribed)
+	}
+
+	m, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{Id: memberId, CompanyId: companyId})
+	if err != nil {
+		return apperror.MapRepoError(err, constant.FailedFetchMember)
+	}
+
+	if m == nil || m.MemberId == 0 {
+		return apperror.Forbidden(constant.ProductNotPermitted)
+	}
+
+	if !isPermitted(m.ProductPermission, productSlug) {
+		return apperror.Forbidden(constant.ProductNotPermitted)
+	}
+
+	return nil
+}
+
+func (svc *service) subscribedSlugs(companyId string) (map[string]bool, error) {
+	svc.cache.mu.Lock()
+	entry, ok := svc.cache.entries[companyId]
+	svc.cache.mu.Unlock()
+
+	if ok && svc.now().Before(entry.expiresAt) {
+		return entry.slugs, nil
+	}
+
+	products, err := svc.repo.GetSubscribedProductsAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch subscribed products")
+	}
+
+	slugs := make(map[string]bool, len(products))
+	for _, p := range products {
+		slugs[p.ProductSlug] = true
+	}
+
+	svc.cache.mu.Lock()
+	svc.cache.entries[companyId] = &cacheEntry{slugs: slugs, expiresAt: svc.now().Add(cacheTTL)}
+	svc.cache.mu.Unlock()
+
+	return slugs, nil
+}
+
+// isPermitted reports whether a member's product permission, a comma
+// separated list of product slugs, allows productSlug. An empty permission
+// allows every product the company is subscribed to.
+func isPermitted(permission, productSlug string) bool {
+	if strings.TrimSpace(permission) == "" {
+		return true
+	}
+
+	for _, slug := range strings.Split(permission, ",") {
+		if strings.TrimSpace(slug) == productSlug {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package subscription
+
+import (
+	"front-office/internal/core/member"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetSubscribedProductsAPI(companyId string) ([]*subscribedProduct, error) {
+	args := m.Called(companyId)
+	return args.Get(0).([]*subscribedProduct), args.Error(1)
+}
+
+type MockMemberRepository struct {
+	member.Repository
+	mock.Mock
+}
+
+func (m *MockMemberRepository) GetMemberAPI(query *member.FindUserQuery) (*member.MstMember, error) {
+	args := m.Called(query)
+	return args.Get(0).(*member.MstMember), args.Error(1)
+}
+
+func newTestService(repo *MockRepository, memberRepo *MockMemberRepository, now *time.Time) *service {
+	return &service{
+		repo:       repo,
+		memberRepo: memberRepo,
+		cache:      &cache{entries: map[string]*cacheEntry{}},
+		now:        func() time.Time { return *now },
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	subscribed := []*subscribedProduct{{ProductId: 1, ProductSlug: constant.SlugPhoneLiveStatus}}
+
+	t.Run("should allow a permitted member of a subscribed company", func(t *testing.T) {
+		now := time.Now()
+		repo := new(MockRepository)
+		repo.On("GetSubscribedProductsAPI", constant.DummyCompanyId).Return(subscribed, nil).Once()
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", mock.Anything).Return(&member.MstMember{MemberId: 1}, nil)
+
+		svc := newTestService(repo, memberRepo, &now)
+
+		assert.NoError(t, svc.Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugPhoneLiveStatus))
+		assert.NoError(t, svc.Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugPhoneLiveStatus))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should refetch subscriptions once the cache expires", func(t *testing.T) {
+		now := time.Now()
+		repo := new(MockRepository)
+		repo.On("GetSubscribedProductsAPI", constant.DummyCompanyId).Return(subscribed, nil).Twice()
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", mock.Anything).Return(&member.MstMember{MemberId: 1}, nil)
+
+		svc := newTestService(repo, memberRepo, &now)
+
+		assert.NoError(t, svc.Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugPhoneLiveStatus))
+		now = now.Add(cacheTTL)
+		assert.NoError(t, svc.Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugPhoneLiveStatus))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should forbid products the company is not subscribed to", func(t *testing.T) {
+		now := time.Now()
+		repo := new(MockRepository)
+		repo.On("GetSubscribedProductsAPI", constant.DummyCompanyId).Return(subscribed, nil)
+
+		err := newTestService(repo, new(MockMemberRepository), &now).Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugMultipleLoan7Days)
+
+		assert.Equal(t, apperror.Forbidden(constant.ProductNotSubscribed), err)
+	})
+
+	t.Run("should forbid members without permission for the product", func(t *testing.T) {
+		now := time.Now()
+		repo := new(MockRepository)
+		repo.On("GetSubscribedProductsAPI", constant.DummyCompanyId).Return(subscribed, nil)
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", mock.Anything).Return(&member.MstMember{MemberId: 1, ProductPermission: constant.SlugMultipleLoan7Days}, nil)
+
+		err := newTestService(repo, memberRepo, &now).Authorize(constant.DummyCompanyId, constant.DummyMemberId, constant.SlugPhoneLiveStatus)
+
+		assert.Equal(t, apperror.Forbidden(constant.ProductNotPermitted), err)
+	})
+}
+
+func TestIsPermitted(t *testing.T) {
+	assert.True(t, isPermitted("", "tax_score"))
+	assert.True(t, isPermitted("phone_live_status, tax_score", "tax_score"))
+	assert.False(t, isPermitted("phone_live_status", "tax_score"))
+}
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/common/constant"
 	"os"
 	"path/filepath"
@@ -27,14 +28,13 @@ func (r *repository) GetTemplatePath(category, filename string) (string, error)
 }
 
 func (r *repository) GetAvailableTemplates() (map[string][]string, error) {
-	products := []string{
-		constant.PhoneLiveTemplates,
-		constant.LoanRecordCheckerTemplates,
-		constant.MultipleLoanTemplates,
-		constant.TaxComplianceStatusTemplates,
-		constant.TaxScoreTemplates,
-		constant.TaxVerificationTemplates,
-		constant.GenRetailTemplates,
+	products := []string{constant.GenRetailTemplates}
+	seen := map[string]bool{constant.GenRetailTemplates: true}
+	for _, handler := range registry.All() {
+		if handler.TemplateDir != "" && !seen[handler.TemplateDir] {
+			seen[handler.TemplateDir] = true
+			products = append(products, handler.TemplateDir)
+		}
 	}
 
 	result := make(map[string][]string)
@@ -0,0 +1,93 @@
+package topup
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	CreateTopup(c *fiber.Ctx) error
+	GetTopups(c *fiber.Ctx) error
+	GetTopup(c *fiber.Ctx) error
+	SubmitPaymentProof(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) CreateTopup(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+
+	reqBody, ok := c.Locals(constant.Request).(*createTopupRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.CreateTopup(companyId, memberId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to create top-up",
+		result,
+	))
+}
+
+func (ctrl *controller) GetTopups(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetTopups(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get top-ups",
+		result,
+	))
+}
+
+func (ctrl *controller) GetTopup(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetTopup(companyId, c.Params("topup_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get top-up",
+		result,
+	))
+}
+
+func (ctrl *controller) SubmitPaymentProof(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	result, err := ctrl.svc.SubmitPaymentProof(companyId, memberId, c.Params("topup_id"), file)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to submit payment confirmation",
+		result,
+	))
+}
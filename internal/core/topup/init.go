@@ -0,0 +1,24 @@
+package topup
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/member"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	operationRepo := operation.NewRepository(cfg, client, nil)
+	service := NewService(repository, memberRepo, operationRepo)
+	controller := NewController(service)
+
+	apiGroup.Post("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(createTopupRequest{}), controller.CreateTopup)
+	apiGroup.Get("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetTopups)
+	apiGroup.Get("/:topup_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetTopup)
+	apiGroup.Put("/:topup_id/payment-proof", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.SubmitPaymentProof)
+}
@@ -0,0 +1,54 @@
+package topup
+
+import "time"
+
+// PaymentSchemePrepaid marks companies that pay for usage by topping up a
+// balance in advance.
+const PaymentSchemePrepaid = "prepaid"
+
+// Statuses of a top-up. A top-up waits for its payment proof, is then
+// submitted for verification in Aifcore and ends verified or rejected; a
+// rejected top-up takes a new proof.
+const (
+	StatusPending   = "pending"
+	StatusSubmitted = "submitted"
+	StatusVerified  = "verified"
+	StatusRejected  = "rejected"
+)
+
+// maxProofSize is the largest payment proof accepted.
+const maxProofSize = 2 * 1024 * 1024
+
+type Topup struct {
+	Id             uint       `json:"id"`
+	CompanyId      uint       `json:"company_id"`
+	MemberId       uint       `json:"member_id"`
+	Amount         float64    `json:"amount"`
+	Status         string     `json:"status"`
+	ProofFileName  string     `json:"proof_file_name"`
+	RejectedReason string     `json:"rejected_reason"`
+	SubmittedAt    *time.Time `json:"submitted_at"`
+	VerifiedAt     *time.Time `json:"verified_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type createTopupRequest struct {
+	Amount float64 `json:"amount" validate:"required~Amount cannot be empty"`
+}
+
+type createTopupPayload struct {
+	CompanyId uint    `json:"company_id"`
+	MemberId  uint    `json:"member_id"`
+	Amount    float64 `json:"amount"`
+	Status    string  `json:"status"`
+}
+
+// paymentProofPayload carries an uploaded proof of payment to Aifcore, which
+// moves the top-up to submitted.
+type paymentProofPayload struct {
+	MemberId    uint   `json:"member_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
@@ -0,0 +1,161 @@
+package topup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallCreateTopupAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Topup{Id: 1, Status: StatusPending}), nil)
+
+		result, err := repo.CreateTopupAPI(&createTopupPayload{CompanyId: 1, Amount: 100000})
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusPending, result.Status)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		result, err := repo.CreateTopupAPI(&createTopupPayload{})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		_, err := repo.CreateTopupAPI(&createTopupPayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetTopupsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Topup{{Id: 1}, {Id: 2}}), nil)
+
+		result, err := repo.GetTopupsAPI(constant.DummyCompanyId)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetTopupsAPI(constant.DummyCompanyId)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetTopupAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Topup{Id: 1, Status: StatusVerified}), nil)
+
+		result, err := repo.GetTopupAPI(constant.DummyCompanyId, "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusVerified, result.Status)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetTopupAPI(constant.DummyCompanyId, "1")
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallSubmitPaymentProofAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Topup{Id: 1, Status: StatusSubmitted}), nil)
+
+		result, err := repo.SubmitPaymentProofAPI(constant.DummyCompanyId, "1", &paymentProofPayload{FileName: "proof.pdf", Content: []byte("%PDF-")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusSubmitted, result.Status)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.SubmitPaymentProofAPI(constant.DummyCompanyId, "1", &paymentProofPayload{})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
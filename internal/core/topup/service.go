@@ -0,0 +1,194 @@
+package topup
+
+import (
+	"fmt"
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/member"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+func NewService(repo Repository, memberRepo member.Repository, operationRepo operation.Repository) Service {
+	return &service{
+		repo,
+		memberRepo,
+		operationRepo,
+	}
+}
+
+type service struct {
+	repo          Repository
+	memberRepo    member.Repository
+	operationRepo operation.Repository
+}
+
+type Service interface {
+	CreateTopup(companyId, memberId string, req *createTopupRequest) (*Topup, error)
+	GetTopups(companyId string) ([]*Topup, error)
+	GetTopup(companyId, topupId string) (*Topup, error)
+	SubmitPaymentProof(companyId, memberId, topupId string, file *multipart.FileHeader) (*Topup, error)
+}
+
+// proofContentTypes are the payment proof formats accepted, by extension.
+var proofContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".pdf":  "application/pdf",
+}
+
+// CreateTopup opens a balance top-up for a prepaid company. It stays pending
+// until a payment proof is submitted.
+func (svc *service) CreateTopup(companyId, memberId string, req *createTopupRequest) (*Topup, error) {
+	if req.Amount <= 0 {
+		return nil, apperror.BadRequest("amount must be greater than zero")
+	}
+
+	m, err := svc.getMember(companyId, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(m.MstCompany.PaymentScheme, PaymentSchemePrepaid) {
+		return nil, apperror.BadRequest("top-ups are only available to prepaid companies")
+	}
+
+	result, err := svc.repo.CreateTopupAPI(&createTopupPayload{
+		CompanyId: m.CompanyId,
+		MemberId:  m.MemberId,
+		Amount:    req.Amount,
+		Status:    StatusPending,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to create top-up")
+	}
+
+	svc.logOperation(m, constant.EventTopupBalance)
+
+	return result, nil
+}
+
+func (svc *service) GetTopups(companyId string) ([]*Topup, error) {
+	result, err := svc.repo.GetTopupsAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch top-ups")
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (svc *service) GetTopup(companyId, topupId string) (*Topup, error) {
+	result, err := svc.repo.GetTopupAPI(companyId, topupId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch top-up")
+	}
+
+	if result == nil || helper.ConvertUintToString(result.CompanyId) != companyId {
+		return nil, apperror.NotFound("top-up not found")
+	}
+
+	return result, nil
+}
+
+// SubmitPaymentProof uploads the proof of payment of a pending or rejected
+// top-up and submits it for verification.
+func (svc *service) SubmitPaymentProof(companyId, memberId, topupId string, file *multipart.FileHeader) (*Topup, error) {
+	t, err := svc.GetTopup(companyId, topupId)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status != StatusPending && t.Status != StatusRejected {
+		return nil, apperror.BadRequest(fmt.Sprintf("top-up is already %s", t.Status))
+	}
+
+	m, err := svc.getMember(companyId, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helper.ValidateUploadedFile(file, maxProofSize, []string{".jpg", ".jpeg", ".png", ".pdf"}); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, apperror.Internal("failed to open payment proof", err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, apperror.Internal("failed to read payment proof", err)
+	}
+
+	contentType, err := detectProofType(file.Filename, content)
+	if err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	result, err := svc.repo.SubmitPaymentProofAPI(companyId, topupId, &paymentProofPayload{
+		MemberId:    m.MemberId,
+		FileName:    filepath.Base(file.Filename),
+		ContentType: contentType,
+		Content:     content,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to submit payment proof")
+	}
+
+	svc.logOperation(m, constant.EventSubmitPaymentConfirmation)
+
+	return result, nil
+}
+
+func (svc *service) getMember(companyId, memberId string) (*member.MstMember, error) {
+	m, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{Id: memberId, CompanyId: companyId})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchMember)
+	}
+
+	if m == nil || m.MemberId == 0 {
+		return nil, apperror.NotFound(constant.UserNotFound)
+	}
+
+	return m, nil
+}
+
+func (svc *service) logOperation(m *member.MstMember, action string) {
+	if err := svc.operationRepo.AddLogOperation(&operation.AddLogRequest{
+		MemberId:  m.MemberId,
+		CompanyId: m.CompanyId,
+		Action:    action,
+	}); err != nil {
+		log.Warn().Err(err).Msgf("failed to log %s event", action)
+	}
+}
+
+// detectProofType checks that a payment proof's content matches its
+// extension and returns its content type.
+func detectProofType(fileName string, content []byte) (string, error) {
+	expected, ok := proofContentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return "", fmt.Errorf("payment proof must be a JPG, PNG or PDF file")
+	}
+
+	if detected := http.DetectContentType(content); detected != expected {
+		return "", fmt.Errorf("payment proof content does not match its %s extension", filepath.Ext(fileName))
+	}
+
+	return expected, nil
+}
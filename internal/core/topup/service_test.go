@@ -0,0 +1,32 @@
+package topup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectProofType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pdf := []byte("%PDF-1.4\n")
+
+	t.Run("should accept content matching its extension", func(t *testing.T) {
+		contentType, err := detectProofType("proof.PNG", png)
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+
+		contentType, err = detectProofType("proof.pdf", pdf)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/pdf", contentType)
+	})
+
+	t.Run("should reject content not matching its extension", func(t *testing.T) {
+		_, err := detectProofType("proof.jpg", pdf)
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject other file types", func(t *testing.T) {
+		_, err := detectProofType("proof.exe", []byte("MZ"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package usage
+
+import (
+	"bytes"
+	"fmt"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	GetUsage(c *fiber.Ctx) error
+	ExportUsage(c *fiber.Ctx) error
+	GetMetrics(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) GetUsage(c *fiber.Ctx) error {
+	result, err := ctrl.svc.GetUsage(usageFilter(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get usage",
+		result,
+	))
+}
+
+func (ctrl *controller) ExportUsage(c *fiber.Ctx) error {
+	var buf bytes.Buffer
+	filename, err := ctrl.svc.ExportUsage(usageFilter(c), &buf)
+	if err != nil {
+		return err
+	}
+
+	c.Set(constant.HeaderContentType, constant.TextOrCSVContentType)
+	c.Set(constant.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filename))
+	return c.SendStream(bytes.NewReader(buf.Bytes()))
+}
+
+func (ctrl *controller) GetMetrics(c *fiber.Ctx) error {
+	result, err := ctrl.svc.GetMetrics(usageFilter(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get metrics",
+		result,
+	))
+}
+
+func usageFilter(c *fiber.Ctx) *Filter {
+	return &Filter{
+		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		MemberId:    c.Query("member_id"),
+		ProductSlug: c.Query("product_slug"),
+		StartDate:   c.Query(constant.StartDate),
+		EndDate:     c.Query(constant.EndDate),
+		Interval:    c.Query("interval"),
+	}
+}
@@ -0,0 +1,27 @@
+package usage
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/audit"
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	transactionRepo := transaction.NewRepository(cfg, client, nil)
+	productRepo := product.NewRepository(cfg, client)
+	service := NewService(transactionRepo, productRepo)
+	controller := NewController(service)
+	auditService := audit.NewService(operation.NewRepository(cfg, client, nil), member.NewRepository(cfg, client, nil))
+
+	apiGroup.Get("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetUsage)
+	apiGroup.Get("/metrics", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetMetrics)
+	apiGroup.Get("/export", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), audit.Export(auditService, constant.EventExportData, ""), controller.ExportUsage)
+}
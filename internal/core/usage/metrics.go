@@ -0,0 +1,139 @@
+package usage
+
+import (
+	"encoding/json"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// GetMetrics builds the dashboard series of every product a company called
+// in the range, from the same transaction logs as GetUsage.
+func (svc *service) GetMetrics(filter *Filter) (*Metrics, error) {
+	if err := normalizeFilter(filter, time.Now()); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	records, err := svc.fetchRecords(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := buildMetrics(records, filter)
+	metrics.StartDate = filter.StartDate
+	metrics.EndDate = filter.EndDate
+	metrics.Interval = filter.Interval
+
+	return metrics, nil
+}
+
+// buildMetrics counts records by product and period. Every series has a
+// point for each period of the filter range, so charts need no gap filling.
+func buildMetrics(records []*record, filter *Filter) *Metrics {
+	labels := periods(filter.StartDate, filter.EndDate, filter.Interval)
+
+	series := map[string]map[string]*Point{}
+	for _, r := range records {
+		points, ok := series[r.ProductSlug]
+		if !ok {
+			points = make(map[string]*Point, len(labels))
+			for _, label := range labels {
+				points[label] = &Point{Period: label}
+			}
+			series[r.ProductSlug] = points
+		}
+
+		point, ok := points[period(r.Time, filter.Interval)]
+		if !ok {
+			continue // logged outside the range, e.g. across a time zone boundary
+		}
+
+		switch {
+		case r.Success:
+			point.Success++
+		case r.Status >= http.StatusInternalServerError:
+			point.Error++
+		default:
+			point.Failure++
+		}
+
+		if !r.Success {
+			continue
+		}
+
+		if bucket := distributionBucket(r); bucket != "" {
+			if point.Distribution == nil {
+				point.Distribution = map[string]int{}
+			}
+			point.Distribution[bucket]++
+		}
+	}
+
+	metrics := &Metrics{Products: make([]*Series, 0, len(series))}
+	for slug, points := range series {
+		s := &Series{ProductSlug: slug, Points: make([]*Point, 0, len(labels))}
+		for _, label := range labels {
+			s.Points = append(s.Points, points[label])
+		}
+
+		metrics.Products = append(metrics.Products, s)
+	}
+
+	sort.Slice(metrics.Products, func(i, j int) bool {
+		return metrics.Products[i].ProductSlug < metrics.Products[j].ProductSlug
+	})
+
+	return metrics
+}
+
+// distributionBucket returns the bucket of a successful call: the grade of
+// a Scoreezy call, or the bucket the product's handler assigns its data.
+func distributionBucket(r *record) string {
+	if r.ProductSlug == constant.SlugGenRetailV3 {
+		return r.Grade
+	}
+
+	handler, ok := registry.Get(r.ProductSlug)
+	if !ok || handler.Distribution == nil || len(r.Data) == 0 {
+		return ""
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(r.Data, &data); err != nil {
+		return ""
+	}
+
+	return handler.Distribution(data)
+}
+
+// period labels the day, week or month t falls in.
+func period(t time.Time, interval string) string {
+	switch interval {
+	case IntervalDay:
+		return t.Format(constant.FormatYYYYMMDD)
+	case IntervalWeek:
+		offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+		return t.AddDate(0, 0, -offset).Format(constant.FormatYYYYMMDD)
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// periods labels every period between two validated YYYY-MM-DD dates.
+func periods(startDate, endDate, interval string) []string {
+	start, _ := time.Parse(constant.FormatYYYYMMDD, startDate)
+	end, _ := time.Parse(constant.FormatYYYYMMDD, endDate)
+
+	var labels []string
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		label := period(day, interval)
+		if len(labels) == 0 || labels[len(labels)-1] != label {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels
+}
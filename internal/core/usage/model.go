@@ -0,0 +1,90 @@
+package usage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Periods usage can be grouped by. Weeks start on Monday and are labelled
+// with that date.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// Filter selects the transaction logs of a company to aggregate. StartDate
+// and EndDate are inclusive YYYY-MM-DD dates; MemberId and ProductSlug are
+// optional.
+type Filter struct {
+	CompanyId   string
+	MemberId    string
+	ProductSlug string
+	StartDate   string
+	EndDate     string
+	Interval    string
+}
+
+// Row counts the calls one member made to one product in one period. Paid
+// and Free split the calls by pricing strategy, Success and Failed by outcome.
+type Row struct {
+	Period      string `json:"period"`
+	MemberId    uint   `json:"member_id"`
+	ProductSlug string `json:"product_slug"`
+	Total       int    `json:"total"`
+	Paid        int    `json:"paid"`
+	Free        int    `json:"free"`
+	Success     int    `json:"success"`
+	Failed      int    `json:"failed"`
+}
+
+// Report is the usage of a company over a date range with its grand totals.
+type Report struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Interval  string `json:"interval"`
+	Total     int    `json:"total"`
+	Paid      int    `json:"paid"`
+	Free      int    `json:"free"`
+	Success   int    `json:"success"`
+	Failed    int    `json:"failed"`
+	Rows      []*Row `json:"rows"`
+}
+
+// Metrics are the dashboard series of a company, one per product called in
+// the range, each with a point for every period of the range.
+type Metrics struct {
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	Interval  string    `json:"interval"`
+	Products  []*Series `json:"products"`
+}
+
+type Series struct {
+	ProductSlug string   `json:"product_slug"`
+	Points      []*Point `json:"points"`
+}
+
+// Point counts the calls to a product in one period by outcome. Error counts
+// failed calls answered with a server error. Distribution buckets the
+// successful calls of products that define one, e.g. by remarks or grade.
+type Point struct {
+	Period       string         `json:"period"`
+	Success      int            `json:"success"`
+	Failure      int            `json:"failure"`
+	Error        int            `json:"error"`
+	Distribution map[string]int `json:"distribution,omitempty"`
+}
+
+// record is one product catalog or Scoreezy call reduced to what usage is
+// counted by. Status, Data and Grade are only read by the metrics.
+type record struct {
+	Time        time.Time
+	MemberId    uint
+	ProductSlug string
+	Paid        bool
+	Success     bool
+	Status      int
+	Data        json.RawMessage
+	Grade       string
+}
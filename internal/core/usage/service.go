@@ -0,0 +1,292 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/product"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func NewService(transactionRepo transaction.Repository, productRepo product.Repository) Service {
+	return &service{
+		transactionRepo: transactionRepo,
+		productRepo:     productRepo,
+	}
+}
+
+type service struct {
+	transactionRepo transaction.Repository
+	productRepo     product.Repository
+
+	mu     sync.Mutex
+	slugs  map[uint]string
+	loaded bool
+}
+
+type Service interface {
+	GetUsage(filter *Filter) (*Report, error)
+	ExportUsage(filter *Filter, buf *bytes.Buffer) (string, error)
+	GetMetrics(filter *Filter) (*Metrics, error)
+}
+
+// GetUsage aggregates the product catalog and Scoreezy transaction logs of a
+// company by period, member and product.
+func (svc *service) GetUsage(filter *Filter) (*Report, error) {
+	if err := normalizeFilter(filter, time.Now()); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	records, err := svc.fetchRecords(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := aggregate(records, filter.Interval)
+	report.StartDate = filter.StartDate
+	report.EndDate = filter.EndDate
+	report.Interval = filter.Interval
+
+	return report, nil
+}
+
+func (svc *service) ExportUsage(filter *Filter, buf *bytes.Buffer) (string, error) {
+	report, err := svc.GetUsage(filter)
+	if err != nil {
+		return "", err
+	}
+
+	writer := csv.NewWriter(buf)
+
+	headers := []string{"Period", "Member ID", "Product", "Total", "Paid", "Free", "Success", "Failed"}
+	if err := writer.Write(headers); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	for _, row := range report.Rows {
+		if err := writer.Write([]string{
+			row.Period,
+			strconv.FormatUint(uint64(row.MemberId), 10),
+			row.ProductSlug,
+			strconv.Itoa(row.Total),
+			strconv.Itoa(row.Paid),
+			strconv.Itoa(row.Free),
+			strconv.Itoa(row.Success),
+			strconv.Itoa(row.Failed),
+		}); err != nil {
+			return "", apperror.Internal("failed to write CSV", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	return fmt.Sprintf("usage_%s_until_%s.csv", filter.StartDate, filter.EndDate), nil
+}
+
+func (svc *service) fetchRecords(filter *Filter) ([]*record, error) {
+	procatLogs, err := svc.transactionRepo.GetLogsTransByDateRangeAPI(filter.CompanyId, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchLogs)
+	}
+
+	scoreezyLogs, err := svc.transactionRepo.GetLogsScoreezyByDateRangeAPI(filter.CompanyId, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchLogs)
+	}
+
+	slugs := svc.productSlugs()
+	records := make([]*record, 0, len(procatLogs)+len(scoreezyLogs))
+
+	for _, l := range procatLogs {
+		records = append(records, &record{
+			Time:        l.CreatedAt,
+			MemberId:    l.MemberID,
+			ProductSlug: productSlug(slugs, l.ProductID),
+			Paid:        strings.EqualFold(l.PricingStrategy, constant.PricingStrategyPay),
+			Success:     l.Success,
+			Status:      l.Status,
+			Data:        json.RawMessage(l.Data),
+		})
+	}
+
+	for _, l := range scoreezyLogs {
+		records = append(records, &record{
+			Time:        l.CreatedAt,
+			MemberId:    l.MemberId,
+			ProductSlug: productSlug(slugs, l.ProductId),
+			Paid:        strings.EqualFold(l.Status, constant.PricingStrategyPay),
+			Success:     l.Success,
+			Grade:       l.Grade,
+		})
+	}
+
+	return filterRecords(records, filter), nil
+}
+
+// productSlugs maps product IDs to the slugs of every registered datahub
+// product and Scoreezy. Products are looked up once; a failed lookup is
+// retried on the next call.
+func (svc *service) productSlugs() map[uint]string {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if svc.loaded {
+		return svc.slugs
+	}
+
+	slugs := []string{constant.SlugGenRetailV3}
+	for _, h := range registry.All() {
+		slugs = append(slugs, h.Slug)
+	}
+
+	result := make(map[uint]string, len(slugs))
+	complete := true
+	for _, slug := range slugs {
+		p, err := svc.productRepo.GetProductAPI(slug)
+		if err != nil {
+			log.Warn().Err(err).Str("product", slug).Msg("failed to fetch product for usage")
+			complete = false
+			continue
+		}
+		if p.ProductId != 0 {
+			result[p.ProductId] = slug
+		}
+	}
+
+	svc.slugs, svc.loaded = result, complete
+
+	return result
+}
+
+func productSlug(slugs map[uint]string, productId uint) string {
+	if slug, ok := slugs[productId]; ok {
+		return slug
+	}
+
+	return fmt.Sprintf("product_%d", productId)
+}
+
+// normalizeFilter defaults the range to the current month up to now and the
+// interval to monthly, and validates what was given.
+func normalizeFilter(filter *Filter, now time.Time) error {
+	if filter.StartDate == "" {
+		filter.StartDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format(constant.FormatYYYYMMDD)
+	}
+	if filter.EndDate == "" {
+		filter.EndDate = now.Format(constant.FormatYYYYMMDD)
+	}
+	if filter.Interval == "" {
+		filter.Interval = IntervalMonth
+	}
+
+	start, err := time.Parse(constant.FormatYYYYMMDD, filter.StartDate)
+	if err != nil {
+		return fmt.Errorf("start_date must be formatted as YYYY-MM-DD")
+	}
+
+	end, err := time.Parse(constant.FormatYYYYMMDD, filter.EndDate)
+	if err != nil {
+		return fmt.Errorf("end_date must be formatted as YYYY-MM-DD")
+	}
+
+	if end.Before(start) {
+		return fmt.Errorf("end_date cannot be before start_date")
+	}
+
+	if filter.Interval != IntervalDay && filter.Interval != IntervalWeek && filter.Interval != IntervalMonth {
+		return fmt.Errorf("interval must be %s, %s or %s", IntervalDay, IntervalWeek, IntervalMonth)
+	}
+
+	return nil
+}
+
+func filterRecords(records []*record, filter *Filter) []*record {
+	if filter.MemberId == "" && filter.ProductSlug == "" {
+		return records
+	}
+
+	result := make([]*record, 0, len(records))
+	for _, r := range records {
+		if filter.MemberId != "" && strconv.FormatUint(uint64(r.MemberId), 10) != filter.MemberId {
+			continue
+		}
+		if filter.ProductSlug != "" && r.ProductSlug != filter.ProductSlug {
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// aggregate counts records by period, member and product, ordered the same
+// way.
+func aggregate(records []*record, interval string) *Report {
+	type key struct {
+		period   string
+		memberId uint
+		slug     string
+	}
+
+	report := &Report{Rows: []*Row{}}
+	rows := map[key]*Row{}
+
+	for _, r := range records {
+		k := key{period(r.Time, interval), r.MemberId, r.ProductSlug}
+
+		row, ok := rows[k]
+		if !ok {
+			row = &Row{Period: k.period, MemberId: k.memberId, ProductSlug: k.slug}
+			rows[k] = row
+			report.Rows = append(report.Rows, row)
+		}
+
+		row.Total++
+		report.Total++
+
+		if r.Paid {
+			row.Paid++
+			report.Paid++
+		} else {
+			row.Free++
+			report.Free++
+		}
+
+		if r.Success {
+			row.Success++
+			report.Success++
+		} else {
+			row.Failed++
+			report.Failed++
+		}
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Period != b.Period {
+			return a.Period < b.Period
+		}
+		if a.MemberId != b.MemberId {
+			return a.MemberId < b.MemberId
+		}
+
+		return a.ProductSlug < b.ProductSlug
+	})
+
+	return report
+}
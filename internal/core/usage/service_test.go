@@ -0,0 +1,128 @@
+package usage
+
+import (
+	"front-office/internal/datahub/compliance/multipleloan"
+	"front-office/internal/datahub/incometax/taxscore"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 10, 0, 0, 0, time.UTC)
+	}
+
+	records := []*record{
+		{Time: day(2), MemberId: 2, ProductSlug: "b", Paid: true, Success: true},
+		{Time: day(1), MemberId: 1, ProductSlug: "a", Paid: true, Success: true},
+		{Time: day(1), MemberId: 1, ProductSlug: "a", Paid: false, Success: false},
+		{Time: day(3), MemberId: 1, ProductSlug: "a", Paid: true, Success: true},
+	}
+
+	t.Run("should group by month", func(t *testing.T) {
+		result := aggregate(records, IntervalMonth)
+
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, 3, result.Paid)
+		assert.Equal(t, 1, result.Free)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, []*Row{
+			{Period: "2024-01", MemberId: 1, ProductSlug: "a", Total: 3, Paid: 2, Free: 1, Success: 2, Failed: 1},
+			{Period: "2024-01", MemberId: 2, ProductSlug: "b", Total: 1, Paid: 1, Success: 1},
+		}, result.Rows)
+	})
+
+	t.Run("should group by day", func(t *testing.T) {
+		result := aggregate(records, IntervalDay)
+
+		assert.Len(t, result.Rows, 3)
+		assert.Equal(t, "2024-01-01", result.Rows[0].Period)
+		assert.Equal(t, 2, result.Rows[0].Total)
+		assert.Equal(t, "2024-01-03", result.Rows[2].Period)
+	})
+}
+
+func TestNormalizeFilter(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 8, 0, 0, 0, time.UTC)
+
+	t.Run("should default to the current month", func(t *testing.T) {
+		filter := &Filter{}
+
+		assert.NoError(t, normalizeFilter(filter, now))
+		assert.Equal(t, "2024-03-01", filter.StartDate)
+		assert.Equal(t, "2024-03-15", filter.EndDate)
+		assert.Equal(t, IntervalMonth, filter.Interval)
+	})
+
+	t.Run("should reject a reversed range", func(t *testing.T) {
+		assert.Error(t, normalizeFilter(&Filter{StartDate: "2024-03-10", EndDate: "2024-03-01"}, now))
+	})
+
+	t.Run("should accept weekly intervals", func(t *testing.T) {
+		assert.NoError(t, normalizeFilter(&Filter{Interval: IntervalWeek}, now))
+	})
+
+	t.Run("should reject unknown intervals", func(t *testing.T) {
+		assert.Error(t, normalizeFilter(&Filter{Interval: "year"}, now))
+	})
+}
+
+func TestFilterRecords(t *testing.T) {
+	records := []*record{
+		{MemberId: 1, ProductSlug: "a"},
+		{MemberId: 2, ProductSlug: "a"},
+		{MemberId: 1, ProductSlug: "b"},
+	}
+
+	result := filterRecords(records, &Filter{MemberId: "1", ProductSlug: "a"})
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, records[0], result[0])
+}
+
+func TestPeriods(t *testing.T) {
+	assert.Equal(t, []string{"2024-02-26", "2024-03-04"}, periods("2024-03-01", "2024-03-04", IntervalWeek))
+	assert.Equal(t, []string{"2024-01", "2024-02"}, periods("2024-01-31", "2024-02-01", IntervalMonth))
+	assert.Len(t, periods("2024-03-01", "2024-03-31", IntervalDay), 31)
+}
+
+func TestBuildMetrics(t *testing.T) {
+	for _, handler := range multipleloan.NewHandlers(nil) {
+		registry.Register(handler)
+	}
+	registry.Register(taxscore.NewHandler(nil))
+
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 10, 0, 0, 0, time.UTC)
+	}
+
+	records := []*record{
+		{Time: day(1), ProductSlug: constant.SlugMultipleLoan7Days, Success: true, Data: []byte(`{"query_count":0}`)},
+		{Time: day(2), ProductSlug: constant.SlugMultipleLoan7Days, Success: true, Data: []byte(`{"query_count":4}`)},
+		{Time: day(2), ProductSlug: constant.SlugMultipleLoan7Days, Success: true, Data: []byte(`{"query_count":12}`)},
+		{Time: day(2), ProductSlug: constant.SlugMultipleLoan7Days, Status: 400},
+		{Time: day(5), ProductSlug: constant.SlugMultipleLoan7Days, Status: 502},
+		{Time: day(4), ProductSlug: constant.SlugTaxScore, Success: true, Data: []byte(`{"score":"67"}`)},
+		{Time: day(4), ProductSlug: constant.SlugGenRetailV3, Success: true, Grade: "A"},
+	}
+
+	result := buildMetrics(records, &Filter{StartDate: "2024-03-01", EndDate: "2024-03-10", Interval: IntervalWeek})
+
+	assert.Len(t, result.Products, 3)
+
+	loans := result.Products[0]
+	assert.Equal(t, constant.SlugMultipleLoan7Days, loans.ProductSlug)
+	assert.Equal(t, []*Point{
+		{Period: "2024-02-26", Success: 3, Failure: 1, Distribution: map[string]int{"0": 1, "3-5": 1, ">10": 1}},
+		{Period: "2024-03-04", Error: 1},
+	}, loans.Points)
+
+	assert.Equal(t, constant.SlugTaxScore, result.Products[1].ProductSlug)
+	assert.Equal(t, map[string]int{"60-69": 1}, result.Products[1].Points[1].Distribution)
+	assert.Equal(t, constant.SlugGenRetailV3, result.Products[2].ProductSlug)
+	assert.Equal(t, map[string]int{"A": 1}, result.Products[2].Points[1].Distribution)
+}
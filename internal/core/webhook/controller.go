@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	CreateWebhook(c *fiber.Ctx) error
+	GetWebhooks(c *fiber.Ctx) error
+	GetWebhook(c *fiber.Ctx) error
+	UpdateWebhook(c *fiber.Ctx) error
+	DeleteWebhook(c *fiber.Ctx) error
+	RotateSecret(c *fiber.Ctx) error
+	SendTest(c *fiber.Ctx) error
+	GetDeliveries(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) CreateWebhook(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*webhookRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.CreateWebhook(companyId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to create webhook",
+		result,
+	))
+}
+
+func (ctrl *controller) GetWebhooks(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetWebhooks(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get webhooks",
+		result,
+	))
+}
+
+func (ctrl *controller) GetWebhook(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetWebhook(companyId, c.Params("webhook_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get webhook",
+		result,
+	))
+}
+
+func (ctrl *controller) UpdateWebhook(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*updateWebhookRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	if err := ctrl.svc.UpdateWebhook(companyId, c.Params("webhook_id"), reqBody); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to update webhook",
+		nil,
+	))
+}
+
+func (ctrl *controller) DeleteWebhook(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.DeleteWebhook(companyId, c.Params("webhook_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to delete webhook",
+		nil,
+	))
+}
+
+func (ctrl *controller) RotateSecret(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.RotateSecret(companyId, c.Params("webhook_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to rotate webhook secret",
+		result,
+	))
+}
+
+func (ctrl *controller) SendTest(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.SendTest(companyId, c.Params("webhook_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to send test event",
+		result,
+	))
+}
+
+func (ctrl *controller) GetDeliveries(c *fiber.Ctx) error {
+	filter := &deliveryFilter{
+		CompanyId: fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		WebhookId: c.Params("webhook_id"),
+		Page:      c.Query(constant.Page, "1"),
+		Size:      c.Query(constant.Size, "10"),
+	}
+
+	result, err := ctrl.svc.GetDeliveries(filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
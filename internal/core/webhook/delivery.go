@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"front-office/pkg/common/constant"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxAttempts         = 5
+	initialBackoff      = 2 * time.Second
+	deliveryTimeout     = 10 * time.Second
+	rotationGracePeriod = 24 * time.Hour
+)
+
+// deliver attempts an event until the webhook answers 2xx or maxAttempts is
+// reached, doubling the wait between attempts.
+func (svc *service) deliver(companyId string, w *Webhook, event *Event) {
+	backoff := initialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if svc.attempt(companyId, w, event, attempt).Success {
+			return
+		}
+
+		if attempt < maxAttempts {
+			svc.sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	log.Warn().Uint("webhook_id", w.Id).Str("event", event.Type).Str("event_id", event.Id).Msg("webhook delivery gave up")
+}
+
+// attempt posts the event once and logs the outcome as a delivery.
+func (svc *service) attempt(companyId string, w *Webhook, event *Event, attempt int) *Delivery {
+	delivery := &Delivery{
+		WebhookId: w.Id,
+		EventId:   event.Id,
+		Event:     event.Type,
+		Attempt:   attempt,
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		delivery.Error = err.Error()
+		return svc.logDelivery(companyId, delivery)
+	}
+	delivery.Payload = string(body)
+
+	start := svc.now()
+	statusCode, err := svc.post(w, event, body, start)
+	delivery.DurationMs = svc.now().Sub(start).Milliseconds()
+	delivery.DeliveredAt = start.Format(constant.FormatDateAndTime)
+	delivery.StatusCode = statusCode
+
+	if err != nil {
+		delivery.Error = err.Error()
+	} else if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", statusCode)
+	} else {
+		delivery.Success = true
+	}
+
+	return svc.logDelivery(companyId, delivery)
+}
+
+func (svc *service) post(w *Webhook, event *Event, body []byte, sentAt time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XWebhookEvent, event.Type)
+	req.Header.Set(constant.XWebhookDelivery, event.Id)
+	req.Header.Set(constant.XWebhookSignature, signatureHeader(w, body, sentAt))
+
+	resp, err := svc.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+func (svc *service) logDelivery(companyId string, delivery *Delivery) *Delivery {
+	if err := svc.repo.CreateDeliveryAPI(companyId, delivery); err != nil {
+		log.Error().Err(err).Uint("webhook_id", delivery.WebhookId).Str("event_id", delivery.EventId).Msg("failed to log webhook delivery")
+	}
+
+	return delivery
+}
+
+// signatureHeader builds "t=<unix seconds>,v1=<signature>", adding a second
+// v1 made with the previous secret while a rotation is in its grace period.
+// Receivers accept the request when any v1 matches.
+func signatureHeader(w *Webhook, body []byte, sentAt time.Time) string {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	header := fmt.Sprintf("t=%s,v1=%s", timestamp, Sign(w.Secret, timestamp, body))
+
+	if w.PreviousSecret != "" && w.SecretRotatedAt != nil && sentAt.Sub(*w.SecretRotatedAt) < rotationGracePeriod {
+		header += ",v1=" + Sign(w.PreviousSecret, timestamp, body)
+	}
+
+	return header
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" under secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
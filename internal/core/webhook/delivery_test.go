@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"errors"
+	"front-office/pkg/common/constant"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	Repository
+	mock.Mock
+}
+
+func (m *MockRepository) CreateDeliveryAPI(companyId string, payload *Delivery) error {
+	args := m.Called(companyId, payload)
+	return args.Error(0)
+}
+
+func newTestService(client *MockClient, repo *MockRepository, slept *[]time.Duration) *service {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return &service{
+		repo:   repo,
+		client: client,
+		sleep:  func(d time.Duration) { *slept = append(*slept, d) },
+		now:    func() time.Time { return now },
+	}
+}
+
+func statusResponse(code int) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(""))}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686", Sign("secret", "1700000000", []byte(`{"a":1}`)))
+	assert.NotEqual(t, Sign("secret", "1700000000", []byte(`{"a":1}`)), Sign("other", "1700000000", []byte(`{"a":1}`)))
+	assert.Len(t, Sign("secret", "1700000000", nil), 64)
+}
+
+func TestSignatureHeader(t *testing.T) {
+	sentAt := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"job.completed"}`)
+
+	t.Run("should sign with the current secret", func(t *testing.T) {
+		header := signatureHeader(&Webhook{Secret: "new"}, body, sentAt)
+
+		assert.Equal(t, "t=1700000000,v1="+Sign("new", "1700000000", body), header)
+	})
+
+	t.Run("should add the previous secret during the grace period", func(t *testing.T) {
+		rotatedAt := sentAt.Add(-time.Hour)
+		header := signatureHeader(&Webhook{Secret: "new", PreviousSecret: "old", SecretRotatedAt: &rotatedAt}, body, sentAt)
+
+		assert.Equal(t, "t=1700000000,v1="+Sign("new", "1700000000", body)+",v1="+Sign("old", "1700000000", body), header)
+	})
+
+	t.Run("should drop the previous secret after the grace period", func(t *testing.T) {
+		rotatedAt := sentAt.Add(-rotationGracePeriod)
+		header := signatureHeader(&Webhook{Secret: "new", PreviousSecret: "old", SecretRotatedAt: &rotatedAt}, body, sentAt)
+
+		assert.NotContains(t, header, Sign("old", "1700000000", body))
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	webhook := &Webhook{Id: 1, Url: "https://example.com/hook", Secret: "secret"}
+	event := &Event{Id: "event-id", Type: EventJobCompleted}
+
+	t.Run("should stop retrying once delivered", func(t *testing.T) {
+		client := new(MockClient)
+		client.On("Do", mock.Anything).Return(statusResponse(http.StatusInternalServerError), nil).Once()
+		client.On("Do", mock.Anything).Return(statusResponse(http.StatusOK), nil).Once()
+
+		repo := new(MockRepository)
+		repo.On("CreateDeliveryAPI", constant.DummyCompanyId, mock.Anything).Return(nil)
+
+		var slept []time.Duration
+		newTestService(client, repo, &slept).deliver(constant.DummyCompanyId, webhook, event)
+
+		assert.Equal(t, []time.Duration{initialBackoff}, slept)
+		client.AssertNumberOfCalls(t, "Do", 2)
+		repo.AssertNumberOfCalls(t, "CreateDeliveryAPI", 2)
+	})
+
+	t.Run("should back off exponentially and give up after max attempts", func(t *testing.T) {
+		client := new(MockClient)
+		client.On("Do", mock.Anything).Return(statusResponse(http.StatusOK), errors.New(constant.ErrHTTPReqFailed))
+
+		repo := new(MockRepository)
+		repo.On("CreateDeliveryAPI", constant.DummyCompanyId, mock.Anything).Return(nil)
+
+		var slept []time.Duration
+		newTestService(client, repo, &slept).deliver(constant.DummyCompanyId, webhook, event)
+
+		assert.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second}, slept)
+		client.AssertNumberOfCalls(t, "Do", maxAttempts)
+	})
+
+	t.Run("should sign and log the attempt", func(t *testing.T) {
+		client := new(MockClient)
+		client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Header.Get(constant.XWebhookEvent) == EventJobCompleted &&
+				req.Header.Get(constant.XWebhookDelivery) == "event-id" &&
+				strings.HasPrefix(req.Header.Get(constant.XWebhookSignature), "t=")
+		})).Return(statusResponse(http.StatusNoContent), nil)
+
+		repo := new(MockRepository)
+		repo.On("CreateDeliveryAPI", constant.DummyCompanyId, mock.MatchedBy(func(d *Delivery) bool {
+			return d.Success && d.StatusCode == http.StatusNoContent && d.Attempt == 1
+		})).Return(nil)
+
+		var slept []time.Duration
+		delivery := newTestService(client, repo, &slept).attempt(constant.DummyCompanyId, webhook, event, 1)
+
+		assert.True(t, delivery.Success)
+		client.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+}
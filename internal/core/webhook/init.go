@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	service := NewService(repository)
+	controller := NewController(service)
+
+	apiGroup.Post("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(webhookRequest{}), controller.CreateWebhook)
+	apiGroup.Get("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetWebhooks)
+	apiGroup.Get("/:webhook_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetWebhook)
+	apiGroup.Put("/:webhook_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(updateWebhookRequest{}), controller.UpdateWebhook)
+	apiGroup.Delete("/:webhook_id", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.DeleteWebhook)
+	apiGroup.Post("/:webhook_id/rotate-secret", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.RotateSecret)
+	apiGroup.Post("/:webhook_id/test", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.SendTest)
+	apiGroup.Get("/:webhook_id/deliveries", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.GetDeliveries)
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import "time"
+
+// Events a webhook can subscribe to. EventTest is only sent by the test-send
+// endpoint.
+const (
+	EventJobCreated       = "job.created"
+	EventJobCompleted     = "job.completed"
+	EventJobFailed        = "job.failed"
+	EventWatchlistChanged = "watchlist.changed"
+	EventTest             = "webhook.test"
+)
+
+var subscribableEvents = []string{EventJobCreated, EventJobCompleted, EventJobFailed, EventWatchlistChanged}
+
+// Webhook is a company endpoint notified of job events. Secret is only
+// returned when it is generated; PreviousSecret keeps signing deliveries
+// for a grace period after a rotation so receivers can switch over.
+type Webhook struct {
+	Id              uint       `json:"id"`
+	CompanyId       uint       `json:"company_id"`
+	Url             string     `json:"url"`
+	Events          []string   `json:"events"`
+	IsActive        bool       `json:"is_active"`
+	Secret          string     `json:"secret,omitempty"`
+	PreviousSecret  string     `json:"previous_secret,omitempty"`
+	SecretRotatedAt *time.Time `json:"secret_rotated_at"`
+	CreatedAt       string     `json:"created_at"`
+}
+
+// Delivery is the log of one attempt to deliver an event to a webhook.
+type Delivery struct {
+	Id          uint   `json:"id"`
+	WebhookId   uint   `json:"webhook_id"`
+	EventId     string `json:"event_id"`
+	Event       string `json:"event"`
+	Attempt     int    `json:"attempt"`
+	Payload     string `json:"payload"`
+	StatusCode  int    `json:"status_code"`
+	Success     bool   `json:"success"`
+	Error       string `json:"error"`
+	DurationMs  int64  `json:"duration_ms"`
+	DeliveredAt string `json:"delivered_at"`
+}
+
+// Event is the JSON body posted to webhooks.
+type Event struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	CompanyId string `json:"company_id"`
+	CreatedAt string `json:"created_at"`
+	Data      any    `json:"data"`
+}
+
+// JobEventData is the data of job lifecycle events.
+type JobEventData struct {
+	JobId        string `json:"job_id"`
+	Status       string `json:"status"`
+	ProductId    uint   `json:"product_id,omitempty"`
+	Total        int    `json:"total,omitempty"`
+	SuccessCount uint   `json:"success_count,omitempty"`
+}
+
+type webhookRequest struct {
+	Url    string   `json:"url" validate:"required~Url cannot be empty, url~Url is not valid"`
+	Events []string `json:"events" validate:"required~Events cannot be empty"`
+}
+
+type updateWebhookRequest struct {
+	Url      *string  `json:"url"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"is_active"`
+}
+
+type createWebhookPayload struct {
+	CompanyId string   `json:"company_id"`
+	Url       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret"`
+}
+
+type deliveryFilter struct {
+	CompanyId string
+	WebhookId string
+	Page      string
+	Size      string
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallCreateWebhookAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Webhook{Id: 1, Url: "https://example.com/hook"}), nil)
+
+		result, err := repo.CreateWebhookAPI(&createWebhookPayload{CompanyId: constant.DummyCompanyId})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), result.Id)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		result, err := repo.CreateWebhookAPI(&createWebhookPayload{})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		_, err := repo.CreateWebhookAPI(&createWebhookPayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.CreateWebhookAPI(&createWebhookPayload{})
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetWebhooksAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Webhook{{Id: 1}, {Id: 2}}), nil)
+
+		result, err := repo.GetWebhooksAPI(constant.DummyCompanyId)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetWebhooksAPI(constant.DummyCompanyId)
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallUpdateWebhookAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.UpdateWebhookAPI(constant.DummyCompanyId, "1", map[string]interface{}{"is_active": false})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.UpdateWebhookAPI(constant.DummyCompanyId, "1", map[string]interface{}{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallCreateDeliveryAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.CreateDeliveryAPI(constant.DummyCompanyId, &Delivery{WebhookId: 1, Attempt: 1})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.CreateDeliveryAPI(constant.DummyCompanyId, &Delivery{WebhookId: 1})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallGetDeliveriesAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Delivery{{Id: 1, Success: true}}), nil)
+
+		result, err := repo.GetDeliveriesAPI(&deliveryFilter{CompanyId: constant.DummyCompanyId, WebhookId: "1", Page: "1", Size: "10"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 1)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetDeliveriesAPI(&deliveryFilter{WebhookId: "1"})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/model"
+	"front-office/pkg/httpclient"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// NewService returns the webhook service. Deliveries go through their own
+// client, which only connects to public addresses.
+func NewService(repo Repository) Service {
+	return &service{
+		repo:   repo,
+		client: httpclient.NewPublicClient(deliveryTimeout),
+		sleep:  time.Sleep,
+		now:    time.Now,
+	}
+}
+
+type service struct {
+	repo   Repository
+	client httpclient.HTTPClient
+	sleep  func(time.Duration)
+	now    func() time.Time
+}
+
+type Service interface {
+	CreateWebhook(companyId string, req *webhookRequest) (*Webhook, error)
+	GetWebhooks(companyId string) ([]*Webhook, error)
+	GetWebhook(companyId, webhookId string) (*Webhook, error)
+	UpdateWebhook(companyId, webhookId string, req *updateWebhookRequest) error
+	DeleteWebhook(companyId, webhookId string) error
+	RotateSecret(companyId, webhookId string) (*Webhook, error)
+	SendTest(companyId, webhookId string) (*Delivery, error)
+	GetDeliveries(filter *deliveryFilter) (*model.AifcoreAPIResponse[[]*Delivery], error)
+	Dispatch(companyId, eventType string, data any)
+}
+
+func (svc *service) CreateWebhook(companyId string, req *webhookRequest) (*Webhook, error) {
+	if err := validateUrl(strings.TrimSpace(req.Url)); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	if err := validateEvents(req.Events); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, apperror.Internal("failed to generate webhook secret", err)
+	}
+
+	result, err := svc.repo.CreateWebhookAPI(&createWebhookPayload{
+		CompanyId: companyId,
+		Url:       strings.TrimSpace(req.Url),
+		Events:    req.Events,
+		Secret:    secret,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to create webhook")
+	}
+
+	result.Secret = secret
+	result.PreviousSecret = ""
+
+	return result, nil
+}
+
+func (svc *service) GetWebhooks(companyId string) ([]*Webhook, error) {
+	result, err := svc.repo.GetWebhooksAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch webhooks")
+	}
+
+	for _, w := range result {
+		hideSecrets(w)
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetWebhook(companyId, webhookId string) (*Webhook, error) {
+	result, err := svc.getWebhook(companyId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	hideSecrets(result)
+
+	return result, nil
+}
+
+func (svc *service) UpdateWebhook(companyId, webhookId string, req *updateWebhookRequest) error {
+	data := map[string]interface{}{}
+
+	if req.Url != nil {
+		webhookUrl := strings.TrimSpace(*req.Url)
+		if err := validateUrl(webhookUrl); err != nil {
+			return apperror.BadRequest(err.Error())
+		}
+		data["url"] = webhookUrl
+	}
+
+	if req.Events != nil {
+		if err := validateEvents(req.Events); err != nil {
+			return apperror.BadRequest(err.Error())
+		}
+		data["events"] = req.Events
+	}
+
+	if req.IsActive != nil {
+		data["is_active"] = *req.IsActive
+	}
+
+	if err := svc.repo.UpdateWebhookAPI(companyId, webhookId, data); err != nil {
+		return apperror.MapRepoError(err, "failed to update webhook")
+	}
+
+	return nil
+}
+
+func (svc *service) DeleteWebhook(companyId, webhookId string) error {
+	if err := svc.repo.DeleteWebhookAPI(companyId, webhookId); err != nil {
+		return apperror.MapRepoError(err, "failed to delete webhook")
+	}
+
+	return nil
+}
+
+// RotateSecret replaces the signing secret of a webhook. Deliveries keep
+// carrying a signature made with the old secret for rotationGracePeriod.
+func (svc *service) RotateSecret(companyId, webhookId string) (*Webhook, error) {
+	current, err := svc.getWebhook(companyId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, apperror.Internal("failed to generate webhook secret", err)
+	}
+
+	rotatedAt := svc.now()
+	if err := svc.repo.UpdateWebhookAPI(companyId, webhookId, map[string]interface{}{
+		"secret":            secret,
+		"previous_secret":   current.Secret,
+		"secret_rotated_at": rotatedAt,
+	}); err != nil {
+		return nil, apperror.MapRepoError(err, "failed to rotate webhook secret")
+	}
+
+	current.Secret = secret
+	current.PreviousSecret = ""
+	current.SecretRotatedAt = &rotatedAt
+
+	return current, nil
+}
+
+// SendTest delivers a test event once, without retrying, and returns the
+// logged attempt.
+func (svc *service) SendTest(companyId, webhookId string) (*Delivery, error) {
+	w, err := svc.getWebhook(companyId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	event := svc.newEvent(companyId, EventTest, map[string]string{"message": "webhook test"})
+
+	return svc.attempt(companyId, w, event, 1), nil
+}
+
+func (svc *service) GetDeliveries(filter *deliveryFilter) (*model.AifcoreAPIResponse[[]*Delivery], error) {
+	result, err := svc.repo.GetDeliveriesAPI(filter)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch webhook deliveries")
+	}
+
+	return result, nil
+}
+
+// Dispatch sends an event to every active webhook of the company subscribed
+// to it. Delivery happens in the background; failures are retried with
+// backoff and every attempt is logged.
+func (svc *service) Dispatch(companyId, eventType string, data any) {
+	go func() {
+		webhooks, err := svc.repo.GetWebhooksAPI(companyId)
+		if err != nil {
+			log.Error().Err(err).Str("company_id", companyId).Str("event", eventType).Msg("failed to fetch webhooks")
+			return
+		}
+
+		event := svc.newEvent(companyId, eventType, data)
+		for _, w := range webhooks {
+			if w.IsActive && isSubscribed(w, eventType) {
+				go svc.deliver(companyId, w, event)
+			}
+		}
+	}()
+}
+
+func (svc *service) getWebhook(companyId, webhookId string) (*Webhook, error) {
+	result, err := svc.repo.GetWebhookAPI(companyId, webhookId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch webhook")
+	}
+
+	if result == nil {
+		return nil, apperror.NotFound("webhook not found")
+	}
+
+	return result, nil
+}
+
+func (svc *service) newEvent(companyId, eventType string, data any) *Event {
+	return &Event{
+		Id:        uuid.NewString(),
+		Type:      eventType,
+		CompanyId: companyId,
+		CreatedAt: svc.now().Format(time.RFC3339),
+		Data:      data,
+	}
+}
+
+// validateUrl accepts https URLs whose host only resolves to public
+// addresses. Deliveries check the dialed address again, as DNS may change.
+func validateUrl(rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("url is not valid")
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("url must use https")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("url host %s cannot be resolved", u.Hostname())
+	}
+
+	for _, addr := range addrs {
+		if !httpclient.IsPublicIP(addr.IP) {
+			return fmt.Errorf("url must not point to a loopback, private or link-local address")
+		}
+	}
+
+	return nil
+}
+
+func validateEvents(events []string) error {
+	if len(events) == 0 {
+		return fmt.Errorf("at least one event is required")
+	}
+
+	for _, event := range events {
+		known := false
+		for _, e := range subscribableEvents {
+			if e == event {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown event %s", event)
+		}
+	}
+
+	return nil
+}
+
+func isSubscribed(w *Webhook, eventType string) bool {
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hideSecrets(w *Webhook) {
+	w.Secret = ""
+	w.PreviousSecret = ""
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUrl(t *testing.T) {
+	t.Run("should accept a public https url", func(t *testing.T) {
+		assert.NoError(t, validateUrl("https://93.184.216.34/hooks"))
+	})
+
+	for name, rawUrl := range map[string]string{
+		"plain http":    "http://93.184.216.34/hooks",
+		"missing host":  "https:///hooks",
+		"loopback":      "https://127.0.0.1/hooks",
+		"ipv6 loopback": "https://[::1]/hooks",
+		"private":       "https://10.0.0.8/hooks",
+		"link-local":    "https://169.254.169.254/latest/meta-data",
+		"unspecified":   "https://0.0.0.0/hooks",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			assert.Error(t, validateUrl(rawUrl))
+		})
+	}
+}
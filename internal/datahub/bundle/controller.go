@@ -0,0 +1,118 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"front-office/internal/core/masking"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	SingleRequest(c *fiber.Ctx) error
+	BulkRequest(c *fiber.Ctx) error
+	GetBundleJob(c *fiber.Ctx) error
+	ExportBundleJob(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) SingleRequest(c *fiber.Ctx) error {
+	reqBody, ok := c.Locals(constant.Request).(*bundleRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
+
+	memberId, err := helper.InterfaceToUint(c.Locals(constant.UserId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidUserSession)
+	}
+
+	companyId, err := helper.InterfaceToUint(c.Locals(constant.CompanyId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidCompanySession)
+	}
+
+	result, err := ctrl.svc.SingleRequest(apiKey, memberId, companyId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"success",
+		result,
+	))
+}
+
+func (ctrl *controller) BulkRequest(c *fiber.Ctx) error {
+	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
+
+	memberId, err := helper.InterfaceToUint(c.Locals(constant.UserId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidUserSession)
+	}
+
+	companyId, err := helper.InterfaceToUint(c.Locals(constant.CompanyId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidCompanySession)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	products := strings.Split(c.FormValue("products"), ",")
+
+	result, err := ctrl.svc.BulkRequest(apiKey, memberId, companyId, products, file)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"success",
+		result,
+	))
+}
+
+func (ctrl *controller) GetBundleJob(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetBundleJob(c.Params("bundle_job_id"), companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get bundle job",
+		result,
+	))
+}
+
+func (ctrl *controller) ExportBundleJob(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+	tierLevel := fmt.Sprintf("%v", c.Locals(constant.RoleId))
+
+	var buf bytes.Buffer
+	filename, err := ctrl.svc.ExportBundleJob(c.Params("bundle_job_id"), memberId, companyId, tierLevel, masking.IsMasked(c), &buf)
+	if err != nil {
+		return err
+	}
+
+	c.Set(constant.HeaderContentType, constant.TextOrCSVContentType)
+	c.Set(constant.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filename))
+	return c.SendStream(bytes.NewReader(buf.Bytes()))
+}
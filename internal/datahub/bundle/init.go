@@ -0,0 +1,56 @@
+package bundle
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/audit"
+	"front-office/internal/core/log/operation"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/masking"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/role"
+	"front-office/internal/core/subscription"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/job"
+	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupInit serves the bundle endpoints, which run one applicant through
+// several registered products at once.
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	productRepo := product.NewRepository(cfg, client)
+	jobRepo := job.NewRepository(cfg, client, nil)
+	transactionRepo := transaction.NewRepository(cfg, client, nil)
+	columnMappingRepo := columnmapping.NewRepository(cfg, client, nil)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	webhookRepo := webhook.NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	quotaRepo := quota.NewRepository(cfg, client, nil)
+	subscriptionRepo := subscription.NewRepository(cfg, client)
+
+	decisionService := decision.NewService(decisionRepo)
+	webhookService := webhook.NewService(webhookRepo)
+	quotaService := quota.NewService(quotaRepo, memberRepo)
+	subscriptionService := subscription.NewService(subscriptionRepo, memberRepo)
+	jobService := job.NewService(jobRepo, transactionRepo, decisionService, webhookService, quotaService)
+	columnMappingService := columnmapping.NewService(columnMappingRepo)
+	gatewayService := gateway.NewService(productRepo, transactionRepo, jobService, columnMappingService, quotaService)
+	service := NewService(repository, productRepo, jobService, gatewayService, decisionService, subscriptionService)
+	controller := NewController(service)
+	auditService := audit.NewService(operation.NewRepository(cfg, client, nil), memberRepo)
+	resolveMasking := masking.Resolve(masking.NewService(masking.NewRepository(cfg, client, nil), role.NewRepository(cfg, client)))
+
+	apiGroup.Post("/single-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(bundleRequest{}), controller.SingleRequest)
+	apiGroup.Post("/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.BulkRequest)
+	apiGroup.Get("/jobs/:bundle_job_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, "bundle"), controller.GetBundleJob)
+	apiGroup.Get("/jobs/:bundle_job_id/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, "bundle"), controller.ExportBundleJob)
+}
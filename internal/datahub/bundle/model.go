@@ -0,0 +1,88 @@
+package bundle
+
+import (
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/helper"
+)
+
+// applicantColumns are the fields a bundle row carries. Only products whose
+// request fits inside them can be bundled.
+var applicantColumns = []helper.CSVColumn{
+	{Field: "name", Header: "Name"},
+	{Field: "nik", Header: "ID Card Number"},
+	{Field: "phone_number", Header: "Phone Number"},
+}
+
+type bundleRequest struct {
+	Name        string   `json:"name"`
+	Nik         string   `json:"nik"`
+	PhoneNumber string   `json:"phone_number"`
+	Products    []string `json:"products" validate:"required~Products cannot be empty"`
+}
+
+// BundleJob groups the jobs created for each product of one bundle request
+// or upload.
+type BundleJob struct {
+	Id        uint                `json:"id"`
+	MemberId  uint                `json:"member_id"`
+	CompanyId uint                `json:"company_id"`
+	FileName  string              `json:"file_name"`
+	Total     int                 `json:"total"`
+	Products  []*bundleJobProduct `json:"products"`
+	CreatedAt string              `json:"created_at"`
+}
+
+type bundleJobProduct struct {
+	ProductSlug string `json:"product_slug"`
+	JobId       uint   `json:"job_id"`
+}
+
+type createBundleJobRequest struct {
+	MemberId  string              `json:"member_id"`
+	CompanyId string              `json:"company_id"`
+	FileName  string              `json:"file_name"`
+	Total     int                 `json:"total"`
+	Products  []*bundleJobProduct `json:"products"`
+}
+
+type createBundleJobRespData struct {
+	Id uint `json:"id"`
+}
+
+type bundleResult struct {
+	Product    string `json:"product"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+	Data       any    `json:"data"`
+}
+
+type bundleSingleResponse struct {
+	BundleJobId uint              `json:"bundle_job_id"`
+	Input       map[string]string `json:"input"`
+	Results     []*bundleResult   `json:"results"`
+	Decision    *decision.Outcome `json:"decision"`
+}
+
+type bulkRequestRespData struct {
+	BundleJobId uint `json:"bundle_job_id"`
+	Total       int  `json:"total"`
+}
+
+// bundleRun is a started bundle: one product job per handler, in request order.
+type bundleRun struct {
+	BundleJobId uint
+	Products    []*bundleRunProduct
+	MemberId    uint
+	CompanyId   uint
+	FileName    string
+}
+
+type bundleRunProduct struct {
+	Handler        *registry.ProductHandler
+	Params         *registry.CallParams
+	ProductId      uint
+	ProductGroupId uint
+	JobId          uint
+}
@@ -0,0 +1,533 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"front-office/internal/core/product"
+	"front-office/internal/core/subscription"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func NewService(
+	repo Repository,
+	productRepo product.Repository,
+	jobService job.Service,
+	gatewaySvc gateway.Service,
+	decisionSvc decision.Service,
+	subscriptionSvc subscription.Service,
+) Service {
+	return &service{
+		repo,
+		productRepo,
+		jobService,
+		gatewaySvc,
+		decisionSvc,
+		subscriptionSvc,
+	}
+}
+
+type service struct {
+	repo            Repository
+	productRepo     product.Repository
+	jobService      job.Service
+	gatewaySvc      gateway.Service
+	decisionSvc     decision.Service
+	subscriptionSvc subscription.Service
+}
+
+type Service interface {
+	SingleRequest(apiKey string, memberId, companyId uint, req *bundleRequest) (*bundleSingleResponse, error)
+	BulkRequest(apiKey string, memberId, companyId uint, products []string, file *multipart.FileHeader) (*bulkRequestRespData, error)
+	GetBundleJob(bundleJobId, companyId string) (*BundleJob, error)
+	ExportBundleJob(bundleJobId, memberId, companyId, tierLevel string, isMasked bool, buf *bytes.Buffer) (string, error)
+}
+
+func (svc *service) SingleRequest(apiKey string, memberId, companyId uint, req *bundleRequest) (*bundleSingleResponse, error) {
+	fields := map[string]string{
+		"name":         req.Name,
+		"nik":          req.Nik,
+		"phone_number": req.PhoneNumber,
+	}
+	records := [][]string{
+		helper.CSVHeaders(applicantColumns),
+		{req.Name, req.Nik, req.PhoneNumber},
+	}
+
+	run, err := svc.startBundle(apiKey, memberId, companyId, req.Products, "", records)
+	if err != nil {
+		return nil, err
+	}
+
+	results := svc.processApplicant(run, helper.CSVRowNumber(1), fields)
+
+	if err := svc.finalizeBundle(run); err != nil {
+		return nil, err
+	}
+
+	outcome, err := svc.decideApplicant(run, helper.CSVRowNumber(1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundleSingleResponse{
+		BundleJobId: run.BundleJobId,
+		Input:       fields,
+		Results:     results,
+		Decision:    outcome,
+	}, nil
+}
+
+func (svc *service) BulkRequest(apiKey string, memberId, companyId uint, products []string, file *multipart.FileHeader) (*bulkRequestRespData, error) {
+	if err := helper.ValidateUploadedFile(file, 30*1024*1024, []string{".csv"}); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	records, err := helper.ParseCSVFile(file, helper.CSVHeaders(applicantColumns))
+	if err != nil {
+		return nil, apperror.Internal(constant.FailedParseCSV, err)
+	}
+
+	run, err := svc.startBundle(apiKey, memberId, companyId, products, file.Filename, records)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg         sync.WaitGroup
+		batchCount = 0
+	)
+
+	for i := 1; i < len(records); i++ { // skip header
+		fields := make(map[string]string, len(applicantColumns))
+		for j, column := range applicantColumns {
+			if j < len(records[i]) {
+				fields[column.Field] = records[i][j]
+			}
+		}
+
+		wg.Add(1)
+
+		go func(rowNumber int, fields map[string]string) {
+			defer wg.Done()
+
+			svc.processApplicant(run, rowNumber, fields)
+		}(helper.CSVRowNumber(i), fields)
+
+		batchCount++
+		if batchCount == 100 {
+			time.Sleep(time.Second)
+			batchCount = 0
+		}
+	}
+
+	wg.Wait()
+
+	if err := svc.finalizeBundle(run); err != nil {
+		return nil, err
+	}
+
+	return &bulkRequestRespData{
+		BundleJobId: run.BundleJobId,
+		Total:       len(records) - 1,
+	}, nil
+}
+
+func (svc *service) GetBundleJob(bundleJobId, companyId string) (*BundleJob, error) {
+	result, err := svc.repo.GetBundleJobAPI(bundleJobId, companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch bundle job")
+	}
+
+	if result == nil {
+		return nil, apperror.NotFound("bundle job not found")
+	}
+
+	return result, nil
+}
+
+// ExportBundleJob writes the applicants of a bundle as uploaded, followed by
+// the result columns of every bundled product, one row per applicant. When
+// isMasked is set the personal data of applicants and results is masked.
+func (svc *service) ExportBundleJob(bundleJobId, memberId, companyId, tierLevel string, isMasked bool, buf *bytes.Buffer) (string, error) {
+	bundleJob, err := svc.GetBundleJob(bundleJobId, companyId)
+	if err != nil {
+		return "", err
+	}
+
+	if len(bundleJob.Products) == 0 {
+		return "", apperror.NotFound("bundle job has no products")
+	}
+
+	source, err := svc.jobService.GetJobSource(helper.ConvertUintToString(bundleJob.Products[0].JobId), memberId, companyId, tierLevel)
+	if err != nil {
+		return "", err
+	}
+
+	headers, rows, values, err := svc.consolidateResults(bundleJob.Products, memberId, companyId, isMasked)
+	if err != nil {
+		return "", err
+	}
+
+	ruleSet, err := svc.decisionSvc.GetActiveRuleSet(companyId)
+	if err != nil {
+		return "", err
+	}
+
+	if ruleSet != nil {
+		headers = append(headers, decision.Headers...)
+		for rowNumber, row := range rows {
+			rows[rowNumber] = append(row, decision.Evaluate(ruleSet, values[rowNumber]).Columns()...)
+		}
+	}
+
+	records := source.Records
+	if isMasked {
+		handlers := make([]*registry.ProductHandler, 0, len(bundleJob.Products))
+		for _, p := range bundleJob.Products {
+			if handler, ok := registry.Get(p.ProductSlug); ok {
+				handlers = append(handlers, handler)
+			}
+		}
+		records = source.MaskedRecords(handlers...)
+	}
+
+	if err := helper.WriteEnrichedCSV(buf, records, headers, rows); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	return formatBundleFileName(source.FileName, bundleJobId), nil
+}
+
+// consolidateResults reads back the results of every product job of a bundle
+// and joins them per applicant: result cells side by side, headers suffixed
+// with the product, and the decision rule values of all products merged.
+func (svc *service) consolidateResults(products []*bundleJobProduct, memberId, companyId string, isMasked bool) ([]string, map[int][]string, map[int]map[string]string, error) {
+	var (
+		headers []string
+		widths  = make([]int, len(products))
+		rows    = make([]map[int][]string, len(products))
+		values  = map[int]map[string]string{}
+	)
+
+	for i, p := range products {
+		handler, ok := registry.Get(p.ProductSlug)
+		if !ok {
+			return nil, nil, nil, apperror.BadRequest(constant.UnsupportedProductSlug)
+		}
+
+		results, err := svc.jobService.GetJobResults(helper.ConvertUintToString(p.JobId), memberId, companyId, p.ProductSlug, isMasked)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, header := range results.Headers {
+			headers = append(headers, fmt.Sprintf("%s (%s)", header, handler.RouteSlug))
+		}
+		widths[i] = len(results.Headers)
+		rows[i] = results.Rows
+
+		for rowNumber, productValues := range results.RuleValues {
+			if values[rowNumber] == nil {
+				values[rowNumber] = map[string]string{}
+			}
+			for field, value := range productValues {
+				values[rowNumber][field] = value
+			}
+		}
+	}
+
+	return headers, consolidateRows(widths, rows), values, nil
+}
+
+// decideApplicant applies the company's active rule set to one applicant of
+// a finished bundle. It returns nil when no rule set is active.
+func (svc *service) decideApplicant(run *bundleRun, rowNumber int) (*decision.Outcome, error) {
+	companyId := helper.ConvertUintToString(run.CompanyId)
+
+	ruleSet, err := svc.decisionSvc.GetActiveRuleSet(companyId)
+	if err != nil || ruleSet == nil {
+		return nil, err
+	}
+
+	products := make([]*bundleJobProduct, len(run.Products))
+	for i, p := range run.Products {
+		products[i] = &bundleJobProduct{ProductSlug: p.Handler.Slug, JobId: p.JobId}
+	}
+
+	_, _, values, err := svc.consolidateResults(products, helper.ConvertUintToString(run.MemberId), companyId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return decision.Evaluate(ruleSet, values[rowNumber]), nil
+}
+
+// startBundle creates a job per requested product, keeps the applicants with
+// each of them and records the jobs under one bundle job.
+func (svc *service) startBundle(apiKey string, memberId, companyId uint, products []string, fileName string, records [][]string) (*bundleRun, error) {
+	handlers, err := resolveHandlers(products)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIdStr := helper.ConvertUintToString(memberId)
+	companyIdStr := helper.ConvertUintToString(companyId)
+
+	for _, handler := range handlers {
+		if err := svc.subscriptionSvc.Authorize(companyIdStr, memberIdStr, handler.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	run := &bundleRun{
+		MemberId:  memberId,
+		CompanyId: companyId,
+		FileName:  fileName,
+	}
+	jobs := make([]*bundleJobProduct, 0, len(handlers))
+
+	for _, handler := range handlers {
+		product, err := svc.productRepo.GetProductAPI(handler.Slug)
+		if err != nil {
+			return nil, apperror.MapRepoError(err, constant.FailedFetchProduct)
+		}
+		if product.ProductId == 0 {
+			return nil, apperror.NotFound(constant.ProductNotFound)
+		}
+
+		jobRes, err := svc.jobService.CreateJob(&job.CreateJobRequest{
+			ProductId: product.ProductId,
+			MemberId:  memberIdStr,
+			CompanyId: companyIdStr,
+			Total:     len(records) - 1,
+			Calls:     handler.CallCount(),
+		})
+		if err != nil {
+			svc.failJobs(run)
+
+			return nil, err
+		}
+		jobIdStr := helper.ConvertUintToString(jobRes.JobId)
+
+		run.Products = append(run.Products, &bundleRunProduct{
+			Handler: handler,
+			Params: &registry.CallParams{
+				APIKey:    apiKey,
+				JobId:     jobIdStr,
+				MemberId:  memberIdStr,
+				CompanyId: companyIdStr,
+			},
+			ProductId:      product.ProductId,
+			ProductGroupId: product.ProductGroupId,
+			JobId:          jobRes.JobId,
+		})
+		jobs = append(jobs, &bundleJobProduct{
+			ProductSlug: handler.Slug,
+			JobId:       jobRes.JobId,
+		})
+
+		// the consolidated export reads the applicants back from the jobs
+		if err := svc.jobService.SaveJobSource(jobIdStr, &job.JobSource{
+			FileName: fileName,
+			Records:  records,
+			Fields:   helper.HeaderFields(helper.CSVHeaders(applicantColumns), applicantColumns),
+		}); err != nil {
+			svc.failJobs(run)
+
+			return nil, err
+		}
+	}
+
+	bundleJob, err := svc.repo.CreateBundleJobAPI(&createBundleJobRequest{
+		MemberId:  memberIdStr,
+		CompanyId: companyIdStr,
+		FileName:  fileName,
+		Total:     len(records) - 1,
+		Products:  jobs,
+	})
+	if err != nil {
+		svc.failJobs(run)
+
+		return nil, apperror.MapRepoError(err, "failed to create bundle job")
+	}
+	run.BundleJobId = bundleJob.Id
+
+	return run, nil
+}
+
+// failJobs releases the jobs and quota already taken by the products of a
+// bundle that could not be started.
+func (svc *service) failJobs(run *bundleRun) {
+	companyId := helper.ConvertUintToString(run.CompanyId)
+	for _, p := range run.Products {
+		if err := svc.jobService.FinalizeFailedJob(p.Params.JobId, companyId); err != nil {
+			log.Error().Err(err).Str("job_id", p.Params.JobId).Msg("failed to fail bundle job")
+		}
+	}
+}
+
+// processApplicant sends one applicant to every product of the bundle in
+// parallel and returns the outcomes in bundle order.
+func (svc *service) processApplicant(run *bundleRun, rowNumber int, fields map[string]string) []*bundleResult {
+	var wg sync.WaitGroup
+	results := make([]*bundleResult, len(run.Products))
+
+	for i, p := range run.Products {
+		wg.Add(1)
+
+		go func(i int, p *bundleRunProduct) {
+			defer wg.Done()
+
+			results[i] = svc.processProduct(run, p, rowNumber, fields)
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (svc *service) processProduct(run *bundleRun, p *bundleRunProduct, rowNumber int, fields map[string]string) *bundleResult {
+	result := &bundleResult{Product: p.Handler.RouteSlug}
+
+	req, err := p.Handler.RequestFromFields(fields)
+	if err != nil {
+		result.StatusCode = http.StatusInternalServerError
+		result.Message = err.Error()
+		return result
+	}
+
+	resp, err := svc.gatewaySvc.ProcessRow(&gateway.RowContext{
+		Handler:        p.Handler,
+		Params:         p.Params,
+		MemberId:       run.MemberId,
+		CompanyId:      run.CompanyId,
+		ProductId:      p.ProductId,
+		ProductGroupId: p.ProductGroupId,
+		JobId:          p.JobId,
+		RowNumber:      rowNumber,
+		FileName:       run.FileName,
+		Request:        req,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("product", p.Handler.Slug).Int("row_number", rowNumber).Msg("error during bundle processing")
+
+		result.StatusCode = http.StatusInternalServerError
+		var appErr *apperror.AppError
+		if apperror.AsAppError(err, &appErr) {
+			result.StatusCode = appErr.StatusCode
+		}
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Success = resp.Success
+	result.StatusCode = resp.StatusCode
+	result.Message = resp.Message
+	result.Data = resp.Data
+
+	return result
+}
+
+func (svc *service) finalizeBundle(run *bundleRun) error {
+	for _, p := range run.Products {
+		if err := svc.jobService.FinalizeJob(p.Params.JobId, p.Params.CompanyId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveHandlers maps the requested route slugs to their handlers, dropping
+// duplicates and rejecting products that need more than the applicant fields.
+func resolveHandlers(products []string) ([]*registry.ProductHandler, error) {
+	if len(products) == 0 {
+		return nil, apperror.BadRequest("at least one product is required")
+	}
+
+	applicantFields := make(map[string]bool, len(applicantColumns))
+	for _, column := range applicantColumns {
+		applicantFields[column.Field] = true
+	}
+
+	seen := make(map[string]bool, len(products))
+	handlers := make([]*registry.ProductHandler, 0, len(products))
+
+	for _, slug := range products {
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		handler, ok := registry.GetByRouteSlug(slug)
+		if !ok {
+			return nil, apperror.BadRequest(fmt.Sprintf("product %s is not supported", slug))
+		}
+
+		for _, column := range handler.Columns {
+			if !applicantFields[column.Field] {
+				return nil, apperror.BadRequest(fmt.Sprintf("product %s cannot be bundled", slug))
+			}
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	if len(handlers) == 0 {
+		return nil, apperror.BadRequest("at least one product is required")
+	}
+
+	return handlers, nil
+}
+
+// consolidateRows places the result rows of each product side by side, keyed
+// by source row number. Rows a product did not log are left blank.
+func consolidateRows(widths []int, rows []map[int][]string) map[int][]string {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+
+	result := map[int][]string{}
+	offset := 0
+	for i, productRows := range rows {
+		for rowNumber, values := range productRows {
+			row, ok := result[rowNumber]
+			if !ok {
+				row = make([]string, total)
+				result[rowNumber] = row
+			}
+
+			copy(row[offset:offset+widths[i]], values)
+		}
+
+		offset += widths[i]
+	}
+
+	return result
+}
+
+func formatBundleFileName(fileName, bundleJobId string) string {
+	base := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	if fileName == "" || base == "" || base == "." {
+		return fmt.Sprintf("bundle_job_id_%s.csv", bundleJobId)
+	}
+
+	return fmt.Sprintf("%s_bundle.csv", base)
+}
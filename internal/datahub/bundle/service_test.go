@@ -0,0 +1,58 @@
+package bundle
+
+import (
+	"front-office/internal/datahub/compliance/loanrecordchecker"
+	"front-office/internal/datahub/incometax/taxscore"
+	"front-office/internal/datahub/registry"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveHandlers(t *testing.T) {
+	registry.Register(loanrecordchecker.NewHandler(nil))
+	registry.Register(taxscore.NewHandler(nil))
+
+	t.Run("should drop duplicate products", func(t *testing.T) {
+		handlers, err := resolveHandlers([]string{"loan-record-checker", " loan-record-checker"})
+
+		assert.NoError(t, err)
+		assert.Len(t, handlers, 1)
+	})
+
+	t.Run("should reject unknown products", func(t *testing.T) {
+		_, err := resolveHandlers([]string{"unknown"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject products needing fields outside the applicant", func(t *testing.T) {
+		_, err := resolveHandlers([]string{"tax-score"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should require a product", func(t *testing.T) {
+		_, err := resolveHandlers([]string{""})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConsolidateRows(t *testing.T) {
+	result := consolidateRows([]int{2, 1}, []map[int][]string{
+		{2: {"a", "b"}, 3: {"c", "d"}},
+		{2: {"x"}, 4: {"y"}},
+	})
+
+	assert.Equal(t, map[int][]string{
+		2: {"a", "b", "x"},
+		3: {"c", "d", ""},
+		4: {"", "", "y"},
+	}, result)
+}
+
+func TestFormatBundleFileName(t *testing.T) {
+	assert.Equal(t, "applicants_bundle.csv", formatBundleFileName("applicants.csv", "7"))
+	assert.Equal(t, "bundle_job_id_7.csv", formatBundleFileName("", "7"))
+}
@@ -0,0 +1,83 @@
+package columnmapping
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	CreateMapping(c *fiber.Ctx) error
+	GetMappings(c *fiber.Ctx) error
+	DeleteMapping(c *fiber.Ctx) error
+	GetProductFields(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) CreateMapping(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*createMappingRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.CreateMapping(companyId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to create column mapping",
+		result,
+	))
+}
+
+func (ctrl *controller) GetMappings(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetMappings(companyId, c.Query("product_slug"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get column mappings",
+		result,
+	))
+}
+
+func (ctrl *controller) DeleteMapping(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.DeleteMapping(companyId, c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to delete column mapping",
+		nil,
+	))
+}
+
+func (ctrl *controller) GetProductFields(c *fiber.Ctx) error {
+	result, err := ctrl.svc.GetProductFields(c.Params("product_slug"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get product fields",
+		result,
+	))
+}
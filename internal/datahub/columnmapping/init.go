@@ -0,0 +1,20 @@
+package columnmapping
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repo := NewRepository(cfg, client, nil)
+	service := NewService(repo)
+	controller := NewController(service)
+
+	apiGroup.Get("/fields/:product_slug", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetProductFields)
+	apiGroup.Get("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetMappings)
+	apiGroup.Post("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(createMappingRequest{}), controller.CreateMapping)
+	apiGroup.Delete("/:id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.DeleteMapping)
+}
@@ -0,0 +1,53 @@
+package columnmapping
+
+import (
+	"front-office/pkg/helper"
+	"time"
+)
+
+type ColumnMapping struct {
+	Id          uint              `json:"id"`
+	CompanyId   uint              `json:"company_id"`
+	Name        string            `json:"name"`
+	ProductSlug string            `json:"product_slug"`
+	Mapping     map[string]string `json:"mapping"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type createMappingRequest struct {
+	Name        string            `json:"name" validate:"required~Field name is required"`
+	ProductSlug string            `json:"product_slug" validate:"required~Field product slug is required"`
+	Mapping     map[string]string `json:"mapping" validate:"required~Field mapping is required"`
+}
+
+type createMappingPayload struct {
+	CompanyId   string            `json:"-"`
+	Name        string            `json:"name"`
+	ProductSlug string            `json:"product_slug"`
+	Mapping     map[string]string `json:"mapping"`
+}
+
+// UploadMapping is the column mapping sent along with a bulk upload, either
+// the id of a saved mapping or an inline JSON object. Both empty means the
+// file must follow the product template.
+type UploadMapping struct {
+	MappingId string
+	Mapping   string
+}
+
+// Upload is a parsed bulk upload.
+type Upload struct {
+	// Source holds the records as uploaded, for keeping with the job.
+	Source [][]string
+	// Records are the records rearranged into the product template, so
+	// fields can be read by template position.
+	Records [][]string
+	// Fields names the field each column of Source is read into, empty for
+	// columns that were not mapped.
+	Fields []string
+}
+
+type productFieldsResponse struct {
+	ProductSlug string             `json:"product_slug"`
+	Fields      []helper.CSVColumn `json:"fields"`
+}
@@ -0,0 +1,174 @@
+package columnmapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallCreateMappingAPI(t *testing.T) {
+	payload := &createMappingPayload{
+		CompanyId:   constant.DummyCompanyId,
+		Name:        "core banking export",
+		ProductSlug: constant.SlugTaxScore,
+		Mapping:     map[string]string{"NPWP Nasabah": "npwp"},
+	}
+
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, ColumnMapping{Id: 1, Name: payload.Name}), nil)
+
+		result, err := repo.CreateMappingAPI(payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), result.Id)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		result, err := repo.CreateMappingAPI(payload)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.CreateMappingAPI(payload)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetMappingsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []ColumnMapping{{Id: 1}, {Id: 2}}), nil)
+
+		result, err := repo.GetMappingsAPI(constant.DummyCompanyId, constant.SlugTaxScore)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.GetMappingsAPI(constant.DummyCompanyId, constant.SlugTaxScore)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetMappingAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, ColumnMapping{
+			Id:      1,
+			Mapping: map[string]string{"0": "npwp"},
+		}), nil)
+
+		result, err := repo.GetMappingAPI(constant.DummyCompanyId, "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "npwp", result.Mapping["0"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseNewRequestError, func(t *testing.T) {
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockInvalidHost},
+		}, new(MockClient), nil)
+
+		result, err := repo.GetMappingAPI(constant.DummyCompanyId, "1")
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestCallDeleteMappingAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.DeleteMappingAPI(constant.DummyCompanyId, "1")
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.DeleteMappingAPI(constant.DummyCompanyId, "1")
+
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,176 @@
+package columnmapping
+
+import (
+	"encoding/json"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"mime/multipart"
+	"strings"
+)
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+type service struct {
+	repo Repository
+}
+
+type Service interface {
+	CreateMapping(companyId string, req *createMappingRequest) (*ColumnMapping, error)
+	GetMappings(companyId, slug string) ([]*ColumnMapping, error)
+	DeleteMapping(companyId, mappingId string) error
+	GetProductFields(slug string) (*productFieldsResponse, error)
+	ParseUpload(companyId, productSlug string, file *multipart.FileHeader, upload *UploadMapping) (*Upload, error)
+}
+
+func (svc *service) CreateMapping(companyId string, req *createMappingRequest) (*ColumnMapping, error) {
+	handler, err := lookupHandler(req.ProductSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helper.ValidateColumnMapping(handler.Columns, req.Mapping); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	result, err := svc.repo.CreateMappingAPI(&createMappingPayload{
+		CompanyId:   companyId,
+		Name:        strings.TrimSpace(req.Name),
+		ProductSlug: handler.Slug,
+		Mapping:     req.Mapping,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to create column mapping")
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetMappings(companyId, slug string) ([]*ColumnMapping, error) {
+	productSlug := ""
+	if slug != "" {
+		handler, err := lookupHandler(slug)
+		if err != nil {
+			return nil, err
+		}
+		productSlug = handler.Slug
+	}
+
+	result, err := svc.repo.GetMappingsAPI(companyId, productSlug)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch column mappings")
+	}
+
+	return result, nil
+}
+
+func (svc *service) DeleteMapping(companyId, mappingId string) error {
+	if err := svc.repo.DeleteMappingAPI(companyId, mappingId); err != nil {
+		return apperror.MapRepoError(err, "failed to delete column mapping")
+	}
+
+	return nil
+}
+
+func (svc *service) GetProductFields(slug string) (*productFieldsResponse, error) {
+	handler, err := lookupHandler(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productFieldsResponse{
+		ProductSlug: slug,
+		Fields:      handler.Columns,
+	}, nil
+}
+
+// ParseUpload reads a bulk upload for productSlug, along with the field each
+// of its columns is read into.
+func (svc *service) ParseUpload(companyId, productSlug string, file *multipart.FileHeader, upload *UploadMapping) (*Upload, error) {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return nil, apperror.BadRequest(constant.UnsupportedProductSlug)
+	}
+	columns := handler.Columns
+
+	if upload == nil || (upload.MappingId == "" && upload.Mapping == "") {
+		records, err := helper.ParseCSVFile(file, helper.CSVHeaders(columns))
+		if err != nil {
+			return nil, apperror.Internal(constant.FailedParseCSV, err)
+		}
+
+		// the template has the columns in order
+		indexes := make([]int, len(columns))
+		for i := range indexes {
+			indexes[i] = i
+		}
+
+		return &Upload{
+			Source:  records,
+			Records: records,
+			Fields:  helper.SourceFields(records[0], columns, indexes),
+		}, nil
+	}
+
+	mapping, err := svc.resolveUploadMapping(companyId, productSlug, upload)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := helper.ReadCSVFile(file)
+	if err != nil {
+		return nil, apperror.Internal(constant.FailedParseCSV, err)
+	}
+
+	indexes, err := helper.ResolveColumnMapping(source[0], columns, mapping)
+	if err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	return &Upload{
+		Source:  source,
+		Records: helper.ProjectCSVRecords(source, columns, indexes),
+		Fields:  helper.SourceFields(source[0], columns, indexes),
+	}, nil
+}
+
+func (svc *service) resolveUploadMapping(companyId, productSlug string, upload *UploadMapping) (map[string]string, error) {
+	if upload.MappingId != "" && upload.Mapping != "" {
+		return nil, apperror.BadRequest("send either mapping_id or mapping, not both")
+	}
+
+	if upload.Mapping != "" {
+		var mapping map[string]string
+		if err := json.Unmarshal([]byte(upload.Mapping), &mapping); err != nil {
+			return nil, apperror.BadRequest("mapping must be a JSON object of source column to field")
+		}
+
+		return mapping, nil
+	}
+
+	saved, err := svc.repo.GetMappingAPI(companyId, upload.MappingId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch column mapping")
+	}
+
+	if saved == nil {
+		return nil, apperror.NotFound("column mapping not found")
+	}
+
+	if saved.ProductSlug != productSlug {
+		return nil, apperror.BadRequest("column mapping belongs to another product")
+	}
+
+	return saved.Mapping, nil
+}
+
+func lookupHandler(routeSlug string) (*registry.ProductHandler, error) {
+	if handler, ok := registry.GetByRouteSlug(routeSlug); ok {
+		return handler, nil
+	}
+
+	return nil, apperror.BadRequest(constant.UnsupportedProductSlug)
+}
@@ -0,0 +1,39 @@
+package loanrecordchecker
+
+import (
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+)
+
+func NewHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugLoanRecordChecker,
+		RouteSlug:   "loan-record-checker",
+		Group:       registry.GroupCompliance,
+		Name:        "loan record checker",
+		TemplateDir: constant.LoanRecordCheckerTemplates,
+		Columns: []helper.CSVColumn{
+			{Field: "name", Header: "Name"},
+			{Field: "nik", Header: "ID Card Number"},
+			{Field: "phone_number", Header: "Phone Number"},
+		},
+		NewRequest: func() any { return &loanRecordCheckerRequest{} },
+		Call: registry.WrapCall(func(params *registry.CallParams, req *loanRecordCheckerRequest) (*model.ProCatAPIResponse[dataLoanRecord], error) {
+			return repo.LoanRecordCheckerAPI(params.APIKey, params.JobId, params.MemberId, params.CompanyId, req)
+		}),
+		MapError: registry.MapPartnerError,
+		ExportColumns: []registry.ExportColumn{
+			{Header: "Name", Source: registry.SourceInput, Field: "name"},
+			{Header: "NIK", Source: registry.SourceInput, Field: "nik"},
+			{Header: "Phone Number", Source: registry.SourceInput, Field: "phone_number"},
+			{Header: "Remarks", Source: registry.SourceData, Field: "remarks"},
+			{Header: "Data Status", Source: registry.SourceData, Field: "status"},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		Distribution: registry.FieldDistribution("remarks"),
+		MaskedFields: []string{"name", "nik", "phone_number"},
+	}
+}
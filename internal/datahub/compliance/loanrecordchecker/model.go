@@ -1,22 +1,28 @@
 package loanrecordchecker
 
+import "front-office/pkg/utility/identity"
+
 type loanRecordCheckerRequest struct {
 	Name  string `json:"name" validate:"required~Name cannot be empty"`
-	Nik   string `json:"nik" validate:"required~NIK cannot be empty., numeric~ID Card No is only number, length(16)~ID Card No must be 16 digit number."`
-	Phone string `json:"phone_number" validate:"required~Phone Number cannot be empty, indophone, min(9)"`
+	Nik   string `json:"nik" validate:"required~NIK cannot be empty."`
+	Phone string `json:"phone_number" validate:"required~Phone Number cannot be empty."`
 }
 
-type loanCheckerContext struct {
-	APIKey         string                    `json:"api_key"`
-	JobIdStr       string                    `json:"job_id_str"`
-	MemberIdStr    string                    `json:"member_id_str"`
-	CompanyIdStr   string                    `json:"company_id_str"`
-	MemberId       uint                      `json:"member_id"`
-	CompanyId      uint                      `json:"company_id"`
-	ProductId      uint                      `json:"product_id"`
-	ProductGroupId uint                      `json:"product_group_id"`
-	JobId          uint                      `json:"job_id"`
-	Request        *loanRecordCheckerRequest `json:"request"`
+// Normalize checks the NIK and brings the phone number to canonical form.
+func (r *loanRecordCheckerRequest) Normalize() error {
+	nik, err := identity.NormalizeNIK(r.Nik)
+	if err != nil {
+		return err
+	}
+
+	phone, err := identity.NormalizePhone(r.Phone)
+	if err != nil {
+		return err
+	}
+
+	r.Nik, r.Phone = nik, phone
+
+	return nil
 }
 
 type dataLoanRecord struct {
@@ -0,0 +1,189 @@
+package multipleloan
+
+import (
+	"errors"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"math"
+	"net/http"
+	"sync"
+)
+
+// Velocity indicators of an all windows result.
+const (
+	VelocityNone         = "none"
+	VelocityAccelerating = "accelerating"
+	VelocitySteady       = "steady"
+	VelocityDecelerating = "decelerating"
+)
+
+// windowCalls are the 7, 30 and 90 days calls of an all windows request.
+var windowCalls = []multipleLoanFunc{
+	Repository.CallMultipleLoan7Days,
+	Repository.CallMultipleLoan30Days,
+	Repository.CallMultipleLoan90Days,
+}
+
+// newAllWindowsHandler returns the handler that checks the 7, 30 and 90 days
+// windows of one subject at once. Each window is still called, logged and
+// billed as its own transaction under the all windows job.
+func newAllWindowsHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugMultipleLoanAll,
+		RouteSlug:   "all-multiple-loan",
+		Group:       registry.GroupCompliance,
+		Name:        "all windows multiple loan",
+		TemplateDir: constant.MultipleLoanTemplates,
+		Columns: []helper.CSVColumn{
+			{Field: "nik", Header: "ID Card Number"},
+			{Field: "phone_number", Header: "Phone Number"},
+		},
+		NewRequest: func() any { return &multipleLoanRequest{} },
+		Call: func(params *registry.CallParams, req any) (*model.ProCatAPIResponse[any], error) {
+			typed, ok := req.(*multipleLoanRequest)
+			if !ok {
+				return nil, errors.New("unexpected request type")
+			}
+
+			return callAllWindows(repo, params, typed)
+		},
+		MapError: registry.MapPartnerError,
+		ExportColumns: []registry.ExportColumn{
+			{Header: "NIK", Source: registry.SourceInput, Field: "nik"},
+			{Header: "Phone Number", Source: registry.SourceInput, Field: "phone_number"},
+			{Header: "Query Count 7 Days", Source: registry.SourceData, Field: "query_count_7d"},
+			{Header: "Query Count 30 Days", Source: registry.SourceData, Field: "query_count_30d"},
+			{Header: "Query Count 90 Days", Source: registry.SourceData, Field: "query_count_90d"},
+			{Header: "7d/30d Ratio", Source: registry.SourceData, Field: "ratio_7d_30d"},
+			{Header: "30d/90d Ratio", Source: registry.SourceData, Field: "ratio_30d_90d"},
+			{Header: "Velocity", Source: registry.SourceData, Field: "velocity"},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		MaskedFields: []string{"nik", "phone_number"},
+		MergedField:  "velocity",
+		Calls:        len(windowCalls),
+	}
+}
+
+// callAllWindows calls the three windows concurrently and merges their query
+// counts. When a window fails the merged result is still returned, with the
+// failing window's message and status, alongside its error.
+func callAllWindows(repo Repository, params *registry.CallParams, req *multipleLoanRequest) (*model.ProCatAPIResponse[any], error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([]*model.ProCatAPIResponse[dataMultipleLoanResponse], len(windowCalls))
+		errs    = make([]error, len(windowCalls))
+	)
+
+	for i, call := range windowCalls {
+		wg.Add(1)
+
+		go func(i int, call multipleLoanFunc) {
+			defer wg.Done()
+
+			results[i], errs[i] = call(repo, params.APIKey, params.JobId, params.MemberId, params.CompanyId, req)
+		}(i, call)
+	}
+
+	wg.Wait()
+
+	return mergeWindows(results, errs)
+}
+
+// mergeWindows merges the 7, 30 and 90 days results, in that order.
+func mergeWindows(results []*model.ProCatAPIResponse[dataMultipleLoanResponse], errs []error) (*model.ProCatAPIResponse[any], error) {
+	data := &allWindowsResponse{}
+	merged := &model.ProCatAPIResponse[any]{Success: true, Data: data}
+
+	var firstErr error
+	for i, result := range results {
+		if result != nil {
+			if result.TransactionId != "" {
+				data.transactionIds = append(data.transactionIds, result.TransactionId)
+			}
+			if merged.Input == nil {
+				merged.Input, merged.Date, merged.StatusCode = result.Input, result.Date, result.StatusCode
+			}
+			if result.PricingStrategy == constant.PricingStrategyPay || merged.PricingStrategy == "" {
+				merged.PricingStrategy = result.PricingStrategy
+			}
+		}
+
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+				merged.Success = false
+				merged.Message, merged.StatusCode = errs[i].Error(), http.StatusInternalServerError
+				if result != nil {
+					merged.Message, merged.StatusCode = result.Message, result.StatusCode
+				}
+			}
+			continue
+		}
+
+		if result == nil {
+			continue
+		}
+
+		switch i {
+		case 0:
+			data.QueryCount7Days = result.Data.QueryCount
+		case 1:
+			data.QueryCount30Days = result.Data.QueryCount
+		case 2:
+			data.QueryCount90Days = result.Data.QueryCount
+		}
+
+		if merged.Message == "" {
+			merged.Message = result.Message
+		}
+	}
+
+	if len(data.transactionIds) > 0 {
+		merged.TransactionId = data.transactionIds[0]
+	}
+
+	data.Ratio7To30 = ratio(data.QueryCount7Days, data.QueryCount30Days)
+	data.Ratio30To90 = ratio(data.QueryCount30Days, data.QueryCount90Days)
+	data.Velocity = velocity(data)
+
+	if firstErr != nil && len(data.transactionIds) == 0 {
+		return nil, firstErr
+	}
+
+	return merged, firstErr
+}
+
+// velocity compares how much of the longer window's activity happened in the
+// shorter one with what an even spread would give: 7/30 of the 30 days and
+// 30/90 of the 90 days queries.
+func velocity(data *allWindowsResponse) string {
+	if data.QueryCount90Days == 0 && data.QueryCount30Days == 0 && data.QueryCount7Days == 0 {
+		return VelocityNone
+	}
+
+	recent := data.Ratio7To30 > 7.0/30
+	medium := data.Ratio30To90 > 30.0/90
+
+	switch {
+	case recent && medium:
+		return VelocityAccelerating
+	case !recent && !medium:
+		return VelocityDecelerating
+	default:
+		return VelocitySteady
+	}
+}
+
+// ratio divides two query counts, rounded to two decimals. It is 0 when the
+// longer window has no queries.
+func ratio(shorter, longer uint) float64 {
+	if longer == 0 {
+		return 0
+	}
+
+	return math.Round(float64(shorter)/float64(longer)*100) / 100
+}
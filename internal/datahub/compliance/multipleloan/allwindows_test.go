@@ -0,0 +1,95 @@
+package multipleloan
+
+import (
+	"errors"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeWindows(t *testing.T) {
+	window := func(transactionId string, queryCount uint) *model.ProCatAPIResponse[dataMultipleLoanResponse] {
+		return &model.ProCatAPIResponse[dataMultipleLoanResponse]{
+			Success:         true,
+			Message:         "Succeed",
+			StatusCode:      http.StatusOK,
+			PricingStrategy: constant.PricingStrategyPay,
+			TransactionId:   transactionId,
+			Data:            dataMultipleLoanResponse{QueryCount: queryCount},
+		}
+	}
+
+	t.Run("should merge the windows with their ratios", func(t *testing.T) {
+		result, err := mergeWindows(
+			[]*model.ProCatAPIResponse[dataMultipleLoanResponse]{window("a", 3), window("b", 4), window("c", 8)},
+			[]error{nil, nil, nil},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "a", result.TransactionId)
+		assert.Equal(t, constant.PricingStrategyPay, result.PricingStrategy)
+		assert.Equal(t, &allWindowsResponse{
+			QueryCount7Days:  3,
+			QueryCount30Days: 4,
+			QueryCount90Days: 8,
+			Ratio7To30:       0.75,
+			Ratio30To90:      0.5,
+			Velocity:         VelocityAccelerating,
+			transactionIds:   []string{"a", "b", "c"},
+		}, result.Data)
+	})
+
+	t.Run("should return the partial result with the failing window's error", func(t *testing.T) {
+		failed := &model.ProCatAPIResponse[dataMultipleLoanResponse]{Message: "partner unavailable", StatusCode: http.StatusBadGateway}
+		windowErr := errors.New("partner unavailable")
+
+		result, err := mergeWindows(
+			[]*model.ProCatAPIResponse[dataMultipleLoanResponse]{window("a", 1), failed, nil},
+			[]error{nil, windowErr, errors.New("timeout")},
+		)
+
+		assert.ErrorIs(t, err, windowErr)
+		assert.False(t, result.Success)
+		assert.Equal(t, "partner unavailable", result.Message)
+		assert.Equal(t, http.StatusBadGateway, result.StatusCode)
+		assert.Equal(t, []string{"a"}, result.Data.(*allWindowsResponse).TransactionIds())
+	})
+
+	t.Run("should return only the error when no window answered", func(t *testing.T) {
+		result, err := mergeWindows(
+			[]*model.ProCatAPIResponse[dataMultipleLoanResponse]{nil, nil, nil},
+			[]error{errors.New("timeout"), errors.New("timeout"), errors.New("timeout")},
+		)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestAllWindowsHandler(t *testing.T) {
+	handler := newAllWindowsHandler(nil)
+
+	assert.Equal(t, 3, handler.CallCount(), "each window is charged to the quota")
+}
+
+func TestVelocity(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *allWindowsResponse
+		expected string
+	}{
+		{"should report none without queries", &allWindowsResponse{}, VelocityNone},
+		{"should report accelerating", &allWindowsResponse{QueryCount7Days: 1, Ratio7To30: 0.5, Ratio30To90: 0.5}, VelocityAccelerating},
+		{"should report steady", &allWindowsResponse{QueryCount7Days: 1, Ratio7To30: 0.5, Ratio30To90: 0.2}, VelocitySteady},
+		{"should report decelerating", &allWindowsResponse{QueryCount90Days: 9, Ratio7To30: 0.1, Ratio30To90: 0.2}, VelocityDecelerating},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, velocity(tt.data))
+		})
+	}
+}
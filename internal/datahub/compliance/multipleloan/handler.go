@@ -0,0 +1,82 @@
+package multipleloan
+
+import (
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+)
+
+// NewHandlers returns one handler per multiple loan window, which differ
+// only in slug and upstream endpoint, followed by the all windows handler.
+func NewHandlers(repo Repository) []*registry.ProductHandler {
+	windows := []struct {
+		slug, routeSlug, name string
+		call                  multipleLoanFunc
+	}{
+		{constant.SlugMultipleLoan7Days, "7d-multiple-loan", "7 days multiple loan", Repository.CallMultipleLoan7Days},
+		{constant.SlugMultipleLoan30Days, "30d-multiple-loan", "30 days multiple loan", Repository.CallMultipleLoan30Days},
+		{constant.SlugMultipleLoan90Days, "90d-multiple-loan", "90 days multiple loan", Repository.CallMultipleLoan90Days},
+	}
+
+	handlers := make([]*registry.ProductHandler, 0, len(windows)+1)
+	for _, w := range windows {
+		call := w.call
+		handlers = append(handlers, &registry.ProductHandler{
+			Slug:        w.slug,
+			RouteSlug:   w.routeSlug,
+			Group:       registry.GroupCompliance,
+			Name:        w.name,
+			TemplateDir: constant.MultipleLoanTemplates,
+			Columns: []helper.CSVColumn{
+				{Field: "nik", Header: "ID Card Number"},
+				{Field: "phone_number", Header: "Phone Number"},
+			},
+			NewRequest: func() any { return &multipleLoanRequest{} },
+			Call: registry.WrapCall(func(params *registry.CallParams, req *multipleLoanRequest) (*model.ProCatAPIResponse[dataMultipleLoanResponse], error) {
+				return call(repo, params.APIKey, params.JobId, params.MemberId, params.CompanyId, req)
+			}),
+			MapError: registry.MapPartnerError,
+			ExportColumns: []registry.ExportColumn{
+				{Header: "NIK", Source: registry.SourceInput, Field: "nik"},
+				{Header: "Phone Number", Source: registry.SourceInput, Field: "phone_number"},
+				{Header: "Query Count", Source: registry.SourceData, Field: "query_count"},
+				{Header: "Status", Source: registry.SourceStatus},
+				{Header: "Description", Source: registry.SourceMessage},
+			},
+			Distribution: queryCountBucket,
+			MaskedFields: []string{"nik", "phone_number"},
+		})
+	}
+
+	return append(handlers, newAllWindowsHandler(repo))
+}
+
+type multipleLoanFunc func(Repository, string, string, string, string, *multipleLoanRequest) (*model.ProCatAPIResponse[dataMultipleLoanResponse], error)
+
+// queryCountBuckets are the upper bounds of the dashboard query count
+// buckets; counts above the last fall in an open bucket.
+var queryCountBuckets = []struct {
+	max   int
+	label string
+}{
+	{0, "0"},
+	{2, "1-2"},
+	{5, "3-5"},
+	{10, "6-10"},
+}
+
+func queryCountBucket(data map[string]any) string {
+	count, ok := data["query_count"].(float64)
+	if !ok {
+		return ""
+	}
+
+	for _, bucket := range queryCountBuckets {
+		if int(count) <= bucket.max {
+			return bucket.label
+		}
+	}
+
+	return ">10"
+}
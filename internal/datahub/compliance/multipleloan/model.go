@@ -1,24 +1,45 @@
 package multipleloan
 
+import "front-office/pkg/utility/identity"
+
 type multipleLoanRequest struct {
-	Nik   string `json:"nik" validate:"required~NIK cannot be empty., numeric~ID Card No is only number, length(16)~ID Card No must be 16 digit number."`
-	Phone string `json:"phone_number" validate:"required~Phone Number cannot be empty, indophone, min(9)"`
+	Nik   string `json:"nik" validate:"required~NIK cannot be empty."`
+	Phone string `json:"phone_number" validate:"required~Phone Number cannot be empty."`
+}
+
+// Normalize checks the NIK and brings the phone number to canonical form.
+func (r *multipleLoanRequest) Normalize() error {
+	nik, err := identity.NormalizeNIK(r.Nik)
+	if err != nil {
+		return err
+	}
+
+	phone, err := identity.NormalizePhone(r.Phone)
+	if err != nil {
+		return err
+	}
+
+	r.Nik, r.Phone = nik, phone
+
+	return nil
 }
 
 type dataMultipleLoanResponse struct {
 	QueryCount uint `json:"query_count"`
 }
 
-type multipleLoanContext struct {
-	APIKey         string               `json:"api_key"`
-	JobIdStr       string               `json:"job_id_str"`
-	MemberIdStr    string               `json:"member_id_str"`
-	CompanyIdStr   string               `json:"company_id_str"`
-	ProductSlug    string               `json:"product_slug"`
-	MemberId       uint                 `json:"member_id"`
-	CompanyId      uint                 `json:"company_id"`
-	ProductId      uint                 `json:"product_id"`
-	ProductGroupId uint                 `json:"product_group_id"`
-	JobId          uint                 `json:"job_id"`
-	Request        *multipleLoanRequest `json:"request"`
+// allWindowsResponse merges the query counts of the 7, 30 and 90 days windows.
+// The ratios compare each window with the next longer one.
+type allWindowsResponse struct {
+	QueryCount7Days  uint    `json:"query_count_7d"`
+	QueryCount30Days uint    `json:"query_count_30d"`
+	QueryCount90Days uint    `json:"query_count_90d"`
+	Ratio7To30       float64 `json:"ratio_7d_30d"`
+	Ratio30To90      float64 `json:"ratio_30d_90d"`
+	Velocity         string  `json:"velocity"`
+	transactionIds   []string
+}
+
+func (r *allWindowsResponse) TransactionIds() []string {
+	return r.transactionIds
 }
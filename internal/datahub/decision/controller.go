@@ -0,0 +1,93 @@
+package decision
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	CreateRuleSet(c *fiber.Ctx) error
+	GetRuleSets(c *fiber.Ctx) error
+	GetRuleSet(c *fiber.Ctx) error
+	ActivateRuleSet(c *fiber.Ctx) error
+	GetFields(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) CreateRuleSet(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*ruleSetRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.CreateRuleSet(companyId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to create rule set",
+		result,
+	))
+}
+
+func (ctrl *controller) GetRuleSets(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetRuleSets(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get rule sets",
+		result,
+	))
+}
+
+func (ctrl *controller) GetRuleSet(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetRuleSet(companyId, c.Params("rule_set_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get rule set",
+		result,
+	))
+}
+
+func (ctrl *controller) ActivateRuleSet(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.ActivateRuleSet(companyId, c.Params("rule_set_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to activate rule set",
+		nil,
+	))
+}
+
+func (ctrl *controller) GetFields(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get rule fields",
+		ctrl.svc.GetFields(),
+	))
+}
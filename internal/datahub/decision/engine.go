@@ -0,0 +1,112 @@
+package decision
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Headers are the columns a decision adds to an export.
+var Headers = []string{"Decision", "Decision Rule"}
+
+// Evaluate applies ruleSet to the result values of one row, keyed by rule
+// field. A condition on a field the row does not have never matches.
+func Evaluate(ruleSet *RuleSet, values map[string]string) *Outcome {
+	for _, rule := range ruleSet.Rules {
+		if matchRule(rule, values) {
+			return &Outcome{Decision: rule.Decision, Rule: rule.Name}
+		}
+	}
+
+	decision := ruleSet.DefaultDecision
+	if decision == "" {
+		decision = DefaultDecision
+	}
+
+	return &Outcome{Decision: decision}
+}
+
+// Columns returns the export cells of an outcome in Headers order.
+func (o *Outcome) Columns() []string {
+	return []string{o.Decision, o.Rule}
+}
+
+func matchRule(rule *Rule, values map[string]string) bool {
+	if len(rule.Conditions) == 0 {
+		return false
+	}
+
+	anyMatch := rule.Match == MatchAny
+	for _, condition := range rule.Conditions {
+		matched := matchCondition(condition, values)
+		if anyMatch && matched {
+			return true
+		}
+		if !anyMatch && !matched {
+			return false
+		}
+	}
+
+	return !anyMatch
+}
+
+func matchCondition(condition *Condition, values map[string]string) bool {
+	value, ok := values[condition.Field]
+	if !ok {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+	expected := strings.TrimSpace(condition.Value)
+
+	switch condition.Operator {
+	case OpEqual:
+		return strings.EqualFold(value, expected)
+	case OpNotEqual:
+		return !strings.EqualFold(value, expected)
+	case OpContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(expected))
+	case OpIn:
+		for _, option := range strings.Split(expected, ",") {
+			if strings.EqualFold(value, strings.TrimSpace(option)) {
+				return true
+			}
+		}
+		return false
+	case OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual:
+		return compareNumbers(condition.Operator, value, expected)
+	}
+
+	return false
+}
+
+func compareNumbers(operator, value, expected string) bool {
+	a, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+
+	b, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+
+	switch operator {
+	case OpGreater:
+		return a > b
+	case OpGreaterOrEqual:
+		return a >= b
+	case OpLess:
+		return a < b
+	default:
+		return a <= b
+	}
+}
+
+func isValidOperator(operator string) bool {
+	switch operator {
+	case OpEqual, OpNotEqual, OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual, OpContains, OpIn:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package decision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	ruleSet := &RuleSet{
+		DefaultDecision: "approve",
+		Rules: []*Rule{
+			{
+				Name:     "too many loans or disconnected",
+				Decision: "reject",
+				Match:    MatchAny,
+				Conditions: []*Condition{
+					{Field: "multiple-loan-30-days.query_count", Operator: OpGreater, Value: "3"},
+					{Field: "phone-live-status.subscriber_status", Operator: OpEqual, Value: "disconnected"},
+				},
+			},
+			{
+				Name:     "weak grade",
+				Decision: "review",
+				Conditions: []*Condition{
+					{Field: FieldGenRetailGrade, Operator: OpIn, Value: "D, E"},
+					{Field: FieldGenRetailProbabilityToDefault, Operator: OpGreaterOrEqual, Value: "0.4"},
+				},
+			},
+		},
+	}
+
+	t.Run("should return the first matching rule", func(t *testing.T) {
+		outcome := Evaluate(ruleSet, map[string]string{
+			"multiple-loan-30-days.query_count":   "5",
+			"phone-live-status.subscriber_status": "active",
+		})
+
+		assert.Equal(t, &Outcome{Decision: "reject", Rule: "too many loans or disconnected"}, outcome)
+	})
+
+	t.Run("should compare text case insensitively", func(t *testing.T) {
+		outcome := Evaluate(ruleSet, map[string]string{"phone-live-status.subscriber_status": "Disconnected"})
+
+		assert.Equal(t, "reject", outcome.Decision)
+	})
+
+	t.Run("should require every condition when matching all", func(t *testing.T) {
+		outcome := Evaluate(ruleSet, map[string]string{FieldGenRetailGrade: "D", FieldGenRetailProbabilityToDefault: "0.1"})
+		assert.Equal(t, &Outcome{Decision: "approve"}, outcome)
+
+		outcome = Evaluate(ruleSet, map[string]string{FieldGenRetailGrade: "e", FieldGenRetailProbabilityToDefault: "0.45"})
+		assert.Equal(t, &Outcome{Decision: "review", Rule: "weak grade"}, outcome)
+	})
+
+	t.Run("should not match missing or non numeric values", func(t *testing.T) {
+		outcome := Evaluate(ruleSet, map[string]string{"multiple-loan-30-days.query_count": "n/a"})
+
+		assert.Equal(t, &Outcome{Decision: "approve"}, outcome)
+	})
+
+	t.Run("should fall back to approve without a default decision", func(t *testing.T) {
+		outcome := Evaluate(&RuleSet{}, map[string]string{})
+
+		assert.Equal(t, DefaultDecision, outcome.Decision)
+	})
+}
+
+func TestValidateRules(t *testing.T) {
+	fields := []*Field{{Key: FieldGenRetailGrade}}
+
+	assert.NoError(t, validateRules([]*Rule{
+		{Name: "grade", Decision: "reject", Conditions: []*Condition{{Field: FieldGenRetailGrade, Operator: OpEqual, Value: "E"}}},
+	}, fields))
+
+	assert.Error(t, validateRules([]*Rule{
+		{Name: "unknown field", Decision: "reject", Conditions: []*Condition{{Field: "tax-score.score", Operator: OpEqual}}},
+	}, fields))
+
+	assert.Error(t, validateRules([]*Rule{
+		{Name: "unknown operator", Decision: "reject", Conditions: []*Condition{{Field: FieldGenRetailGrade, Operator: "like"}}},
+	}, fields))
+
+	assert.Error(t, validateRules([]*Rule{
+		{Name: "bad match", Decision: "reject", Match: "some", Conditions: []*Condition{{Field: FieldGenRetailGrade, Operator: OpEqual}}},
+	}, fields))
+}
@@ -0,0 +1,21 @@
+package decision
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	service := NewService(repository)
+	controller := NewController(service)
+
+	apiGroup.Get("/fields", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetFields)
+	apiGroup.Post("/", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(ruleSetRequest{}), controller.CreateRuleSet)
+	apiGroup.Get("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetRuleSets)
+	apiGroup.Get("/:rule_set_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetRuleSet)
+	apiGroup.Put("/:rule_set_id/activate", middleware.AdminAuth(), middleware.GetJWTPayloadFromCookie(), controller.ActivateRuleSet)
+}
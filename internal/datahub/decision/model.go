@@ -0,0 +1,86 @@
+package decision
+
+// Condition operators.
+const (
+	OpEqual          = "eq"
+	OpNotEqual       = "neq"
+	OpGreater        = "gt"
+	OpGreaterOrEqual = "gte"
+	OpLess           = "lt"
+	OpLessOrEqual    = "lte"
+	OpContains       = "contains"
+	OpIn             = "in"
+)
+
+// Rule match modes.
+const (
+	MatchAll = "all"
+	MatchAny = "any"
+)
+
+const DefaultDecision = "approve"
+
+// Fields of genretail scoring results, which are not served by the product
+// registry.
+const (
+	FieldGenRetailGrade                = "genretail.grade"
+	FieldGenRetailProbabilityToDefault = "genretail.probability_to_default"
+)
+
+// RuleSet is one version of a company's decision rules. Saving a rule set
+// under an existing name creates the next version; only the active version
+// is applied to results.
+type RuleSet struct {
+	Id              uint    `json:"id"`
+	CompanyId       uint    `json:"company_id"`
+	Name            string  `json:"name"`
+	Version         int     `json:"version"`
+	IsActive        bool    `json:"is_active"`
+	DefaultDecision string  `json:"default_decision"`
+	Rules           []*Rule `json:"rules"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// Rule yields Decision when its conditions match, all of them or any of
+// them depending on Match. Rules are tried in order and the first match wins.
+type Rule struct {
+	Name       string       `json:"name" validate:"required~Rule name cannot be empty"`
+	Decision   string       `json:"decision" validate:"required~Rule decision cannot be empty"`
+	Match      string       `json:"match"`
+	Conditions []*Condition `json:"conditions" validate:"required~Rule conditions cannot be empty"`
+}
+
+// Condition compares a result field, keyed "<product>.<field>", with Value.
+// The in operator takes a comma separated list.
+type Condition struct {
+	Field    string `json:"field" validate:"required~Condition field cannot be empty"`
+	Operator string `json:"operator" validate:"required~Condition operator cannot be empty"`
+	Value    string `json:"value"`
+}
+
+// Outcome is the decision reached for one result and the rule that
+// triggered it, empty when the default decision applied.
+type Outcome struct {
+	Decision string `json:"decision"`
+	Rule     string `json:"rule"`
+}
+
+// Field is a result field rules can refer to.
+type Field struct {
+	Key     string `json:"key"`
+	Product string `json:"product"`
+	Label   string `json:"label"`
+}
+
+type ruleSetRequest struct {
+	Name            string  `json:"name" validate:"required~Name cannot be empty"`
+	DefaultDecision string  `json:"default_decision"`
+	Rules           []*Rule `json:"rules" validate:"required~Rules cannot be empty"`
+}
+
+type createRuleSetPayload struct {
+	CompanyId       string  `json:"company_id"`
+	Name            string  `json:"name"`
+	DefaultDecision string  `json:"default_decision"`
+	Rules           []*Rule `json:"rules"`
+}
@@ -0,0 +1,137 @@
+package decision
+
+import (
+	"fmt"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"strings"
+)
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+type service struct {
+	repo Repository
+}
+
+type Service interface {
+	CreateRuleSet(companyId string, req *ruleSetRequest) (*RuleSet, error)
+	GetRuleSets(companyId string) ([]*RuleSet, error)
+	GetRuleSet(companyId, ruleSetId string) (*RuleSet, error)
+	GetActiveRuleSet(companyId string) (*RuleSet, error)
+	ActivateRuleSet(companyId, ruleSetId string) error
+	GetFields() []*Field
+}
+
+func (svc *service) CreateRuleSet(companyId string, req *ruleSetRequest) (*RuleSet, error) {
+	if err := validateRules(req.Rules, svc.GetFields()); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	result, err := svc.repo.CreateRuleSetAPI(&createRuleSetPayload{
+		CompanyId:       companyId,
+		Name:            strings.TrimSpace(req.Name),
+		DefaultDecision: strings.TrimSpace(req.DefaultDecision),
+		Rules:           req.Rules,
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to create rule set")
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetRuleSets(companyId string) ([]*RuleSet, error) {
+	result, err := svc.repo.GetRuleSetsAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch rule sets")
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetRuleSet(companyId, ruleSetId string) (*RuleSet, error) {
+	result, err := svc.repo.GetRuleSetAPI(companyId, ruleSetId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch rule set")
+	}
+
+	if result == nil {
+		return nil, apperror.NotFound("rule set not found")
+	}
+
+	return result, nil
+}
+
+// GetActiveRuleSet returns the rule set applied to the company's results, or
+// nil when the company has not activated one.
+func (svc *service) GetActiveRuleSet(companyId string) (*RuleSet, error) {
+	result, err := svc.repo.GetActiveRuleSetAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch active rule set")
+	}
+
+	return result, nil
+}
+
+func (svc *service) ActivateRuleSet(companyId, ruleSetId string) error {
+	if err := svc.repo.ActivateRuleSetAPI(companyId, ruleSetId); err != nil {
+		return apperror.MapRepoError(err, "failed to activate rule set")
+	}
+
+	return nil
+}
+
+// GetFields lists the result fields rules can refer to: every export column
+// of the registered products and the genretail score.
+func (svc *service) GetFields() []*Field {
+	var fields []*Field
+	for _, handler := range registry.All() {
+		for i, key := range handler.RuleFields() {
+			if key == "" {
+				continue
+			}
+
+			fields = append(fields, &Field{
+				Key:     key,
+				Product: handler.RouteSlug,
+				Label:   handler.ExportColumns[i].Header,
+			})
+		}
+	}
+
+	return append(fields,
+		&Field{Key: FieldGenRetailGrade, Product: "genretail", Label: "Grade"},
+		&Field{Key: FieldGenRetailProbabilityToDefault, Product: "genretail", Label: "Probability To Default"},
+	)
+}
+
+func validateRules(rules []*Rule, fields []*Field) error {
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+
+	for _, rule := range rules {
+		if rule.Match != "" && rule.Match != MatchAll && rule.Match != MatchAny {
+			return fmt.Errorf("rule %s: match must be %s or %s", rule.Name, MatchAll, MatchAny)
+		}
+
+		if len(rule.Conditions) == 0 {
+			return fmt.Errorf("rule %s: at least one condition is required", rule.Name)
+		}
+
+		for _, condition := range rule.Conditions {
+			if !known[condition.Field] {
+				return fmt.Errorf("rule %s: unknown field %s", rule.Name, condition.Field)
+			}
+
+			if !isValidOperator(condition.Operator) {
+				return fmt.Errorf("rule %s: unknown operator %s", rule.Name, condition.Operator)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package gateway
+
+import (
+	"fmt"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/usepzaka/validator"
+)
+
+func NewController(svc Service, group string) Controller {
+	return &controller{svc, group}
+}
+
+type controller struct {
+	svc   Service
+	group string
+}
+
+type Controller interface {
+	SingleRequest(c *fiber.Ctx) error
+	BulkRequest(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) SingleRequest(c *fiber.Ctx) error {
+	handler, err := ctrl.lookupHandler(c.Params("product_slug"))
+	if err != nil {
+		return err
+	}
+
+	req := handler.NewRequest()
+	if err := c.BodyParser(req); err != nil {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := identity.Normalize(req); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.SingleRequest(handler, apiKey, memberId, companyId, req)
+	if err != nil {
+		return err
+	}
+
+	status := result.StatusCode
+	if status == 0 {
+		status = fiber.StatusOK
+	}
+
+	return c.Status(status).JSON(result)
+}
+
+func (ctrl *controller) BulkRequest(c *fiber.Ctx) error {
+	handler, err := ctrl.lookupHandler(c.Params("product_slug"))
+	if err != nil {
+		return err
+	}
+
+	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
+
+	memberId, err := helper.InterfaceToUint(c.Locals(constant.UserId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidUserSession)
+	}
+
+	companyId, err := helper.InterfaceToUint(c.Locals(constant.CompanyId))
+	if err != nil {
+		return apperror.Unauthorized(constant.InvalidCompanySession)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	err = ctrl.svc.BulkRequest(handler, apiKey, memberId, companyId, file, &columnmapping.UploadMapping{
+		MappingId: c.FormValue(constant.MappingId),
+		Mapping:   c.FormValue(constant.Mapping),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"success",
+		nil,
+	))
+}
+
+func (ctrl *controller) lookupHandler(routeSlug string) (*registry.ProductHandler, error) {
+	handler, ok := registry.GetByRouteSlug(routeSlug)
+	if !ok || handler.Group != ctrl.group {
+		return nil, apperror.NotFound(constant.ProductNotFound)
+	}
+
+	return handler, nil
+}
@@ -0,0 +1,44 @@
+package gateway
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/subscription"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/job"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupInit serves the single and bulk request endpoints of every registered
+// product of group under apiGroup.
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient, group string) {
+	productRepo := product.NewRepository(cfg, client)
+	jobRepo := job.NewRepository(cfg, client, nil)
+	transactionRepo := transaction.NewRepository(cfg, client, nil)
+	columnMappingRepo := columnmapping.NewRepository(cfg, client, nil)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	webhookRepo := webhook.NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	quotaRepo := quota.NewRepository(cfg, client, nil)
+	subscriptionRepo := subscription.NewRepository(cfg, client)
+
+	decisionService := decision.NewService(decisionRepo)
+	webhookService := webhook.NewService(webhookRepo)
+	quotaService := quota.NewService(quotaRepo, memberRepo)
+	subscriptionService := subscription.NewService(subscriptionRepo, memberRepo)
+	jobService := job.NewService(jobRepo, transactionRepo, decisionService, webhookService, quotaService)
+	columnMappingService := columnmapping.NewService(columnMappingRepo)
+	service := NewService(productRepo, transactionRepo, jobService, columnMappingService, quotaService)
+	controller := NewController(service, group)
+
+	apiGroup.Post("/:product_slug/single-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), subscription.RequireRouteProduct(subscriptionService), controller.SingleRequest)
+	apiGroup.Post("/:product_slug/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), subscription.RequireRouteProduct(subscriptionService), controller.BulkRequest)
+}
@@ -0,0 +1,27 @@
+package gateway
+
+import "front-office/internal/datahub/registry"
+
+// RowContext is one uploaded row of a bulk job bound to the product it is sent to.
+type RowContext struct {
+	Handler        *registry.ProductHandler
+	Params         *registry.CallParams
+	MemberId       uint
+	CompanyId      uint
+	ProductId      uint
+	ProductGroupId uint
+	JobId          uint
+	RowNumber      int
+	FileName       string
+	Request        any
+}
+
+// BulkInput is a parsed bulk upload. Source is the upload as sent, kept for
+// the enrichment export; Records are its rows in the product's column order,
+// header first.
+type BulkInput struct {
+	FileName string
+	Source   [][]string
+	Records  [][]string
+	Fields   []string
+}
@@ -0,0 +1,369 @@
+package gateway
+
+import (
+	"fmt"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/product"
+	"front-office/internal/core/quota"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/usepzaka/validator"
+)
+
+func NewService(
+	productRepo product.Repository,
+	transactionRepo transaction.Repository,
+	jobService job.Service,
+	columnMappingSvc columnmapping.Service,
+	quotaSvc quota.Service,
+) Service {
+	return &service{
+		productRepo,
+		transactionRepo,
+		jobService,
+		columnMappingSvc,
+		quotaSvc,
+	}
+}
+
+type service struct {
+	productRepo      product.Repository
+	transactionRepo  transaction.Repository
+	jobService       job.Service
+	columnMappingSvc columnmapping.Service
+	quotaSvc         quota.Service
+}
+
+type Service interface {
+	SingleRequest(handler *registry.ProductHandler, apiKey, memberId, companyId string, req any) (*model.ProCatAPIResponse[any], error)
+	BulkRequest(handler *registry.ProductHandler, apiKey string, memberId, companyId uint, file *multipart.FileHeader, mapping *columnmapping.UploadMapping) error
+	RunBulk(handler *registry.ProductHandler, apiKey string, memberId, companyId uint, input *BulkInput) (string, error)
+	ProcessRow(row *RowContext) (*model.ProCatAPIResponse[any], error)
+}
+
+func (svc *service) SingleRequest(handler *registry.ProductHandler, apiKey, memberId, companyId string, req any) (*model.ProCatAPIResponse[any], error) {
+	product, err := svc.productRepo.GetProductAPI(handler.Slug)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, constant.FailedFetchProduct)
+	}
+	if product.ProductId == 0 {
+		return nil, apperror.NotFound(constant.ProductNotFound)
+	}
+
+	jobRes, err := svc.jobService.CreateJob(&job.CreateJobRequest{
+		ProductId: product.ProductId,
+		MemberId:  memberId,
+		CompanyId: companyId,
+		Total:     1,
+		Calls:     handler.CallCount(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	jobIdStr := helper.ConvertUintToString(jobRes.JobId)
+
+	result, err := handler.Call(&registry.CallParams{
+		APIKey:    apiKey,
+		JobId:     jobIdStr,
+		MemberId:  memberId,
+		CompanyId: companyId,
+	}, req)
+	if err := svc.quotaSvc.Record(memberId, companyId, product.ProductId, jobRes.JobId, handler.CallCount(), paidCalls(result)); err != nil {
+		log.Error().Err(err).Str("job_id", jobIdStr).Msg("failed to record quota usage")
+	}
+	if err != nil {
+		if err := svc.jobService.FinalizeFailedJob(jobIdStr, companyId); err != nil {
+			return nil, err
+		}
+
+		return nil, mapCallError(handler, err)
+	}
+
+	if err := svc.completeTransactions(result, map[string]interface{}{
+		"success": helper.BoolPtr(true),
+	}); err != nil {
+		if err := svc.jobService.FinalizeFailedJob(jobIdStr, companyId); err != nil {
+			log.Error().Err(err).Str("job_id", jobIdStr).Msg("failed to fail job")
+		}
+
+		return nil, apperror.MapRepoError(err, "failed to update transaction log")
+	}
+
+	if err := svc.jobService.FinalizeJob(jobIdStr, companyId); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (svc *service) BulkRequest(handler *registry.ProductHandler, apiKey string, memberId, companyId uint, file *multipart.FileHeader, mapping *columnmapping.UploadMapping) error {
+	if err := helper.ValidateUploadedFile(file, 30*1024*1024, []string{".csv"}); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	upload, err := svc.columnMappingSvc.ParseUpload(helper.ConvertUintToString(companyId), handler.Slug, file, mapping)
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.RunBulk(handler, apiKey, memberId, companyId, &BulkInput{
+		FileName: file.Filename,
+		Source:   upload.Source,
+		Records:  upload.Records,
+		Fields:   upload.Fields,
+	})
+
+	return err
+}
+
+// RunBulk processes an already parsed upload as a new job and returns the
+// job ID once every row has been processed.
+func (svc *service) RunBulk(handler *registry.ProductHandler, apiKey string, memberId, companyId uint, input *BulkInput) (string, error) {
+	product, err := svc.productRepo.GetProductAPI(handler.Slug)
+	if err != nil {
+		return "", apperror.MapRepoError(err, constant.FailedFetchProduct)
+	}
+	if product.ProductId == 0 {
+		return "", apperror.NotFound(constant.ProductNotFound)
+	}
+
+	memberIdStr := helper.ConvertUintToString(memberId)
+	companyIdStr := helper.ConvertUintToString(companyId)
+	records := input.Records
+
+	jobRes, err := svc.jobService.CreateJob(&job.CreateJobRequest{
+		ProductId: product.ProductId,
+		MemberId:  memberIdStr,
+		CompanyId: companyIdStr,
+		Total:     len(records) - 1,
+		Calls:     handler.CallCount(),
+	})
+	if err != nil {
+		return "", err
+	}
+	jobIdStr := helper.ConvertUintToString(jobRes.JobId)
+
+	if err := svc.jobService.SaveJobSource(jobIdStr, &job.JobSource{
+		FileName: input.FileName,
+		Records:  input.Source,
+		Fields:   input.Fields,
+	}); err != nil {
+		// without its upload the job could not be exported enriched
+		if err := svc.jobService.FinalizeFailedJob(jobIdStr, companyIdStr); err != nil {
+			log.Error().Err(err).Str("job_id", jobIdStr).Msg("failed to fail job")
+		}
+
+		return "", err
+	}
+
+	params := &registry.CallParams{
+		APIKey:    apiKey,
+		JobId:     jobIdStr,
+		MemberId:  memberIdStr,
+		CompanyId: companyIdStr,
+	}
+
+	var (
+		wg         sync.WaitGroup
+		errChan    = make(chan error, len(records))
+		batchCount = 0
+	)
+
+	for i := 1; i < len(records); i++ { // skip header
+		row := &RowContext{
+			Handler:        handler,
+			Params:         params,
+			MemberId:       memberId,
+			CompanyId:      companyId,
+			ProductId:      product.ProductId,
+			ProductGroupId: product.ProductGroupId,
+			JobId:          jobRes.JobId,
+			RowNumber:      helper.CSVRowNumber(i),
+			FileName:       input.FileName,
+		}
+
+		req, err := handler.RequestFromRow(records[i])
+		if err != nil {
+			// an unreadable row fails on its own, like a row failing validation
+			row.Request = records[i]
+			svc.logRejectedRow(row, fmt.Errorf("%s: %w", constant.FailedParseCSV, err))
+			if err := svc.quotaSvc.Record(memberIdStr, companyIdStr, product.ProductId, jobRes.JobId, handler.CallCount(), 0); err != nil {
+				log.Error().Err(err).Str("job_id", jobIdStr).Int("row_number", row.RowNumber).Msg("failed to record quota usage")
+			}
+			progress.Record(companyIdStr, jobIdStr, false)
+			continue
+		}
+		row.Request = req
+
+		wg.Add(1)
+
+		go func(row *RowContext) {
+			defer wg.Done()
+
+			if _, err := svc.ProcessRow(row); err != nil {
+				errChan <- err
+			}
+		}(row)
+
+		batchCount++
+		if batchCount == 100 {
+			time.Sleep(time.Second)
+			batchCount = 0
+		}
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	for err := range errChan {
+		log.Error().Err(err).Str("product", handler.Slug).Msg("error during bulk processing")
+	}
+
+	return jobIdStr, svc.jobService.FinalizeJob(jobIdStr, companyIdStr)
+}
+
+// ProcessRow sends one row to its product and records the outcome in the
+// transaction log, quota and progress stream of the row's job.
+func (svc *service) ProcessRow(params *RowContext) (*model.ProCatAPIResponse[any], error) {
+	result, err := svc.processRow(params)
+	if err := svc.quotaSvc.Record(params.Params.MemberId, params.Params.CompanyId, params.ProductId, params.JobId, params.Handler.CallCount(), paidCalls(result)); err != nil {
+		log.Error().Err(err).Str("job_id", params.Params.JobId).Int("row_number", params.RowNumber).Msg("failed to record quota usage")
+	}
+	progress.Record(params.Params.CompanyId, params.Params.JobId, err == nil)
+
+	return result, err
+}
+
+func (svc *service) processRow(params *RowContext) (*model.ProCatAPIResponse[any], error) {
+	err := validator.ValidateStruct(params.Request)
+	if err == nil {
+		err = identity.Normalize(params.Request)
+	}
+
+	if err != nil {
+		svc.logRejectedRow(params, err)
+
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	result, err := params.Handler.Call(params.Params, params.Request)
+	if err != nil {
+		message, status := err.Error(), http.StatusInternalServerError
+		if result != nil {
+			message, status = result.Message, result.StatusCode
+		}
+
+		if err := svc.transactionRepo.CreateLogTransAPI(&transaction.LogTransProCatRequest{
+			MemberID:       params.MemberId,
+			CompanyID:      params.CompanyId,
+			ProductID:      params.ProductId,
+			ProductGroupID: params.ProductGroupId,
+			JobID:          params.JobId,
+			RowNumber:      params.RowNumber,
+			FileName:       params.FileName,
+			Message:        message,
+			Status:         status,
+			Success:        false,
+			ResponseBody: &transaction.ResponseBody{
+				Input:    params.Request,
+				DateTime: time.Now().Format(constant.FormatDateAndTime),
+			},
+			Data:         nil,
+			RequestBody:  params.Request,
+			RequestTime:  time.Now(),
+			ResponseTime: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+
+		return nil, mapCallError(params.Handler, err)
+	}
+
+	if err := svc.completeTransactions(result, map[string]interface{}{
+		"success":    helper.BoolPtr(true),
+		"row_number": params.RowNumber,
+		"file_name":  params.FileName,
+	}); err != nil {
+		return nil, apperror.MapRepoError(err, "failed to update log transaction")
+	}
+
+	return result, nil
+}
+
+// logRejectedRow records a row that was never sent to its product because
+// its request could not be read or failed validation.
+func (svc *service) logRejectedRow(params *RowContext, err error) {
+	_ = svc.transactionRepo.CreateLogTransAPI(&transaction.LogTransProCatRequest{
+		MemberID:       params.MemberId,
+		CompanyID:      params.CompanyId,
+		ProductID:      params.ProductId,
+		ProductGroupID: params.ProductGroupId,
+		JobID:          params.JobId,
+		RowNumber:      params.RowNumber,
+		FileName:       params.FileName,
+		Message:        err.Error(),
+		Status:         http.StatusBadRequest,
+		Success:        false,
+		ResponseBody: &transaction.ResponseBody{
+			Input:    params.Request,
+			DateTime: time.Now().Format(constant.FormatDateAndTime),
+		},
+		Data:        nil,
+		RequestBody: params.Request,
+	})
+}
+
+// completeTransactions updates the transaction log of a successful call. A
+// merged result is stored on each of the transactions it was built from, so
+// any one of them describes the whole request.
+func (svc *service) completeTransactions(result *model.ProCatAPIResponse[any], fields map[string]interface{}) error {
+	transactionIds := []string{result.TransactionId}
+	if merged, ok := result.Data.(registry.MergedResult); ok {
+		transactionIds = merged.TransactionIds()
+		fields["data"] = result.Data
+	}
+
+	for _, transactionId := range transactionIds {
+		if err := svc.transactionRepo.UpdateLogTransAPI(transactionId, fields); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// paidCalls returns the number of billed calls behind a paid result: one per
+// transaction of a merged result, otherwise one.
+func paidCalls(result *model.ProCatAPIResponse[any]) int {
+	if result == nil || result.PricingStrategy != constant.PricingStrategyPay {
+		return 0
+	}
+
+	if merged, ok := result.Data.(registry.MergedResult); ok {
+		return len(merged.TransactionIds())
+	}
+
+	return 1
+}
+
+func mapCallError(handler *registry.ProductHandler, err error) error {
+	context := fmt.Sprintf("failed to process %s", handler.Name)
+	if handler.MapError != nil {
+		return handler.MapError(err, context)
+	}
+
+	return apperror.MapRepoError(err, context)
+}
@@ -0,0 +1,48 @@
+package nikdecoder
+
+import (
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController() Controller {
+	return &controller{}
+}
+
+type controller struct{}
+
+type Controller interface {
+	Decode(c *fiber.Ctx) error
+}
+
+// Decode reads the region, birth date and gender encoded in a NIK. It runs
+// locally, so it creates no job and uses no quota.
+func (ctrl *controller) Decode(c *fiber.Ctx) error {
+	reqBody, ok := c.Locals(constant.Request).(*decodeRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	decoded, err := identity.DecodeNIK(reqBody.NIK)
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to decode NIK",
+		&decodeResponse{
+			NIK:          decoded.Number,
+			ProvinceCode: decoded.ProvinceCode,
+			Province:     decoded.Province,
+			RegencyCode:  decoded.RegencyCode,
+			Regency:      decoded.Regency,
+			DistrictCode: decoded.DistrictCode,
+			BirthDate:    decoded.BirthDate.Format(constant.FormatYYYYMMDD),
+			Gender:       decoded.Gender,
+		},
+	))
+}
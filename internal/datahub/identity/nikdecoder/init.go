@@ -0,0 +1,14 @@
+package nikdecoder
+
+import (
+	"front-office/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router) {
+	controller := NewController()
+
+	nikDecoderGroup := apiGroup.Group("nik-decoder")
+	nikDecoderGroup.Post("/decode", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(decodeRequest{}), controller.Decode)
+}
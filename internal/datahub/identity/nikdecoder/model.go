@@ -0,0 +1,16 @@
+package nikdecoder
+
+type decodeRequest struct {
+	NIK string `json:"nik" validate:"required~NIK cannot be empty."`
+}
+
+type decodeResponse struct {
+	NIK          string `json:"nik"`
+	ProvinceCode string `json:"province_code"`
+	Province     string `json:"province"`
+	RegencyCode  string `json:"regency_code"`
+	Regency      string `json:"regency"`
+	DistrictCode string `json:"district_code"`
+	BirthDate    string `json:"birth_date"`
+	Gender       string `json:"gender"`
+}
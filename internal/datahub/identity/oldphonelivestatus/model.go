@@ -6,6 +6,23 @@ import (
 	"time"
 )
 
+const (
+	// adminTierLevel lists the legacy jobs of every member of a company.
+	adminTierLevel    = "1"
+	migrationPageSize = 100
+	// migratedFileName is recorded as the source file of migrated rows, the
+	// legacy pipeline did not keep the uploaded file name.
+	migratedFileName = "legacy_phone_live_status.csv"
+)
+
+// MigrationResult counts what MigrateCompany copied. Jobs still running and
+// jobs migrated by an earlier run are skipped.
+type MigrationResult struct {
+	Jobs    int `json:"jobs"`
+	Rows    int `json:"rows"`
+	Skipped int `json:"skipped"`
+}
+
 type mstPhoneLiveStatusJob struct {
 	Id           uint               `json:"id"`
 	Total        int                `json:"total"`
@@ -24,7 +41,7 @@ type mstPhoneLiveStatusJobDetail struct {
 	MemberId         uint      `json:"member_id"`
 	CompanyId        uint      `json:"company_id"`
 	JobId            uint      `json:"job_id"`
-	PhoneNumber      string    `json:"phone_number" validate:"required~phone number is required, min(10)~phone number must be at least 10 characters, indophone~invalid number"`
+	PhoneNumber      string    `json:"phone_number" validate:"required~phone number is required"`
 	InProgress       bool      `json:"in_progess"`
 	Sequence         int       `json:"sequence"`
 	Status           string    `json:"status"`
@@ -39,7 +56,7 @@ type mstPhoneLiveStatusJobDetail struct {
 }
 
 type phoneLiveStatusRequest struct {
-	PhoneNumber string `json:"phone_number" validate:"required~phone number is required, min(10)~phone number must be at least 10 characters, indophone~invalid number"`
+	PhoneNumber string `json:"phone_number" validate:"required~phone number is required"`
 	TrxId       string `json:"trx_id"`
 }
 
@@ -1,429 +1,230 @@
 package oldphonelivestatus
 
 import (
-	"bytes"
-	"encoding/csv"
 	"fmt"
-	"front-office/internal/core/member"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/product"
+	"front-office/internal/datahub/job"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
-	"front-office/pkg/common/model"
 	"front-office/pkg/helper"
-	"mime/multipart"
+	"net/http"
+	"sort"
 	"strconv"
-	"strings"
-	"sync"
-	"time"
 
 	"github.com/rs/zerolog/log"
-	"github.com/usepzaka/validator"
 )
 
-func NewService(repo Repository, memberRepo member.Repository) Service {
+// NewService returns the migration of legacy phone live status jobs. The
+// legacy routes are served by the phonelivestatus package; this package only
+// remains to copy the jobs it stored, and can be removed once every company
+// is migrated.
+func NewService(repo Repository, productRepo product.Repository, jobRepo job.Repository, transactionRepo transaction.Repository) Service {
 	return &service{
 		repo,
-		memberRepo,
+		productRepo,
+		jobRepo,
+		transactionRepo,
 	}
 }
 
 type service struct {
-	repo       Repository
-	memberRepo member.Repository
+	repo            Repository
+	productRepo     product.Repository
+	jobRepo         job.Repository
+	transactionRepo transaction.Repository
 }
 
 type Service interface {
-	CreateJob(memberId, companyId string, reqBody *createJobRequest) (*createJobRespData, error)
-	GetPhoneLiveStatusJob(filter *phoneLiveStatusFilter) (*jobListRespData, error)
-	GetAllPhoneLiveStatusDetails(filter *phoneLiveStatusFilter) ([]*mstPhoneLiveStatusJobDetail, error)
-	GetPhoneLiveStatusDetailsByDateRange(filter *phoneLiveStatusFilter) ([]*mstPhoneLiveStatusJobDetail, error)
-	GetJobsSummary(filter *phoneLiveStatusFilter) (*jobsSummaryRespData, error)
-	GetPhoneLiveStatusDetailsSummary(filter *phoneLiveStatusFilter) (*jobDetailRespData, error)
-	ExportJobsSummary(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error)
-	ExportJobDetails(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error)
-	UpdateJob(jobId uint, reqBody *updateJobRequest) error
-	UpdateJobDetail(jobId, jobDetailId uint, reqBody *updateJobDetailRequest) error
-	ProcessPhoneLiveStatus(memberId, companyId string, reqBody *phoneLiveStatusRequest) error
-	BulkProcessPhoneLiveStatus(apiKey, memberId, companyId string, fileHeader *multipart.FileHeader) error
+	MigrateCompany(memberId, companyId string) (*MigrationResult, error)
 }
 
-func (svc *service) CreateJob(memberId, companyId string, reqBody *createJobRequest) (*createJobRespData, error) {
-	job, err := svc.repo.CreateJobAPI(reqBody)
+// MigrateCompany copies every finished legacy job of a company into the
+// shared job and transaction logs, one transaction per job detail, and marks
+// the legacy job migrated so a rerun skips it. memberId must be an admin of
+// the company so that the jobs of all its members are listed.
+func (svc *service) MigrateCompany(memberId, companyId string) (*MigrationResult, error) {
+	product, err := svc.productRepo.GetProductAPI(constant.SlugPhoneLiveStatus)
 	if err != nil {
-		return nil, apperror.MapRepoError(err, constant.ErrCreatePhoneLiveJob)
+		return nil, apperror.MapRepoError(err, constant.FailedFetchProduct)
 	}
-
-	return job, err
-}
-
-func (svc *service) GetPhoneLiveStatusJob(filter *phoneLiveStatusFilter) (*jobListRespData, error) {
-	jobs, err := svc.repo.CallGetPhoneLiveStatusJobAPI(filter)
-	if err != nil {
-		return nil, apperror.MapRepoError(err, "failed to fetch phone live status jobs")
-	}
-
-	return jobs, nil
-}
-
-func (svc *service) GetPhoneLiveStatusDetailsSummary(filter *phoneLiveStatusFilter) (*jobDetailRespData, error) {
-	jobDetails, err := svc.repo.GetJobDetailsAPI(filter)
-	if err != nil {
-		return nil, apperror.MapRepoError(err, constant.ErrFetchPhoneLiveDetail)
-	}
-
-	return jobDetails, nil
-}
-
-func (svc *service) GetAllPhoneLiveStatusDetails(filter *phoneLiveStatusFilter) ([]*mstPhoneLiveStatusJobDetail, error) {
-	jobDetails, err := svc.repo.CallGetAllJobDetailsAPI(filter)
-	if err != nil {
-		return nil, apperror.MapRepoError(err, constant.ErrFetchPhoneLiveDetail)
-	}
-
-	return jobDetails, nil
-}
-
-func (svc *service) GetPhoneLiveStatusDetailsByDateRange(filter *phoneLiveStatusFilter) ([]*mstPhoneLiveStatusJobDetail, error) {
-	jobDetail, err := svc.repo.CallGetJobDetailsByDateRangeAPI(filter)
-	if err != nil {
-		return nil, apperror.MapRepoError(err, constant.ErrFetchPhoneLiveDetail)
-	}
-
-	return jobDetail, nil
-}
-
-func (svc *service) GetJobsSummary(filter *phoneLiveStatusFilter) (*jobsSummaryRespData, error) {
-	jobsSummary, err := svc.repo.CallGetJobsSummary(filter)
-	if err != nil {
-		return nil, apperror.MapRepoError(err, "failed to fetch phone live status jobs summary")
-	}
-
-	return jobsSummary, nil
-}
-
-func (svc *service) ExportJobsSummary(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error) {
-	data, err := svc.repo.CallGetJobDetailsByDateRangeAPI(filter)
-	if err != nil {
-		return "", apperror.MapRepoError(err, "failed to fetch job details")
-	}
-
-	if err := writeJobDetailsToCSV(buf, data); err != nil {
-		return "", apperror.Internal("failed to write CSV", err)
-	}
-
-	filename := formatCSVFileName("job_summary", filter.StartDate, filter.EndDate)
-
-	return filename, nil
-}
-
-func (svc *service) ExportJobDetails(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error) {
-	data, err := svc.repo.CallGetAllJobDetailsAPI(filter)
-	if err != nil {
-		return "", apperror.MapRepoError(err, "failed to fetch job details")
+	if product.ProductId == 0 {
+		return nil, apperror.NotFound(constant.ProductNotFound)
 	}
 
-	if err := writeJobDetailsToCSV(buf, data); err != nil {
-		return "", apperror.Internal("failed to write CSV", err)
-	}
-
-	filename := formatCSVFileName("job_summary", filter.StartDate, filter.EndDate)
+	result := &MigrationResult{}
+	for page := 1; ; page++ {
+		jobs, err := svc.repo.CallGetPhoneLiveStatusJobAPI(&phoneLiveStatusFilter{
+			Page:      strconv.Itoa(page),
+			Size:      strconv.Itoa(migrationPageSize),
+			MemberId:  memberId,
+			CompanyId: companyId,
+			TierLevel: adminTierLevel,
+		})
+		if err != nil {
+			return result, apperror.MapRepoError(err, "failed to fetch legacy phone live status jobs")
+		}
 
-	return filename, nil
-}
+		for _, legacyJob := range jobs.Jobs {
+			if legacyJob.Status != constant.JobStatusDone {
+				result.Skipped++
+				continue
+			}
 
-func (svc *service) UpdateJob(jobId uint, reqBody *updateJobRequest) error {
-	jobIdStr := strconv.FormatUint(uint64(jobId), 10)
-	if err := svc.repo.UpdateJobAPI(jobIdStr, reqBody); err != nil {
-		return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveJob)
-	}
+			rows, err := svc.migrateJob(product.ProductId, product.ProductGroupId, legacyJob)
+			if err != nil {
+				return result, err
+			}
 
-	return nil
-}
+			result.Jobs++
+			result.Rows += rows
+		}
 
-func (svc *service) UpdateJobDetail(jobId, jobDetailId uint, reqBody *updateJobDetailRequest) error {
-	jobIdStr := strconv.FormatUint(uint64(jobId), 10)
-	jobDetailIdStr := strconv.FormatUint(uint64(jobId), 10)
-	if err := svc.repo.CallUpdateJobDetail(jobIdStr, jobDetailIdStr, reqBody); err != nil {
-		return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveDetail)
+		if len(jobs.Jobs) < migrationPageSize {
+			return result, nil
+		}
 	}
-
-	return nil
 }
 
-func (svc *service) ProcessPhoneLiveStatus(memberId, companyId string, reqBody *phoneLiveStatusRequest) error {
-	member, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{
-		Id:        memberId,
-		CompanyId: companyId,
-	})
-	if err != nil {
-		return apperror.MapRepoError(err, constant.FailedFetchMember)
-	}
-	if member.MemberId == 0 {
-		return apperror.NotFound(constant.UserNotFound)
-	}
-
-	job, err := svc.repo.CreateJobAPI(&createJobRequest{
-		MemberId:                memberId,
-		CompanyId:               companyId,
-		PhoneLiveStatusRequests: []*phoneLiveStatusRequest{reqBody},
-	})
-	if err != nil {
-		return apperror.MapRepoError(err, constant.ErrCreatePhoneLiveJob)
-	}
-	jobIdStr := strconv.Itoa(int(job.JobId))
+func (svc *service) migrateJob(productId, productGroupId uint, legacyJob *mstPhoneLiveStatusJob) (int, error) {
+	legacyJobId := strconv.FormatUint(uint64(legacyJob.Id), 10)
+	memberId := strconv.FormatUint(uint64(legacyJob.MemberId), 10)
+	companyId := strconv.FormatUint(uint64(legacyJob.CompanyId), 10)
 
-	jobDetails, err := svc.repo.CallGetAllJobDetailsAPI(&phoneLiveStatusFilter{
-		JobId:     jobIdStr,
+	details, err := svc.repo.CallGetAllJobDetailsAPI(&phoneLiveStatusFilter{
+		JobId:     legacyJobId,
 		MemberId:  memberId,
 		CompanyId: companyId,
 	})
 	if err != nil {
-		return apperror.MapRepoError(err, "failed to fetch job detail")
+		return 0, apperror.MapRepoError(err, constant.ErrFetchPhoneLiveDetail)
 	}
-	if len(jobDetails) == 0 {
-		return apperror.NotFound("no job details found")
-	}
-	jobDetailIdStr := strconv.Itoa(int(jobDetails[0].Id))
 
-	if err := svc.validateSingleRequest(jobIdStr, jobDetailIdStr, reqBody); err != nil {
-		return err
-	}
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].Sequence < details[j].Sequence
+	})
 
-	result, err := svc.processPhoneLiveStatus(member.Key, jobIdStr, jobDetailIdStr, jobDetails[0])
-	if err != nil {
-		return err
+	if len(details) > 0 {
+		if err := svc.copyJob(productId, productGroupId, legacyJob, details); err != nil {
+			return 0, err
+		}
 	}
 
-	if err := svc.updateProcessedDetail(jobIdStr, jobDetailIdStr, result); err != nil {
-		return err
+	if err := svc.repo.UpdateJobAPI(legacyJobId, &updateJobRequest{
+		Status: helper.StringPtr(constant.JobStatusMigrated),
+	}); err != nil {
+		return 0, apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveJob)
 	}
 
-	return svc.finalizeJob(jobIdStr)
+	return len(details), nil
 }
 
-func (svc *service) BulkProcessPhoneLiveStatus(apiKey, memberId, companyId string, file *multipart.FileHeader) error {
-	if err := helper.ValidateUploadedFile(file, 30*1024*1024, []string{".csv"}); err != nil {
-		return apperror.BadRequest(err.Error())
-	}
-
-	records, err := helper.ParseCSVFile(file, []string{"phone_number"})
-	if err != nil {
-		return apperror.Internal(constant.FailedParseCSV, err)
-	}
-
-	var phoneReqs []*phoneLiveStatusRequest
-	for i := 1; i < len(records); i++ { // Skip header
-		phoneReqs = append(phoneReqs, &phoneLiveStatusRequest{
-			PhoneNumber: records[i][0],
-		})
-	}
-
-	job, err := svc.repo.CreateJobAPI(&createJobRequest{
-		MemberId:                memberId,
-		CompanyId:               companyId,
-		PhoneLiveStatusRequests: phoneReqs,
-	})
-	if err != nil {
-		return apperror.MapRepoError(err, constant.ErrCreatePhoneLiveJob)
-	}
-	jobIdStr := strconv.Itoa(int(job.JobId))
-
-	jobDetails, err := svc.repo.CallGetAllJobDetailsAPI(&phoneLiveStatusFilter{
-		JobId:     jobIdStr,
-		MemberId:  memberId,
-		CompanyId: companyId,
+// copyJob creates the job and transactions of a legacy job. A copy that
+// fails halfway is finalized as failed and the legacy job left unmarked.
+func (svc *service) copyJob(productId, productGroupId uint, legacyJob *mstPhoneLiveStatusJob, details []*mstPhoneLiveStatusJobDetail) error {
+	newJob, err := svc.jobRepo.CreateJobAPI(&job.CreateJobRequest{
+		ProductId: productId,
+		MemberId:  strconv.FormatUint(uint64(legacyJob.MemberId), 10),
+		CompanyId: strconv.FormatUint(uint64(legacyJob.CompanyId), 10),
+		Total:     len(details),
+		// kept so the legacy routes can still find the job by its old id
+		LegacyJobId: legacyJob.Id,
 	})
 	if err != nil {
-		return apperror.MapRepoError(err, "failed to fetch job detail")
-	}
-	if len(jobDetails) == 0 {
-		return apperror.NotFound("no job details found")
+		return apperror.MapRepoError(err, "failed to create job")
 	}
+	jobIdStr := strconv.FormatUint(uint64(newJob.JobId), 10)
 
-	var (
-		wg         sync.WaitGroup
-		errChan    = make(chan error, len(jobDetails))
-		batchCount = 0
-	)
-
-	for _, detail := range jobDetails {
-		wg.Add(1)
-
-		go func(detail *mstPhoneLiveStatusJobDetail) {
-			defer wg.Done()
-			detailID := strconv.Itoa(int(detail.Id))
-
-			if err := svc.processAndUpdatePhoneLiveStatus(apiKey, jobIdStr, detailID, detail); err != nil {
-				errChan <- err
+	var successCount uint
+	for i, detail := range details {
+		logTrans := toLogTrans(productId, productGroupId, newJob.JobId, helper.CSVRowNumber(i+1), detail)
+		if err := svc.transactionRepo.CreateLogTransAPI(logTrans); err != nil {
+			if err := svc.jobRepo.UpdateJobAPI(jobIdStr, map[string]interface{}{"status": constant.JobStatusFailed}); err != nil {
+				log.Warn().Err(err).Str("job_id", jobIdStr).Msg("failed to mark partial legacy job copy as failed")
 			}
-		}(detail)
 
-		batchCount++
-		if batchCount == 100 {
-			time.Sleep(time.Second)
-			batchCount = 0
+			return apperror.MapRepoError(err, fmt.Sprintf("failed to copy detail %d of legacy job %d", detail.Id, legacyJob.Id))
 		}
-	}
-
-	wg.Wait()
-	close(errChan)
-
-	for err := range errChan {
-		log.Error().Err(err).Msg("error during bulk phone live status processing")
-	}
 
-	return svc.finalizeJob(jobIdStr)
-}
-
-func writeJobDetailsToCSV(buf *bytes.Buffer, data []*mstPhoneLiveStatusJobDetail) error {
-	w := csv.NewWriter(buf)
-	headers := []string{"Phone Number", "Subscriber Status", "Device Status", "Operator", "Phone Type", "Status", "Description"}
-
-	if err := w.Write(headers); err != nil {
-		return err
-	}
-
-	for _, d := range data {
-		desc := ""
-		if d.Message != nil {
-			desc = *d.Message
-		}
-
-		row := []string{d.PhoneNumber, d.SubscriberStatus, d.DeviceStatus, d.Operator, d.PhoneType, d.Status, desc}
-		if err := w.Write(row); err != nil {
-			return err
-		}
-	}
-
-	w.Flush()
-	return w.Error()
-}
-
-func formatCSVFileName(base, startDate, endDate string) string {
-	if endDate != "" && endDate != startDate {
-		return fmt.Sprintf("%s_%s_until_%s.csv", base, startDate, endDate)
-	}
-	return fmt.Sprintf("%s_%s.csv", base, startDate)
-}
-
-func (svc *service) validateSingleRequest(jobId, jobDetailId string, reqBody *phoneLiveStatusRequest) error {
-	if errValidation := validator.ValidateStruct(reqBody); errValidation != nil {
-		if err := svc.repo.UpdateJobAPI(jobId, &updateJobRequest{
-			Status:       helper.StringPtr(constant.JobStatusDone),
-			SuccessCount: helper.IntPtr(1),
-			EndAt:        helper.TimePtr(time.Now()),
-		}); err != nil {
-			return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveJob)
+		if logTrans.Success {
+			successCount++
 		}
-
-		if err := svc.repo.CallUpdateJobDetail(jobId, jobDetailId, &updateJobDetailRequest{
-			Message:    helper.StringPtr(errValidation.Error()),
-			InProgress: helper.BoolPtr(false),
-			Status:     helper.StringPtr(constant.JobStatusFailed),
-		}); err != nil {
-			return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveDetail)
-		}
-
-		return apperror.BadRequest(errValidation.Error())
 	}
 
-	return nil
-}
-
-func (svc *service) processPhoneLiveStatus(apiKey, jobId, jobDetailId string, jobDetail *mstPhoneLiveStatusJobDetail) (*model.ProCatAPIResponse[phoneLiveStatusRespData], error) {
-	req := &phoneLiveStatusRequest{
-		PhoneNumber: jobDetail.PhoneNumber,
-		TrxId:       strconv.FormatUint(uint64(jobDetail.JobId), 10),
+	endAt := legacyJob.CreatedAt
+	if legacyJob.EndAt != nil {
+		endAt = *legacyJob.EndAt
 	}
 
-	result, err := svc.repo.CallPhoneLiveStatusAPI(apiKey, req)
-	if err != nil {
-		if err := svc.repo.CallUpdateJobDetail(jobId, jobDetailId, &updateJobDetailRequest{
-			Message:    helper.StringPtr(err.Error()),
-			InProgress: helper.BoolPtr(false),
-			Status:     helper.StringPtr(constant.JobStatusError),
-		}); err != nil {
-			return nil, apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveDetail)
-		}
-
-		return nil, apperror.MapRepoError(err, "failed to process phone live status request")
-	}
-
-	return result, nil
-}
-
-func (svc *service) processAndUpdatePhoneLiveStatus(apiKey, jobId, jobDetailId string, detail *mstPhoneLiveStatusJobDetail) error {
-	if err := validator.ValidateStruct(detail); err != nil {
-		_ = svc.repo.CallUpdateJobDetail(jobId, jobDetailId, &updateJobDetailRequest{
-			Message:    helper.StringPtr(err.Error()),
-			InProgress: helper.BoolPtr(false),
-			Status:     helper.StringPtr(constant.JobStatusError),
-		})
-
-		return apperror.BadRequest(err.Error())
-	}
-
-	resp, err := svc.processPhoneLiveStatus(apiKey, jobId, jobDetailId, detail)
-	if err != nil {
-		return err
-	}
-
-	if err := svc.updateProcessedDetail(jobId, jobDetailId, resp); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func parseLiveStatusData(liveStatus string) (subscriberStatus, deviceStatus string, err error) {
-	if liveStatus == "" {
-		return "", "", nil
-	}
-
-	parts := strings.Split(liveStatus, ",")
-
-	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
-}
-
-func (svc *service) updateProcessedDetail(jobId, jobDetailId string, phoneLiveResp *model.ProCatAPIResponse[phoneLiveStatusRespData]) error {
-	status := "success"
-	if len(phoneLiveResp.Data.Errors) > 0 && phoneLiveResp.Data.Errors[0].Code == -60001 {
-		status = "fail"
-	}
-
-	subcriberStatus, deviceStatus, err := parseLiveStatusData(phoneLiveResp.Data.LiveStatus)
-	if err != nil {
-		return apperror.Internal("failed to parse live status data", err)
-	}
-
-	if err := svc.repo.CallUpdateJobDetail(jobId, jobDetailId, &updateJobDetailRequest{
-		Status:           helper.StringPtr(status),
-		InProgress:       helper.BoolPtr(false),
-		SubscriberStatus: helper.StringPtr(subcriberStatus),
-		DeviceStatus:     helper.StringPtr(deviceStatus),
-		PhoneType:        helper.StringPtr(phoneLiveResp.Data.PhoneType),
-		Operator:         helper.StringPtr(phoneLiveResp.Data.Operator),
-		PricingStrategy:  helper.StringPtr(phoneLiveResp.PricingStrategy),
-		TransactionId:    helper.StringPtr(phoneLiveResp.TransactionId),
+	if err := svc.jobRepo.UpdateJobAPI(jobIdStr, map[string]interface{}{
+		"status":        constant.JobStatusDone,
+		"success_count": successCount,
+		"end_at":        endAt,
 	}); err != nil {
-		return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveDetail)
+		return apperror.MapRepoError(err, "failed to update job")
 	}
 
 	return nil
 }
 
-func (svc *service) finalizeJob(jobId string) error {
-	count, err := svc.repo.CallGetProcessedCountAPI(jobId)
-	if err != nil {
-		return apperror.MapRepoError(err, "failed to get processed count request")
-	}
-
-	if err := svc.repo.UpdateJobAPI(jobId, &updateJobRequest{
-		Status:       helper.StringPtr(constant.JobStatusDone),
-		SuccessCount: helper.IntPtr(int(count.SuccessCount)),
-		EndAt:        helper.TimePtr(time.Now()),
-	}); err != nil {
-		return apperror.MapRepoError(err, constant.ErrMsgUpdatePhoneLiveJob)
-	}
-
-	return nil
+// toLogTrans converts a legacy job detail into the transaction the gateway
+// would have logged for it. Legacy "fail" details are answered checks of
+// numbers the partner does not know, so they keep their data.
+func toLogTrans(productId, productGroupId, jobId uint, rowNumber int, detail *mstPhoneLiveStatusJobDetail) *transaction.LogTransProCatRequest {
+	input := map[string]string{"phone_number": detail.PhoneNumber}
+
+	var data map[string]string
+	status, success := http.StatusInternalServerError, false
+	switch detail.Status {
+	case "success", "fail":
+		status, success = http.StatusOK, true
+		data = map[string]string{
+			"live_status": joinLiveStatus(detail.SubscriberStatus, detail.DeviceStatus),
+			"operator":    detail.Operator,
+			"phone_type":  detail.PhoneType,
+		}
+	case constant.JobStatusFailed:
+		status = http.StatusBadRequest
+	}
+
+	message := ""
+	if detail.Message != nil {
+		message = *detail.Message
+	}
+
+	return &transaction.LogTransProCatRequest{
+		TransactionID:  detail.TransactionId,
+		MemberID:       detail.MemberId,
+		CompanyID:      detail.CompanyId,
+		JobID:          jobId,
+		ProductID:      productId,
+		ProductGroupID: productGroupId,
+		RequestBody:    input,
+		ResponseBody: &transaction.ResponseBody{
+			Data:            data,
+			Input:           input,
+			TransactionId:   detail.TransactionId,
+			PricingStrategy: detail.PricingStrategy,
+			DateTime:        detail.CreatedAt.Format(constant.FormatDateAndTime),
+		},
+		Data:            data,
+		Status:          status,
+		Success:         success,
+		Message:         message,
+		PricingStrategy: detail.PricingStrategy,
+		RowNumber:       rowNumber,
+		FileName:        migratedFileName,
+		RequestTime:     detail.CreatedAt,
+		ResponseTime:    detail.CreatedAt,
+	}
+}
+
+// joinLiveStatus rebuilds the "subscriber, device" live status the partner
+// returns from the two columns the legacy pipeline split it into.
+func joinLiveStatus(subscriberStatus, deviceStatus string) string {
+	if deviceStatus == "" {
+		return subscriberStatus
+	}
+
+	return subscriberStatus + ", " + deviceStatus
 }
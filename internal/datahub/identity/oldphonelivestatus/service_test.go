@@ -1 +1,66 @@
 package oldphonelivestatus
+
+import (
+	"front-office/pkg/common/constant"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLogTrans(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	detail := func(status string) *mstPhoneLiveStatusJobDetail {
+		return &mstPhoneLiveStatusJobDetail{
+			MemberId:         3,
+			CompanyId:        2,
+			PhoneNumber:      constant.DummyPhoneNumber,
+			Status:           status,
+			SubscriberStatus: "ACTIVE",
+			DeviceStatus:     "REACHABLE",
+			PhoneType:        "mobile",
+			Operator:         "Telkomsel",
+			PricingStrategy:  constant.PricingStrategyPay,
+			TransactionId:    "trx-1",
+			CreatedAt:        createdAt,
+		}
+	}
+
+	t.Run("should keep the data of answered checks", func(t *testing.T) {
+		result := toLogTrans(1, 4, 9, 2, detail("success"))
+
+		assert.Equal(t, http.StatusOK, result.Status)
+		assert.True(t, result.Success)
+		assert.Equal(t, uint(9), result.JobID)
+		assert.Equal(t, 2, result.RowNumber)
+		assert.Equal(t, "trx-1", result.ResponseBody.TransactionId)
+		assert.Equal(t, createdAt, result.RequestTime)
+		assert.Equal(t, map[string]string{
+			"live_status": "ACTIVE, REACHABLE",
+			"operator":    "Telkomsel",
+			"phone_type":  "mobile",
+		}, result.Data)
+	})
+
+	t.Run("should record rejected and errored details without data", func(t *testing.T) {
+		message := "invalid phone number"
+		failed := detail(constant.JobStatusFailed)
+		failed.Message = &message
+
+		result := toLogTrans(1, 4, 9, 2, failed)
+		assert.Equal(t, http.StatusBadRequest, result.Status)
+		assert.False(t, result.Success)
+		assert.Equal(t, message, result.Message)
+		assert.Nil(t, result.Data)
+
+		result = toLogTrans(1, 4, 9, 2, detail(constant.JobStatusError))
+		assert.Equal(t, http.StatusInternalServerError, result.Status)
+		assert.False(t, result.Success)
+	})
+}
+
+func TestJoinLiveStatus(t *testing.T) {
+	assert.Equal(t, "ACTIVE, REACHABLE", joinLiveStatus("ACTIVE", "REACHABLE"))
+	assert.Equal(t, "INACTIVE", joinLiveStatus("INACTIVE", ""))
+}
@@ -3,6 +3,9 @@ package phonelivestatus
 import (
 	"bytes"
 	"fmt"
+	"front-office/internal/core/masking"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/middleware"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
 	"front-office/pkg/helper"
@@ -22,52 +25,12 @@ type controller struct {
 }
 
 type Controller interface {
-	SingleSearch(c *fiber.Ctx) error
-	BulkSearch(c *fiber.Ctx) error
 	GetJobs(c *fiber.Ctx) error
 	GetJobDetails(c *fiber.Ctx) error
 	ExportJobDetails(c *fiber.Ctx) error
 	GetJobsSummary(c *fiber.Ctx) error
 	ExportJobsSummary(c *fiber.Ctx) error
-}
-
-func (ctrl *controller) SingleSearch(c *fiber.Ctx) error {
-	reqBody := c.Locals(constant.Request).(*phoneLiveStatusRequest)
-
-	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
-	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
-	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
-
-	err := ctrl.svc.PhoneLiveStatus(apiKey, memberId, companyId, reqBody)
-	if err != nil {
-		return err
-	}
-
-	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
-		"success",
-		nil,
-	))
-}
-
-func (ctrl *controller) BulkSearch(c *fiber.Ctx) error {
-	apiKey := fmt.Sprintf("%v", c.Locals(constant.APIKey))
-	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
-	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
-
-	file, err := c.FormFile("file")
-	if err != nil {
-		return apperror.BadRequest(err.Error())
-	}
-
-	err = ctrl.svc.BulkPhoneLiveStatus(apiKey, memberId, companyId, file)
-	if err != nil {
-		return err
-	}
-
-	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
-		"success",
-		nil,
-	))
+	StreamJobProgress(c *fiber.Ctx) error
 }
 
 func (ctrl *controller) GetJobs(c *fiber.Ctx) error {
@@ -87,6 +50,10 @@ func (ctrl *controller) GetJobs(c *fiber.Ctx) error {
 		return err
 	}
 
+	if middleware.IsDeprecated(c) {
+		jobs = toLegacyJobs(jobs)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
 		"succeeded to get phone live status jobs",
 		jobs,
@@ -98,45 +65,90 @@ func (ctrl *controller) GetJobDetails(c *fiber.Ctx) error {
 		Page:        c.Query(constant.Page, "1"),
 		Size:        c.Query(constant.Size, "10"),
 		Keyword:     c.Query(constant.Keyword),
-		JobId:       c.Params("id"),
+		SortBy:      c.Query(constant.SortBy),
+		SortOrder:   c.Query(constant.SortOrder),
+		RowNumber:   c.Query(constant.RowNumber),
 		ProductSlug: constant.SlugPhoneLiveStatus,
 		MemberId:    fmt.Sprintf("%v", c.Locals(constant.UserId)),
 		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
 		TierLevel:   fmt.Sprintf("%v", c.Locals(constant.RoleId)),
+		Masked:      masking.IsMasked(c),
 	}
 
-	if filter.JobId == "" {
+	if c.Params("id") == "" {
 		return apperror.BadRequest("missing job ID")
 	}
 
+	if err := helper.ValidateSortParams(filter.SortBy, filter.SortOrder, jobDetailSortFields); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := helper.ValidateRowNumber(filter.RowNumber); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	jobId, err := ctrl.jobId(c)
+	if err != nil {
+		return err
+	}
+	filter.JobId = jobId
+
 	jobDetail, err := ctrl.svc.GetJobDetails(filter)
 	if err != nil {
 		return err
 	}
 
+	var result any = jobDetail
+	if middleware.IsDeprecated(c) {
+		legacyJobId, _ := strconv.ParseUint(c.Params("id"), 10, 0)
+		result = toLegacyJobDetails(uint(legacyJobId), jobDetail)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
 		"succeeded to get phone live status job details",
-		jobDetail,
+		result,
 	))
 }
 
 func (ctrl *controller) ExportJobDetails(c *fiber.Ctx) error {
-	masked, _ := strconv.ParseBool(c.Query("masked"))
 	filter := &phoneLiveStatusFilter{
-		JobId:       c.Params("id"),
 		ProductSlug: constant.SlugPhoneLiveStatus,
 		StartDate:   c.Query(constant.StartDate, ""),
 		EndDate:     c.Query(constant.EndDate, ""),
 		MemberId:    fmt.Sprintf("%v", c.Locals(constant.UserId)),
 		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
 		TierLevel:   fmt.Sprintf("%v", c.Locals(constant.RoleId)),
+		SortBy:      c.Query(constant.SortBy, constant.RowNumber),
+		SortOrder:   c.Query(constant.SortOrder, constant.SortAsc),
 		Size:        constant.SizeUnlimited,
-		Masked:      masked,
+		Masked:      masking.IsMasked(c),
+		Legacy:      middleware.IsDeprecated(c),
+	}
+
+	if err := helper.ValidateSortParams(filter.SortBy, filter.SortOrder, jobDetailSortFields); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	jobId, err := ctrl.jobId(c)
+	if err != nil {
+		return err
+	}
+	filter.JobId = jobId
+
+	exportFn := ctrl.svc.ExportJobDetails
+	switch c.Query(constant.ExportMode, constant.ExportModeStandard) {
+	case constant.ExportModeStandard:
+	case constant.ExportModeEnrichment:
+		filter.SortBy = constant.RowNumber
+		filter.SortOrder = constant.SortAsc
+		exportFn = ctrl.svc.ExportEnrichedJobDetails
+	default:
+		return apperror.BadRequest(constant.InvalidExportMode)
 	}
 
 	var buf bytes.Buffer
 
-	filename, err := ctrl.svc.ExportJobDetails(filter, &buf)
+	filename, err := exportFn(filter, &buf)
 	if err != nil {
 		return err
 	}
@@ -156,6 +168,7 @@ func (ctrl *controller) GetJobsSummary(c *fiber.Ctx) error {
 		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
 		TierLevel:   fmt.Sprintf("%v", c.Locals(constant.RoleId)),
 		Size:        constant.SizeUnlimited,
+		Masked:      masking.IsMasked(c),
 	}
 
 	if filter.StartDate == "" || filter.EndDate == "" {
@@ -174,7 +187,6 @@ func (ctrl *controller) GetJobsSummary(c *fiber.Ctx) error {
 }
 
 func (ctrl *controller) ExportJobsSummary(c *fiber.Ctx) error {
-	masked, _ := strconv.ParseBool(c.Query("masked"))
 	filter := &phoneLiveStatusFilter{
 		ProductSlug: constant.SlugPhoneLiveStatus,
 		StartDate:   c.Query(constant.StartDate, ""),
@@ -183,7 +195,7 @@ func (ctrl *controller) ExportJobsSummary(c *fiber.Ctx) error {
 		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
 		TierLevel:   fmt.Sprintf("%v", c.Locals(constant.RoleId)),
 		Size:        constant.SizeUnlimited,
-		Masked:      masked,
+		Masked:      masking.IsMasked(c),
 	}
 
 	if filter.StartDate == "" || filter.EndDate == "" {
@@ -201,3 +213,36 @@ func (ctrl *controller) ExportJobsSummary(c *fiber.Ctx) error {
 
 	return c.SendStream(bytes.NewReader(buf.Bytes()))
 }
+
+var jobDetailSortFields = []string{constant.RowNumber, constant.CreatedAt}
+
+func (ctrl *controller) StreamJobProgress(c *fiber.Ctx) error {
+	if c.Params("id") == "" {
+		return apperror.BadRequest("missing job ID")
+	}
+
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	jobId, err := ctrl.jobId(c)
+	if err != nil {
+		return err
+	}
+
+	sub, err := ctrl.svc.SubscribeJobProgress(jobId, companyId)
+	if err != nil {
+		return err
+	}
+
+	return progress.Stream(c, sub, true)
+}
+
+// jobId returns the job id of the route. Requests forwarded from the legacy
+// routes name migrated jobs by their legacy id.
+func (ctrl *controller) jobId(c *fiber.Ctx) (string, error) {
+	jobId := c.Params("id")
+	if !middleware.IsDeprecated(c) {
+		return jobId, nil
+	}
+
+	return ctrl.svc.MigratedJobId(jobId, fmt.Sprintf("%v", c.Locals(constant.CompanyId)))
+}
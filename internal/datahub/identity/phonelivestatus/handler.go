@@ -0,0 +1,66 @@
+package phonelivestatus
+
+import (
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+	"strings"
+)
+
+func NewHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugPhoneLiveStatus,
+		RouteSlug:   "phone-live-status",
+		Group:       registry.GroupIdentity,
+		Name:        "phone live status",
+		TemplateDir: constant.PhoneLiveTemplates,
+		Columns:     []helper.CSVColumn{{Field: "phone_number", Header: "Phone Number"}},
+		NewRequest:  func() any { return &phoneLiveStatusRequest{} },
+		Call: registry.WrapCall(func(params *registry.CallParams, req *phoneLiveStatusRequest) (*model.ProCatAPIResponse[phoneLiveStatusRespData], error) {
+			return repo.PhoneLiveStatusAPI(params.APIKey, params.JobId, req)
+		}),
+		MapError: registry.MapPartnerError,
+		ExportColumns: []registry.ExportColumn{
+			{Header: "Phone Number", Source: registry.SourceInput, Field: "phone_number"},
+			{Header: "Subscriber Status", Source: registry.SourceData, Field: "live_status", Name: "subscriber_status", Format: liveStatusPart(0)},
+			{Header: "Device Status", Source: registry.SourceData, Field: "live_status", Name: "device_status", Format: liveStatusPart(1)},
+			{Header: "Operator", Source: registry.SourceData, Field: "operator", Fallback: detectedOperator},
+			{Header: "Phone Type", Source: registry.SourceData, Field: "phone_type", Fallback: detectedPhoneType},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		MaskedFields: []string{"phone_number"},
+	}
+}
+
+// detectedOperator derives the operator from the number's prefix for rows the
+// partner returned without one.
+func detectedOperator(input map[string]any) string {
+	phoneNumber, _ := input["phone_number"].(string)
+	operator, _ := identity.PhoneOperator(phoneNumber)
+
+	return operator
+}
+
+// detectedPhoneType derives the line type from the number's prefix for rows
+// the partner returned without one.
+func detectedPhoneType(input map[string]any) string {
+	phoneNumber, _ := input["phone_number"].(string)
+	_, phoneType := identity.PhoneOperator(phoneNumber)
+
+	return phoneType
+}
+
+// liveStatusPart picks one part of a "subscriber, device" live status.
+func liveStatusPart(index int) func(string) string {
+	return func(liveStatus string) string {
+		parts := strings.Split(liveStatus, ",")
+		if index >= len(parts) {
+			return ""
+		}
+
+		return strings.TrimSpace(parts[index])
+	}
+}
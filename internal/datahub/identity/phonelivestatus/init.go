@@ -2,10 +2,19 @@ package phonelivestatus
 
 import (
 	"front-office/configs/application"
+	"front-office/internal/core/audit"
+	"front-office/internal/core/log/operation"
 	"front-office/internal/core/log/transaction"
-	"front-office/internal/core/product"
+	"front-office/internal/core/masking"
+	"front-office/internal/core/member"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/role"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/decision"
 	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/registry"
 	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
 	"front-office/pkg/httpclient"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,19 +22,34 @@ import (
 
 func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
 	repository := NewRepository(cfg, client, nil)
-	productRepo := product.NewRepository(cfg, client)
 	jobRepo := job.NewRepository(cfg, client, nil)
 	transactionRepo := transaction.NewRepository(cfg, client, nil)
-	jobService := job.NewService(jobRepo, transactionRepo)
-	service := NewService(repository, productRepo, jobRepo, transactionRepo, jobService)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	webhookRepo := webhook.NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	quotaRepo := quota.NewRepository(cfg, client, nil)
+
+	decisionService := decision.NewService(decisionRepo)
+	webhookService := webhook.NewService(webhookRepo)
+	quotaService := quota.NewService(quotaRepo, memberRepo)
+	jobService := job.NewService(jobRepo, transactionRepo, decisionService, webhookService, quotaService)
+	service := NewService(repository, jobService)
 	controller := NewController(service)
+	auditService := audit.NewService(operation.NewRepository(cfg, client, nil), memberRepo)
+	resolveMasking := masking.Resolve(masking.NewService(masking.NewRepository(cfg, client, nil), role.NewRepository(cfg, client)))
+
+	registry.Register(NewHandler(repository))
+
+	// The routes of the retired legacy pipeline are answered by this package
+	// and the gateway, which serve the same paths under phone-live-status.
+	// Legacy job ids and response shapes are kept, see legacy.go.
+	apiGroup.Group("old-phone-live-status").Use(middleware.ForwardDeprecated("/old-phone-live-status", "/phone-live-status"))
 
 	phoneLiveStatusGroup := apiGroup.Group("phone-live-status")
-	phoneLiveStatusGroup.Post("/single-request", middleware.Auth(), middleware.IsRequestValid(phoneLiveStatusRequest{}), middleware.GetJWTPayloadFromCookie(), controller.SingleSearch)
-	phoneLiveStatusGroup.Post("/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.BulkSearch)
 	phoneLiveStatusGroup.Get("/jobs", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJobs)
-	phoneLiveStatusGroup.Get("/jobs/:id/details", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJobDetails)
-	phoneLiveStatusGroup.Get("/jobs/:id/details/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ExportJobDetails)
-	phoneLiveStatusGroup.Get("/jobs-summary", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJobsSummary)
-	phoneLiveStatusGroup.Get("/jobs-summary/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ExportJobsSummary)
+	phoneLiveStatusGroup.Get("/jobs/:id/details", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, constant.SlugPhoneLiveStatus), controller.GetJobDetails)
+	phoneLiveStatusGroup.Get("/jobs/:id/details/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, constant.SlugPhoneLiveStatus), controller.ExportJobDetails)
+	phoneLiveStatusGroup.Get("/jobs/:id/stream", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.StreamJobProgress)
+	phoneLiveStatusGroup.Get("/jobs-summary", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, constant.SlugPhoneLiveStatus), controller.GetJobsSummary)
+	phoneLiveStatusGroup.Get("/jobs-summary/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, constant.SlugPhoneLiveStatus), controller.ExportJobsSummary)
 }
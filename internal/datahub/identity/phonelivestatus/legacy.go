@@ -0,0 +1,98 @@
+package phonelivestatus
+
+import "time"
+
+// The routes of the retired legacy pipeline are forwarded here. Their clients
+// keep the job ids and response shapes they had: migrated jobs are listed and
+// looked up by their legacy id, and job details are returned as the legacy
+// pipeline returned them. The job list and summary are shaped alike already.
+
+// legacyJobDetail is a job detail as the legacy pipeline returned it. Id is
+// the transaction log of the detail and Sequence its position in the job.
+type legacyJobDetail struct {
+	Id               uint      `json:"id"`
+	MemberId         uint      `json:"member_id"`
+	CompanyId        uint      `json:"company_id"`
+	JobId            uint      `json:"job_id"`
+	PhoneNumber      string    `json:"phone_number"`
+	InProgress       bool      `json:"in_progess"`
+	Sequence         int       `json:"sequence"`
+	Status           string    `json:"status"`
+	Message          *string   `json:"message"`
+	SubscriberStatus string    `json:"subscriber_status"`
+	DeviceStatus     string    `json:"device_status"`
+	PhoneType        string    `json:"phone_type"`
+	Operator         string    `json:"operator"`
+	PricingStrategy  string    `json:"pricing_strategy"`
+	TransactionId    string    `json:"transaction_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type legacyJobDetailsRespData struct {
+	TotalData                  int64              `json:"total_data"`
+	TotalDataPercentageSuccess int64              `json:"total_data_percentage_success"`
+	TotalDataPercentageFail    int64              `json:"total_data_percentage_fail"`
+	TotalDataPercentageError   int64              `json:"total_data_percentage_error"`
+	SubsActive                 int64              `json:"subs_active"`
+	DevReachable               int64              `json:"dev_reachable"`
+	DevUnreachable             int64              `json:"dev_unreachable"`
+	DevUnavailable             int64              `json:"dev_unavailable"`
+	JobDetails                 []*legacyJobDetail `json:"job_details"`
+}
+
+// toLegacyJobs names the migrated jobs of a job list by their legacy id.
+func toLegacyJobs(jobs *jobListRespData) *jobListRespData {
+	for _, j := range jobs.Jobs {
+		if j.LegacyJobId != 0 {
+			j.Id = j.LegacyJobId
+		}
+	}
+
+	return jobs
+}
+
+// toLegacyJobDetails returns job details in the shape of the legacy pipeline.
+// jobId is the id the client asked for, which is the legacy id of a migrated
+// job.
+func toLegacyJobDetails(jobId uint, details *jobDetailsDTO) *legacyJobDetailsRespData {
+	result := &legacyJobDetailsRespData{
+		TotalData:                  details.TotalData,
+		TotalDataPercentageSuccess: details.TotalDataPercentageSuccess,
+		TotalDataPercentageFail:    details.TotalDataPercentageFail,
+		TotalDataPercentageError:   details.TotalDataPercentageError,
+		SubsActive:                 details.SubsActive,
+		DevReachable:               details.DevReachable,
+		DevUnreachable:             details.DevUnreachable,
+		DevUnavailable:             details.DevUnavailable,
+		JobDetails:                 make([]*legacyJobDetail, 0, len(details.JobDetails)),
+	}
+
+	for _, d := range details.JobDetails {
+		// rows were numbered like CSV lines, header first, by the migration
+		sequence := 0
+		if d.RowNumber > 1 {
+			sequence = d.RowNumber - 1
+		}
+
+		result.JobDetails = append(result.JobDetails, &legacyJobDetail{
+			Id:               d.Id,
+			MemberId:         d.MemberId,
+			CompanyId:        d.CompanyId,
+			JobId:            jobId,
+			PhoneNumber:      d.PhoneNumber,
+			InProgress:       d.InProgress,
+			Sequence:         sequence,
+			Status:           d.Status,
+			Message:          d.Message,
+			SubscriberStatus: d.SubscriberStatus,
+			DeviceStatus:     d.DeviceStatus,
+			PhoneType:        d.PhoneType,
+			Operator:         d.Operator,
+			PricingStrategy:  d.PricingStrategy,
+			TransactionId:    d.TransactionId,
+			CreatedAt:        d.CreatedAt,
+		})
+	}
+
+	return result
+}
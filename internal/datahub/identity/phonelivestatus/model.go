@@ -3,6 +3,7 @@ package phonelivestatus
 import (
 	"front-office/internal/core/company"
 	"front-office/internal/core/member"
+	"front-office/pkg/utility/identity"
 	"time"
 )
 
@@ -17,9 +18,11 @@ type mstPhoneLiveStatusJob struct {
 	Company      company.MstCompany `json:"-"`
 	CreatedAt    string             `json:"start_time"`
 	EndAt        string             `json:"end_time"`
+	LegacyJobId  uint               `json:"legacy_job_id,omitempty"`
 }
 
 type mstPhoneLiveStatusJobDetail struct {
+	Id               uint      `json:"id"`
 	MemberId         uint      `json:"member_id"`
 	CompanyId        uint      `json:"company_id"`
 	JobId            uint      `json:"job_id"`
@@ -33,6 +36,8 @@ type mstPhoneLiveStatusJobDetail struct {
 	Operator         string    `json:"operator"`
 	PricingStrategy  string    `json:"pricing_strategy"`
 	TransactionId    string    `json:"transaction_id"`
+	RowNumber        int       `json:"row_number"`
+	FileName         string    `json:"file_name"`
 	CreatedAt        time.Time `json:"created_at"`
 	RefLogTrx        RefLogTrx `json:"ref_log_trx"`
 }
@@ -42,10 +47,23 @@ type RefLogTrx struct {
 }
 
 type phoneLiveStatusRequest struct {
-	PhoneNumber string `json:"phone_number" validate:"required~phone number is required, min(10)~phone number must be at least 10 characters, indophone~invalid number"`
+	PhoneNumber string `json:"phone_number" validate:"required~phone number is required"`
 	TrxId       string `json:"trx_id"`
 }
 
+// Normalize brings the phone number to canonical form. Mobile and fixed-line
+// numbers are accepted as long as an operator is assigned their prefix.
+func (r *phoneLiveStatusRequest) Normalize() error {
+	line, err := identity.DetectPhone(r.PhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	r.PhoneNumber = line.Number
+
+	return nil
+}
+
 type phoneLiveStatusRespData struct {
 	LiveStatus string      `json:"live_status"`
 	PhoneType  string      `json:"phone_type"`
@@ -70,7 +88,12 @@ type phoneLiveStatusFilter struct {
 	CompanyId   string
 	TierLevel   string
 	Keyword     string
+	SortBy      string
+	SortOrder   string
+	RowNumber   string
 	Masked      bool
+	// Legacy is set on requests forwarded from the legacy routes.
+	Legacy bool
 }
 
 type jobListRespData struct {
@@ -124,6 +147,7 @@ type jobMetrics struct {
 }
 
 type logTransProductCatalog struct {
+	ID                     uint                   `json:"id"`
 	MemberID               uint                   `json:"member_id"`
 	CompanyID              uint                   `json:"company_id"`
 	JobID                  uint                   `json:"job_id"`
@@ -134,6 +158,8 @@ type logTransProductCatalog struct {
 	Data                   *logTransData          `json:"data"`
 	PricingStrategy        string                 `json:"pricing_strategy"`
 	TransactionId          string                 `json:"transaction_id"`
+	RowNumber              int                    `json:"row_number"`
+	FileName               string                 `json:"file_name"`
 	DateTime               string                 `json:"datetime"`
 	RefTransProductCatalog RefTransProductCatalog `json:"ref_trans_product_catalog"`
 }
@@ -151,14 +177,3 @@ type logTransData struct {
 type logTransInput struct {
 	PhoneNumber string `json:"phone_number,omitempty"`
 }
-
-type phoneLiveStatusContext struct {
-	APIKey         string                  `json:"api_key"`
-	JobIdStr       string                  `json:"job_id_str"`
-	MemberId       uint                    `json:"member_id"`
-	CompanyId      uint                    `json:"company_id"`
-	ProductId      uint                    `json:"product_id"`
-	ProductGroupId uint                    `json:"product_group_id"`
-	JobId          uint                    `json:"job_id"`
-	Request        *phoneLiveStatusRequest `json:"request"`
-}
@@ -127,6 +127,9 @@ func (repo *repository) GetJobDetailsAPI(filter *phoneLiveStatusFilter) (*jobDet
 	q.Add(constant.Page, filter.Page)
 	q.Add(constant.Size, filter.Size)
 	q.Add(constant.Keyword, filter.Keyword)
+	q.Add(constant.SortBy, filter.SortBy)
+	q.Add(constant.SortOrder, filter.SortOrder)
+	q.Add(constant.RowNumber, filter.RowNumber)
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := repo.client.Do(req)
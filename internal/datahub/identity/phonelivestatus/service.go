@@ -3,245 +3,42 @@ package phonelivestatus
 import (
 	"bytes"
 	"encoding/csv"
-	"errors"
 	"fmt"
-	"front-office/internal/core/log/transaction"
-	"front-office/internal/core/product"
 	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
 	"front-office/pkg/helper"
-	"mime/multipart"
-	"net/http"
+	"front-office/pkg/utility/identity"
+	"front-office/pkg/utility/mask"
+	"path/filepath"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
-
-	"github.com/rs/zerolog/log"
-	"github.com/usepzaka/validator"
 )
 
-func NewService(
-	repo Repository,
-	productRepo product.Repository,
-	jobRepo job.Repository,
-	transactionRepo transaction.Repository,
-	jobService job.Service,
-) Service {
+func NewService(repo Repository, jobService job.Service) Service {
 	return &service{
 		repo,
-		productRepo,
-		jobRepo,
-		transactionRepo,
 		jobService,
 	}
 }
 
 type service struct {
-	repo            Repository
-	productRepo     product.Repository
-	jobRepo         job.Repository
-	transactionRepo transaction.Repository
-	jobService      job.Service
+	repo       Repository
+	jobService job.Service
 }
 
 type Service interface {
-	PhoneLiveStatus(apiKey, memberId, companyId string, reqBody *phoneLiveStatusRequest) error
-	BulkPhoneLiveStatus(apiKey, memberId, companyId string, fileHeader *multipart.FileHeader) error
 	GetJobs(filter *phoneLiveStatusFilter) (*jobListRespData, error)
 	GetJobDetails(filter *phoneLiveStatusFilter) (*jobDetailsDTO, error)
 	ExportJobDetails(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error)
+	ExportEnrichedJobDetails(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error)
 	GetJobsSummary(filter *phoneLiveStatusFilter) (*jobsSummaryDTO, error)
 	ExportJobsSummary(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error)
-}
-
-func (svc *service) PhoneLiveStatus(apiKey, memberId, companyId string, reqBody *phoneLiveStatusRequest) error {
-	product, err := svc.productRepo.GetProductAPI(constant.SlugPhoneLiveStatus)
-	if err != nil {
-		return apperror.MapRepoError(err, constant.FailedFetchProduct)
-	}
-	if product.ProductId == 0 {
-		return apperror.NotFound(constant.ProductNotFound)
-	}
-
-	jobRes, err := svc.jobRepo.CreateJobAPI(&job.CreateJobRequest{
-		ProductId: product.ProductId,
-		MemberId:  memberId,
-		CompanyId: companyId,
-		Total:     1,
-	})
-	if err != nil {
-		return apperror.MapRepoError(err, constant.FailedCreateJob)
-	}
-	jobIdStr := helper.ConvertUintToString(jobRes.JobId)
-
-	result, err := svc.repo.PhoneLiveStatusAPI(apiKey, jobIdStr, reqBody)
-	if err != nil {
-		if err := svc.jobService.FinalizeFailedJob(jobIdStr); err != nil {
-			return err
-		}
-
-		var apiErr *apperror.ExternalAPIError
-		if errors.As(err, &apiErr) {
-			return apperror.MapLoanError(apiErr)
-		}
-
-		return apperror.Internal("failed to process phone live status", err)
-	}
-
-	if err := svc.transactionRepo.UpdateLogTransAPI(result.TransactionId, map[string]interface{}{
-		"success": helper.BoolPtr(true),
-	}); err != nil {
-		return apperror.MapRepoError(err, "failed to update transaction log")
-	}
-
-	return svc.jobService.FinalizeJob(jobIdStr)
-}
-
-func (svc *service) BulkPhoneLiveStatus(apiKey, memberId, companyId string, file *multipart.FileHeader) error {
-	product, err := svc.productRepo.GetProductAPI(constant.SlugPhoneLiveStatus)
-	if err != nil {
-		return apperror.MapRepoError(err, constant.FailedFetchProduct)
-	}
-	if product.ProductId == 0 {
-		return apperror.NotFound(constant.ProductNotFound)
-	}
-
-	if err := helper.ValidateUploadedFile(file, 30*1024*1024, []string{".csv"}); err != nil {
-		return apperror.BadRequest(err.Error())
-	}
-
-	records, err := helper.ParseCSVFile(file, []string{"Phone Number"})
-	if err != nil {
-		return apperror.Internal(constant.FailedParseCSV, err)
-	}
-
-	jobRes, err := svc.jobRepo.CreateJobAPI(&job.CreateJobRequest{
-		ProductId: product.ProductId,
-		MemberId:  memberId,
-		CompanyId: companyId,
-		Total:     len(records) - 1,
-	})
-	if err != nil {
-		return apperror.MapRepoError(err, constant.FailedCreateJob)
-	}
-	jobIdStr := helper.ConvertUintToString(jobRes.JobId)
-
-	var phoneReqs []*phoneLiveStatusRequest
-	for i := 1; i < len(records); i++ { // Skip header
-		phoneReqs = append(phoneReqs, &phoneLiveStatusRequest{
-			PhoneNumber: records[i][0],
-		})
-	}
-
-	var (
-		wg         sync.WaitGroup
-		errChan    = make(chan error, len(phoneReqs))
-		batchCount = 0
-	)
-
-	for _, req := range phoneReqs {
-		wg.Add(1)
-
-		go func(phoneLiveReq *phoneLiveStatusRequest) {
-			defer wg.Done()
-			if err := svc.processSingle(&phoneLiveStatusContext{
-				APIKey:         apiKey,
-				JobIdStr:       jobIdStr,
-				MemberId:       jobRes.MemberId,
-				CompanyId:      jobRes.CompanyId,
-				ProductId:      product.ProductId,
-				ProductGroupId: product.ProductGroupId,
-				JobId:          jobRes.JobId,
-				Request:        phoneLiveReq,
-			}); err != nil {
-				errChan <- err
-			}
-		}(req)
-
-		batchCount++
-		if batchCount == 100 {
-			time.Sleep(time.Second)
-			batchCount = 0
-		}
-	}
-
-	wg.Wait()
-	close(errChan)
-
-	for err := range errChan {
-		log.Error().Err(err).Msg("error during bulk phone live status processing")
-	}
-
-	return svc.jobService.FinalizeJob(jobIdStr)
-}
-
-func (svc *service) processSingle(params *phoneLiveStatusContext) error {
-	if err := validator.ValidateStruct(params.Request); err != nil {
-		_ = svc.transactionRepo.CreateLogTransAPI(&transaction.LogTransProCatRequest{
-			MemberID:       params.MemberId,
-			CompanyID:      params.CompanyId,
-			ProductID:      params.ProductId,
-			ProductGroupID: params.ProductGroupId,
-			JobID:          params.JobId,
-			Message:        err.Error(),
-			Status:         http.StatusBadRequest,
-			Success:        false,
-			ResponseBody: &transaction.ResponseBody{
-				Input:    params.Request,
-				DateTime: time.Now().Format(constant.FormatDateAndTime),
-			},
-			Data:         nil,
-			RequestBody:  params.Request,
-			RequestTime:  time.Now(),
-			ResponseTime: time.Now(),
-		})
-
-		return apperror.BadRequest(err.Error())
-	}
-
-	result, err := svc.repo.PhoneLiveStatusAPI(params.APIKey, params.JobIdStr, params.Request)
-	if err != nil {
-		if err := svc.transactionRepo.CreateLogTransAPI(&transaction.LogTransProCatRequest{
-			MemberID:       params.MemberId,
-			CompanyID:      params.CompanyId,
-			ProductID:      params.ProductId,
-			ProductGroupID: params.ProductGroupId,
-			JobID:          params.JobId,
-			Message:        result.Message,
-			Status:         result.StatusCode,
-			Success:        false,
-			ResponseBody: &transaction.ResponseBody{
-				Input:    params.Request,
-				DateTime: time.Now().Format(constant.FormatDateAndTime),
-			},
-			Data:         nil,
-			RequestBody:  params.Request,
-			RequestTime:  time.Now(),
-			ResponseTime: time.Now(),
-		}); err != nil {
-			return err
-		}
-
-		if err := svc.jobService.FinalizeFailedJob(params.JobIdStr); err != nil {
-			return err
-		}
-
-		var apiErr *apperror.ExternalAPIError
-		if errors.As(err, &apiErr) {
-			return apperror.MapLoanError(apiErr)
-		}
-
-		return apperror.Internal("failed to process loan record checker", err)
-	}
-
-	if err := svc.transactionRepo.UpdateLogTransAPI(result.TransactionId, map[string]interface{}{
-		"success": helper.BoolPtr(true),
-	}); err != nil {
-		return apperror.MapRepoError(err, "failed to update log transaction")
-	}
-
-	return nil
+	SubscribeJobProgress(jobId, companyId string) (*progress.Subscription, error)
+	MigratedJobId(legacyJobId, companyId string) (string, error)
 }
 
 func (svc *service) GetJobs(filter *phoneLiveStatusFilter) (*jobListRespData, error) {
@@ -312,7 +109,7 @@ func (svc *service) ExportJobDetails(filter *phoneLiveStatusFilter, buf *bytes.B
 		mappedDetails = append(mappedDetails, mapped)
 	}
 
-	if err := writeJobDetailsToCSV(buf, mappedDetails); err != nil {
+	if err := writeJobDetailsToCSV(buf, mappedDetails, !filter.Legacy); err != nil {
 		return "", apperror.Internal("failed to write CSV", err)
 	}
 
@@ -321,6 +118,45 @@ func (svc *service) ExportJobDetails(filter *phoneLiveStatusFilter, buf *bytes.B
 	return filename, nil
 }
 
+func (svc *service) ExportEnrichedJobDetails(filter *phoneLiveStatusFilter, buf *bytes.Buffer) (string, error) {
+	source, err := svc.jobService.GetJobSource(filter.JobId, filter.MemberId, filter.CompanyId, filter.TierLevel)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := svc.repo.GetJobDetailsAPI(filter)
+	if err != nil {
+		return "", apperror.MapRepoError(err, constant.ErrFetchPhoneLiveDetail)
+	}
+
+	results := make(map[int][]string, len(data.JobDetails))
+	for _, raw := range data.JobDetails {
+		mapped, err := mapToJobDetail(filter.Masked, raw)
+		if err != nil || mapped.RowNumber == 0 {
+			continue
+		}
+
+		results[mapped.RowNumber] = jobDetailResultRow(mapped)
+	}
+
+	records := source.Records
+	if filter.Masked {
+		handler, _ := registry.Get(constant.SlugPhoneLiveStatus)
+		records = source.MaskedRecords(handler)
+	}
+
+	if err := helper.WriteEnrichedCSV(buf, records, jobDetailResultHeaders, results); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(source.FileName), filepath.Ext(source.FileName))
+	if source.FileName == "" {
+		base = "job_detail_id_" + filter.JobId
+	}
+
+	return base + "_enriched.csv", nil
+}
+
 func (svc *service) GetJobsSummary(filter *phoneLiveStatusFilter) (*jobsSummaryDTO, error) {
 	data, err := svc.repo.GetJobsSummaryAPI(filter)
 	if err != nil {
@@ -368,7 +204,7 @@ func (svc *service) ExportJobsSummary(filter *phoneLiveStatusFilter, buf *bytes.
 		mappedDetails = append(mappedDetails, mapped)
 	}
 
-	if err := writeJobDetailsToCSV(buf, mappedDetails); err != nil {
+	if err := writeJobDetailsToCSV(buf, mappedDetails, false); err != nil {
 		return "", apperror.Internal("failed to write CSV", err)
 	}
 
@@ -392,18 +228,28 @@ func mapToJobDetail(masked bool, raw *logTransProductCatalog) (*mstPhoneLiveStat
 		phoneType = raw.Data.PhoneType
 	}
 
+	if operator == "" || phoneType == "" {
+		detectedOperator, detectedType := identity.PhoneOperator(raw.Input.PhoneNumber)
+		if operator == "" {
+			operator = detectedOperator
+		}
+		if phoneType == "" {
+			phoneType = detectedType
+		}
+	}
+
 	createdAt, err := time.Parse("2006-01-02 15:04:05", raw.DateTime)
 	if err != nil {
 		return nil, fmt.Errorf("invalid datetime format: %v", err)
 	}
 
+	phoneNumber = raw.Input.PhoneNumber
 	if masked {
-		phoneNumber = raw.RefTransProductCatalog.Input.PhoneNumber
-	} else {
-		phoneNumber = raw.Input.PhoneNumber
+		phoneNumber = mask.Phone(phoneNumber)
 	}
 
 	return &mstPhoneLiveStatusJobDetail{
+		Id:               raw.ID,
 		MemberId:         raw.MemberID,
 		CompanyId:        raw.CompanyID,
 		JobId:            raw.JobID,
@@ -416,6 +262,8 @@ func mapToJobDetail(masked bool, raw *logTransProductCatalog) (*mstPhoneLiveStat
 		Operator:         operator,
 		PricingStrategy:  raw.PricingStrategy,
 		TransactionId:    raw.TransactionId,
+		RowNumber:        raw.RowNumber,
+		FileName:         raw.FileName,
 		CreatedAt:        createdAt,
 		RefLogTrx: RefLogTrx{
 			PhoneNumber: raw.RefTransProductCatalog.Input.PhoneNumber,
@@ -423,21 +271,22 @@ func mapToJobDetail(masked bool, raw *logTransProductCatalog) (*mstPhoneLiveStat
 	}, nil
 }
 
-func writeJobDetailsToCSV(buf *bytes.Buffer, data []*mstPhoneLiveStatusJobDetail) error {
+func writeJobDetailsToCSV(buf *bytes.Buffer, data []*mstPhoneLiveStatusJobDetail, includeRowNumber bool) error {
 	w := csv.NewWriter(buf)
-	headers := []string{"Phone Number", "Subscriber Status", "Device Status", "Operator", "Phone Type", "Status", "Description"}
+	headers := append([]string{"Phone Number"}, jobDetailResultHeaders...)
+	if includeRowNumber {
+		headers = append([]string{"Row Number"}, headers...)
+	}
 
 	if err := w.Write(headers); err != nil {
 		return err
 	}
 
 	for _, d := range data {
-		desc := ""
-		if d.Message != nil {
-			desc = *d.Message
+		row := append([]string{d.PhoneNumber}, jobDetailResultRow(d)...)
+		if includeRowNumber {
+			row = append([]string{formatRowNumber(d.RowNumber)}, row...)
 		}
-
-		row := []string{d.PhoneNumber, d.SubscriberStatus, d.DeviceStatus, d.Operator, d.PhoneType, d.Status, desc}
 		if err := w.Write(row); err != nil {
 			return err
 		}
@@ -447,9 +296,38 @@ func writeJobDetailsToCSV(buf *bytes.Buffer, data []*mstPhoneLiveStatusJobDetail
 	return w.Error()
 }
 
+// jobDetailResultHeaders are the export columns produced by the check itself,
+// as opposed to the phone number echoed from the request.
+var jobDetailResultHeaders = []string{"Subscriber Status", "Device Status", "Operator", "Phone Type", "Status", "Description"}
+
+func jobDetailResultRow(d *mstPhoneLiveStatusJobDetail) []string {
+	desc := ""
+	if d.Message != nil {
+		desc = *d.Message
+	}
+
+	return []string{d.SubscriberStatus, d.DeviceStatus, d.Operator, d.PhoneType, d.Status, desc}
+}
+
 func formatCSVFileName(base, startDate, endDate string) string {
 	if endDate != "" && endDate != startDate {
 		return fmt.Sprintf("%s_%s_until_%s.csv", base, startDate, endDate)
 	}
 	return fmt.Sprintf("%s_%s.csv", base, startDate)
 }
+
+func formatRowNumber(rowNumber int) string {
+	if rowNumber == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(rowNumber)
+}
+
+func (svc *service) MigratedJobId(legacyJobId, companyId string) (string, error) {
+	return svc.jobService.MigratedJobId(constant.SlugPhoneLiveStatus, legacyJobId, companyId)
+}
+
+func (svc *service) SubscribeJobProgress(jobId, companyId string) (*progress.Subscription, error) {
+	return svc.jobService.SubscribeJobProgress(jobId, companyId)
+}
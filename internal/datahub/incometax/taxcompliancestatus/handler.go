@@ -0,0 +1,33 @@
+package taxcompliancestatus
+
+import (
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+)
+
+func NewHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugTaxComplianceStatus,
+		RouteSlug:   "tax-compliance-status",
+		Group:       registry.GroupIncomeTax,
+		Name:        "tax compliance status",
+		TemplateDir: constant.TaxComplianceStatusTemplates,
+		Columns:     []helper.CSVColumn{{Field: "npwp", Header: "NPWP"}},
+		NewRequest:  func() any { return &taxComplianceStatusRequest{} },
+		Call: registry.WrapCall(func(params *registry.CallParams, req *taxComplianceStatusRequest) (*model.ProCatAPIResponse[taxComplianceRespData], error) {
+			return repo.TaxComplianceStatusAPI(params.APIKey, params.JobId, req)
+		}),
+		ExportColumns: []registry.ExportColumn{
+			{Header: "NPWP", Source: registry.SourceInput, Field: "npwp"},
+			{Header: "Nama", Source: registry.SourceData, Field: "nama"},
+			{Header: "Alamat", Source: registry.SourceData, Field: "alamat"},
+			{Header: "Data Status", Source: registry.SourceData, Field: "status"},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		Distribution: registry.FieldDistribution("status"),
+		MaskedFields: []string{"npwp", "nama"},
+	}
+}
@@ -2,27 +2,12 @@ package taxcompliancestatus
 
 import (
 	"front-office/configs/application"
-	"front-office/internal/core/log/transaction"
-	"front-office/internal/core/product"
-	"front-office/internal/datahub/job"
-	"front-office/internal/middleware"
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/httpclient"
-
-	"github.com/gofiber/fiber/v2"
 )
 
-func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+func SetupInit(cfg *application.Config, client httpclient.HTTPClient) {
 	repo := NewRepository(cfg, client, nil)
-	productRepo := product.NewRepository(cfg, client)
-	jobRepo := job.NewRepository(cfg, client, nil)
-	transactionRepo := transaction.NewRepository(cfg, client, nil)
-
-	jobService := job.NewService(jobRepo, transactionRepo)
-	service := NewService(repo, productRepo, jobRepo, transactionRepo, jobService)
-
-	controller := NewController(service)
 
-	taxComplianceGroup := apiGroup.Group("tax-compliance-status")
-	taxComplianceGroup.Post("/single-request", middleware.Auth(), middleware.IsRequestValid(taxComplianceStatusRequest{}), middleware.GetJWTPayloadFromCookie(), controller.SingleSearch)
-	taxComplianceGroup.Post("/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.BulkSearch)
+	registry.Register(NewHandler(repo))
 }
@@ -0,0 +1,51 @@
+package taxscore
+
+import (
+	"fmt"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"strconv"
+)
+
+func NewHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugTaxScore,
+		RouteSlug:   "tax-score",
+		Group:       registry.GroupIncomeTax,
+		Name:        "tax score",
+		TemplateDir: constant.TaxScoreTemplates,
+		Columns:     []helper.CSVColumn{{Field: "npwp", Header: "NPWP"}},
+		NewRequest:  func() any { return &taxScoreRequest{} },
+		Call: registry.WrapCall(func(params *registry.CallParams, req *taxScoreRequest) (*model.ProCatAPIResponse[taxScoreRespData], error) {
+			return repo.TaxScoreAPI(params.APIKey, params.JobId, req)
+		}),
+		ExportColumns: []registry.ExportColumn{
+			{Header: "NPWP", Source: registry.SourceInput, Field: "npwp"},
+			{Header: "Nama", Source: registry.SourceData, Field: "nama"},
+			{Header: "Alamat", Source: registry.SourceData, Field: "alamat"},
+			{Header: "Data Status", Source: registry.SourceData, Field: "status"},
+			{Header: "Score", Source: registry.SourceData, Field: "score"},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		Distribution: scoreBucket,
+		MaskedFields: []string{"npwp", "nama"},
+	}
+}
+
+// scoreBucket buckets numeric scores by tens, e.g. "60-69". Other scores are
+// bucketed by their value.
+func scoreBucket(data map[string]any) string {
+	score, _ := data["score"].(string)
+
+	n, err := strconv.Atoi(score)
+	if err != nil || n < 0 {
+		return score
+	}
+
+	low := n / 10 * 10
+
+	return fmt.Sprintf("%d-%d", low, low+9)
+}
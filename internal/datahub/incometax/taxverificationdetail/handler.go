@@ -0,0 +1,34 @@
+package taxverificationdetail
+
+import (
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+)
+
+func NewHandler(repo Repository) *registry.ProductHandler {
+	return &registry.ProductHandler{
+		Slug:        constant.SlugTaxVerificationDetail,
+		RouteSlug:   "tax-verification-detail",
+		Group:       registry.GroupIncomeTax,
+		Name:        "tax verification detail",
+		TemplateDir: constant.TaxVerificationTemplates,
+		Columns:     []helper.CSVColumn{{Field: "npwp_or_nik", Header: "ID Card Number"}},
+		NewRequest:  func() any { return &taxVerificationRequest{} },
+		Call: registry.WrapCall(func(params *registry.CallParams, req *taxVerificationRequest) (*model.ProCatAPIResponse[taxVerificationRespData], error) {
+			return repo.TaxVerificationAPI(params.APIKey, params.JobId, req)
+		}),
+		ExportColumns: []registry.ExportColumn{
+			{Header: "Nama", Source: registry.SourceData, Field: "nama"},
+			{Header: "Alamat", Source: registry.SourceData, Field: "alamat"},
+			{Header: "NPWP", Source: registry.SourceInput, Field: "npwp_or_nik"},
+			{Header: "NPWP Verification", Source: registry.SourceData, Field: "npwp_verification"},
+			{Header: "Data Status", Source: registry.SourceData, Field: "status"},
+			{Header: "Tax Compliance", Source: registry.SourceData, Field: "tax_compliance"},
+			{Header: "Status", Source: registry.SourceStatus},
+			{Header: "Description", Source: registry.SourceMessage},
+		},
+		MaskedFields: []string{"npwp_or_nik", "nama"},
+	}
+}
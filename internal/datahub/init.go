@@ -2,14 +2,22 @@ package datahub
 
 import (
 	"front-office/configs/application"
+	"front-office/internal/datahub/bundle"
+	"front-office/internal/datahub/columnmapping"
 	"front-office/internal/datahub/compliance/loanrecordchecker"
 	"front-office/internal/datahub/compliance/multipleloan"
-	"front-office/internal/datahub/identity/oldphonelivestatus"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/identity/nikdecoder"
 	"front-office/internal/datahub/identity/phonelivestatus"
 	"front-office/internal/datahub/incometax/taxcompliancestatus"
 	"front-office/internal/datahub/incometax/taxscore"
 	"front-office/internal/datahub/incometax/taxverificationdetail"
 	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/datahub/registry"
+	"front-office/internal/datahub/schedule"
+	"front-office/internal/datahub/watchlist"
 	"front-office/pkg/httpclient"
 
 	"time"
@@ -21,17 +29,39 @@ func SetupInit(routeAPI fiber.Router, cfg *application.Config) {
 	client := httpclient.NewDefaultClient(10 * time.Second)
 
 	complianceGroupAPI := routeAPI.Group("compliance")
-	loanrecordchecker.SetupInit(complianceGroupAPI, cfg, client)
-	multipleloan.SetupInit(complianceGroupAPI, cfg, client)
+	loanrecordchecker.SetupInit(cfg, client)
+	multipleloan.SetupInit(cfg, client)
+	gateway.SetupInit(complianceGroupAPI, cfg, client, registry.GroupCompliance)
 	job.SetupInit(complianceGroupAPI, cfg, client)
 
 	incomeTaxGroupAPI := routeAPI.Group("incometax")
-	taxcompliancestatus.SetupInit(incomeTaxGroupAPI, cfg, client)
-	taxscore.SetupInit(incomeTaxGroupAPI, cfg, client)
-	taxverificationdetail.SetupInit(incomeTaxGroupAPI, cfg, client)
+	taxcompliancestatus.SetupInit(cfg, client)
+	taxscore.SetupInit(cfg, client)
+	taxverificationdetail.SetupInit(cfg, client)
+	gateway.SetupInit(incomeTaxGroupAPI, cfg, client, registry.GroupIncomeTax)
 	job.SetupInit(incomeTaxGroupAPI, cfg, client)
 
 	identityGroupAPI := routeAPI.Group("identity")
 	phonelivestatus.SetupInit(identityGroupAPI, cfg, client)
-	oldphonelivestatus.SetupInit(identityGroupAPI, cfg, client)
+	nikdecoder.SetupInit(identityGroupAPI)
+	gateway.SetupInit(identityGroupAPI, cfg, client, registry.GroupIdentity)
+
+	bundleGroupAPI := routeAPI.Group("bundles")
+	bundle.SetupInit(bundleGroupAPI, cfg, client)
+
+	decisionGroupAPI := routeAPI.Group("decision-rules")
+	decision.SetupInit(decisionGroupAPI, cfg, client)
+
+	columnMappingGroupAPI := routeAPI.Group("column-mappings")
+	columnmapping.SetupInit(columnMappingGroupAPI, cfg, client)
+
+	scheduleGroupAPI := routeAPI.Group("schedules")
+	schedule.SetupInit(scheduleGroupAPI, cfg, client)
+
+	watchlistGroupAPI := routeAPI.Group("watchlist")
+	watchlist.SetupInit(watchlistGroupAPI, cfg, client)
+
+	jobsGroupAPI := routeAPI.Group("jobs")
+	progress.SetupInit(jobsGroupAPI)
+	job.SetupCompanyInit(jobsGroupAPI, cfg, client)
 }
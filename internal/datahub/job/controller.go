@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"front-office/internal/core/masking"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,10 +26,15 @@ type controller struct {
 
 type Controller interface {
 	GetJob(c *fiber.Ctx) error
+	ListCompanyJobs(c *fiber.Ctx) error
 	GetJobDetails(c *fiber.Ctx) error
 	ExportJobDetails(c *fiber.Ctx) error
 	GetJobDetailsByDateRange(c *fiber.Ctx) error
 	ExportJobDetailsByDateRange(c *fiber.Ctx) error
+	BacktestJob(c *fiber.Ctx) error
+	DiffJobs(c *fiber.Ctx) error
+	ExportJobDiff(c *fiber.Ctx) error
+	StreamJobProgress(c *fiber.Ctx) error
 }
 
 func (ctrl *controller) GetJob(c *fiber.Ctx) error {
@@ -54,6 +64,52 @@ func (ctrl *controller) GetJob(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(result)
 }
 
+// ListCompanyJobs lists jobs of every product. product_slug and status take
+// comma-separated values.
+func (ctrl *controller) ListCompanyJobs(c *fiber.Ctx) error {
+	filter := &companyJobFilter{
+		MemberId:     fmt.Sprintf("%v", c.Locals(constant.UserId)),
+		CompanyId:    fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		TierLevel:    fmt.Sprintf("%v", c.Locals(constant.RoleId)),
+		ProductSlugs: splitQuery(c.Query("product_slug")),
+		Statuses:     splitQuery(c.Query("status")),
+		FilterMember: c.Query("member_id"),
+		StartDate:    c.Query(constant.StartDate),
+		EndDate:      c.Query(constant.EndDate),
+		Name:         c.Query("name"),
+		SortBy:       c.Query(constant.SortBy),
+		SortOrder:    c.Query(constant.SortOrder),
+		Cursor:       c.Query(constant.Cursor),
+	}
+
+	size, err := strconv.Atoi(c.Query(constant.Size, "0"))
+	if err != nil {
+		return apperror.BadRequest("size must be a number")
+	}
+	filter.Size = size
+
+	result, err := ctrl.Svc.ListCompanyJobs(filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get jobs",
+		result,
+	))
+}
+
+func splitQuery(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
 func (ctrl *controller) GetJobDetails(c *fiber.Ctx) error {
 	slug := c.Params("product_slug")
 
@@ -68,8 +124,20 @@ func (ctrl *controller) GetJobDetails(c *fiber.Ctx) error {
 		Page:        c.Query(constant.Page, ""),
 		Size:        c.Query(constant.Size, ""),
 		Keyword:     c.Query("keyword"),
+		SortBy:      c.Query(constant.SortBy),
+		SortOrder:   c.Query(constant.SortOrder),
+		RowNumber:   c.Query(constant.RowNumber),
 		JobId:       c.Params("job_id"),
 		ProductSlug: productSlug,
+		IsMasked:    masking.IsMasked(c),
+	}
+
+	if err := helper.ValidateSortParams(filter.SortBy, filter.SortOrder, jobDetailSortFields); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := helper.ValidateRowNumber(filter.RowNumber); err != nil {
+		return apperror.BadRequest(err.Error())
 	}
 
 	result, err := ctrl.Svc.GetJobDetails(filter)
@@ -103,6 +171,7 @@ func (ctrl *controller) GetJobDetailsByDateRange(c *fiber.Ctx) error {
 		ProductSlug: productSlug,
 		StartDate:   startDate,
 		EndDate:     endDate,
+		IsMasked:    masking.IsMasked(c),
 	}
 
 	result, err := ctrl.Svc.GetJobDetailsByDateRange(filter)
@@ -117,7 +186,7 @@ func (ctrl *controller) ExportJobDetails(c *fiber.Ctx) error {
 	memberId := c.Locals(constant.UserId).(uint)
 	companyId := c.Locals(constant.CompanyId).(uint)
 	slug := c.Params("product_slug")
-	masked, _ := strconv.ParseBool(c.Query("masked"))
+	masked := masking.IsMasked(c)
 
 	productSlug, err := mapProductSlug(slug)
 	if err != nil {
@@ -127,14 +196,33 @@ func (ctrl *controller) ExportJobDetails(c *fiber.Ctx) error {
 	filter := &logFilter{
 		MemberId:    strconv.FormatUint(uint64(memberId), 10),
 		CompanyId:   strconv.FormatUint(uint64(companyId), 10),
+		TierLevel:   fmt.Sprintf("%v", c.Locals(constant.RoleId)),
 		ProductSlug: productSlug,
 		JobId:       c.Params("job_id"),
+		SortBy:      c.Query(constant.SortBy, constant.RowNumber),
+		SortOrder:   c.Query(constant.SortOrder, constant.SortAsc),
 		Size:        constant.SizeUnlimited,
 		IsMasked:    masked,
+		DecodeNIK:   c.QueryBool(constant.DecodeNIK),
+	}
+
+	if err := helper.ValidateSortParams(filter.SortBy, filter.SortOrder, jobDetailSortFields); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	exportFn := ctrl.Svc.ExportJobDetails
+	switch c.Query(constant.ExportMode, constant.ExportModeStandard) {
+	case constant.ExportModeStandard:
+	case constant.ExportModeEnrichment:
+		filter.SortBy = constant.RowNumber
+		filter.SortOrder = constant.SortAsc
+		exportFn = ctrl.Svc.ExportEnrichedJobDetails
+	default:
+		return apperror.BadRequest(constant.InvalidExportMode)
 	}
 
 	var buf bytes.Buffer
-	filename, err := ctrl.Svc.ExportJobDetails(filter, &buf)
+	filename, err := exportFn(filter, &buf)
 	if err != nil {
 		return err
 	}
@@ -148,7 +236,7 @@ func (ctrl *controller) ExportJobDetailsByDateRange(c *fiber.Ctx) error {
 	memberId := c.Locals(constant.UserId).(uint)
 	companyId := c.Locals(constant.CompanyId).(uint)
 	slug := c.Params("product_slug")
-	masked, _ := strconv.ParseBool(c.Query("masked"))
+	masked := masking.IsMasked(c)
 
 	productSlug, err := mapProductSlug(slug)
 	if err != nil {
@@ -169,6 +257,7 @@ func (ctrl *controller) ExportJobDetailsByDateRange(c *fiber.Ctx) error {
 		EndDate:     endDate,
 		Size:        constant.SizeUnlimited,
 		IsMasked:    masked,
+		DecodeNIK:   c.QueryBool(constant.DecodeNIK),
 	}
 
 	var buf bytes.Buffer
@@ -182,20 +271,114 @@ func (ctrl *controller) ExportJobDetailsByDateRange(c *fiber.Ctx) error {
 	return c.SendStream(bytes.NewReader(buf.Bytes()))
 }
 
-var productSlugMap = map[string]string{
-	"loan-record-checker":     constant.SlugLoanRecordChecker,
-	"7d-multiple-loan":        constant.SlugMultipleLoan7Days,
-	"30d-multiple-loan":       constant.SlugMultipleLoan30Days,
-	"90d-multiple-loan":       constant.SlugMultipleLoan90Days,
-	"tax-compliance-status":   constant.SlugTaxComplianceStatus,
-	"tax-score":               constant.SlugTaxScore,
-	"tax-verification-detail": constant.SlugTaxVerificationDetail,
-}
+var jobDetailSortFields = []string{constant.RowNumber, constant.CreatedAt}
 
 func mapProductSlug(slug string) (string, error) {
-	if mapped, ok := productSlugMap[slug]; ok {
-		return mapped, nil
+	if handler, ok := registry.GetByRouteSlug(slug); ok {
+		return handler.Slug, nil
+	}
+
+	return "", errors.New(constant.UnsupportedProductSlug)
+}
+
+func (ctrl *controller) BacktestJob(c *fiber.Ctx) error {
+	productSlug, err := mapProductSlug(c.Params("product_slug"))
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	ruleSetId := c.Query("rule_set_id")
+	if ruleSetId == "" {
+		return apperror.BadRequest("rule_set_id is required")
+	}
+
+	filter := &logFilter{
+		MemberId:    fmt.Sprintf("%v", c.Locals(constant.UserId)),
+		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		JobId:       c.Params("job_id"),
+		ProductSlug: productSlug,
+	}
+
+	result, err := ctrl.Svc.BacktestJob(filter, ruleSetId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to backtest rule set",
+		result,
+	))
+}
+
+func (ctrl *controller) DiffJobs(c *fiber.Ctx) error {
+	filter, compareJobId, err := diffFilter(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := ctrl.Svc.DiffJobs(filter, compareJobId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to compare jobs",
+		result,
+	))
+}
+
+func (ctrl *controller) ExportJobDiff(c *fiber.Ctx) error {
+	filter, compareJobId, err := diffFilter(c)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	filename, err := ctrl.Svc.ExportJobDiff(filter, compareJobId, &buf)
+	if err != nil {
+		return err
+	}
+
+	c.Set(constant.HeaderContentType, constant.TextOrCSVContentType)
+	c.Set(constant.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filename))
+	return c.SendStream(bytes.NewReader(buf.Bytes()))
+}
+
+// diffFilter reads the job being compared from the path and the later job it
+// is compared with from compare_job_id.
+func diffFilter(c *fiber.Ctx) (*logFilter, string, error) {
+	productSlug, err := mapProductSlug(c.Params("product_slug"))
+	if err != nil {
+		return nil, "", apperror.BadRequest(err.Error())
+	}
+
+	compareJobId := c.Query("compare_job_id")
+	if compareJobId == "" {
+		return nil, "", apperror.BadRequest("compare_job_id is required")
+	}
+
+	masked := masking.IsMasked(c)
+
+	return &logFilter{
+		MemberId:    fmt.Sprintf("%v", c.Locals(constant.UserId)),
+		CompanyId:   fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		JobId:       c.Params("job_id"),
+		ProductSlug: productSlug,
+		IsMasked:    masked,
+	}, compareJobId, nil
+}
+
+func (ctrl *controller) StreamJobProgress(c *fiber.Ctx) error {
+	if _, err := mapProductSlug(c.Params("product_slug")); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	sub, err := ctrl.Svc.SubscribeJobProgress(c.Params("job_id"), companyId)
+	if err != nil {
+		return err
 	}
 
-	return "", errors.New("unsupported product slug")
+	return progress.Stream(c, sub, true)
 }
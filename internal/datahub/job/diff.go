@@ -0,0 +1,241 @@
+package job
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"sort"
+	"strings"
+)
+
+// Kinds of row in a job diff.
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// DiffJobs matches the rows of two jobs of the same product by their input
+// values and reports the subjects added to or removed from the compared job
+// and the result fields that changed for subjects found in both.
+func (svc *service) DiffJobs(filter *logFilter, compareJobId string) (*jobDiff, error) {
+	handler, ok := registry.Get(filter.ProductSlug)
+	if !ok {
+		return nil, apperror.BadRequest(constant.UnsupportedProductSlug)
+	}
+
+	if filter.JobId == compareJobId {
+		return nil, apperror.BadRequest("cannot compare a job with itself")
+	}
+
+	baseJob, err := svc.getJob(filter.JobId, filter.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	compareJob, err := svc.getJob(compareJobId, filter.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseJob.ProductId != compareJob.ProductId {
+		return nil, apperror.BadRequest("jobs belong to different products")
+	}
+
+	base, err := svc.getJobRows(filter, filter.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	compare, err := svc.getJobRows(filter, compareJobId)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffJobRows(handler, base, compare, filter.IsMasked)
+	diff.BaseJobId = filter.JobId
+	diff.CompareJobId = compareJobId
+
+	return diff, nil
+}
+
+// ExportJobDiff writes a job diff as CSV, one line per added or removed
+// subject and per changed field.
+func (svc *service) ExportJobDiff(filter *logFilter, compareJobId string, buf *bytes.Buffer) (string, error) {
+	diff, err := svc.DiffJobs(filter, compareJobId)
+	if err != nil {
+		return "", err
+	}
+
+	writer := csv.NewWriter(buf)
+
+	headers := append(append([]string{}, diff.KeyHeaders...), "Change", "Field", "Previous", "Current")
+	if err := writer.Write(headers); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	for _, row := range diff.Rows {
+		if len(row.Fields) == 0 {
+			if err := writer.Write(append(append([]string{}, row.Key...), row.Change, "", "", "")); err != nil {
+				return "", apperror.Internal("failed to write CSV", err)
+			}
+			continue
+		}
+
+		for _, field := range row.Fields {
+			line := append(append([]string{}, row.Key...), row.Change, field.Header, field.Previous, field.Current)
+			if err := writer.Write(line); err != nil {
+				return "", apperror.Internal("failed to write CSV", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	return fmt.Sprintf("job_diff_%s_vs_%s.csv", filter.JobId, compareJobId), nil
+}
+
+func (svc *service) getJobRows(filter *logFilter, jobId string) ([]*logTransProductCatalog, error) {
+	resp, err := svc.repo.GetJobDetailAPI(withMergedField(&logFilter{
+		JobId:       jobId,
+		MemberId:    filter.MemberId,
+		CompanyId:   filter.CompanyId,
+		ProductSlug: filter.ProductSlug,
+		SortBy:      constant.RowNumber,
+		SortOrder:   constant.SortAsc,
+		Size:        constant.SizeUnlimited,
+	}))
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job details")
+	}
+
+	if resp == nil || resp.Data == nil {
+		return nil, nil
+	}
+
+	return resp.Data.JobDetails, nil
+}
+
+// diffRows holds the export rows of one job keyed by the unmasked input
+// values, in row order.
+type diffRows struct {
+	keys    []string
+	display map[string][]string
+	results map[string][]string
+}
+
+// diffJobRows compares two jobs' rows. Inputs are matched unmasked; the key
+// columns reported are masked when isMasked is set. The first row of a
+// duplicated input wins.
+func diffJobRows(handler *registry.ProductHandler, base, compare []*logTransProductCatalog, isMasked bool) *jobDiff {
+	var keyColumns, fieldColumns []int
+	for i, column := range handler.ExportColumns {
+		switch column.Source {
+		case registry.SourceInput:
+			keyColumns = append(keyColumns, i)
+		case registry.SourceData, registry.SourceStatus:
+			fieldColumns = append(fieldColumns, i)
+		}
+	}
+
+	collect := func(details []*logTransProductCatalog) *diffRows {
+		sorted := append([]*logTransProductCatalog{}, details...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].RowNumber < sorted[j].RowNumber
+		})
+
+		rows := &diffRows{
+			display: make(map[string][]string, len(sorted)),
+			results: make(map[string][]string, len(sorted)),
+		}
+
+		for _, d := range sorted {
+			row := mapExportRow(handler, false, d)
+			key := strings.Join(pickColumns(row, keyColumns), "\x1f")
+			if _, seen := rows.results[key]; seen {
+				continue
+			}
+
+			display := row
+			if isMasked {
+				display = mapExportRow(handler, true, d)
+			}
+
+			rows.keys = append(rows.keys, key)
+			rows.display[key] = pickColumns(display, keyColumns)
+			rows.results[key] = row
+		}
+
+		return rows
+	}
+
+	baseRows, compareRows := collect(base), collect(compare)
+	ruleFields := handler.RuleFields()
+
+	diff := &jobDiff{
+		KeyHeaders: make([]string, 0, len(keyColumns)),
+		Rows:       []*diffRow{},
+	}
+	for _, i := range keyColumns {
+		diff.KeyHeaders = append(diff.KeyHeaders, handler.ExportColumns[i].Header)
+	}
+
+	for _, key := range compareRows.keys {
+		current := compareRows.results[key]
+
+		previous, ok := baseRows.results[key]
+		if !ok {
+			diff.Added++
+			diff.Rows = append(diff.Rows, &diffRow{Key: compareRows.display[key], Change: DiffAdded})
+			continue
+		}
+
+		var fields []*fieldChange
+		for _, i := range fieldColumns {
+			if previous[i] == current[i] {
+				continue
+			}
+
+			fields = append(fields, &fieldChange{
+				Field:    strings.TrimPrefix(ruleFields[i], handler.RouteSlug+"."),
+				Header:   handler.ExportColumns[i].Header,
+				Previous: previous[i],
+				Current:  current[i],
+			})
+		}
+
+		if len(fields) == 0 {
+			diff.Unchanged++
+			continue
+		}
+
+		diff.Changed++
+		diff.Rows = append(diff.Rows, &diffRow{Key: compareRows.display[key], Change: DiffChanged, Fields: fields})
+	}
+
+	for _, key := range baseRows.keys {
+		if _, ok := compareRows.results[key]; ok {
+			continue
+		}
+
+		diff.Removed++
+		diff.Rows = append(diff.Rows, &diffRow{Key: baseRows.display[key], Change: DiffRemoved})
+	}
+
+	return diff
+}
+
+func pickColumns(row []string, columns []int) []string {
+	result := make([]string, len(columns))
+	for i, c := range columns {
+		result[i] = row[c]
+	}
+
+	return result
+}
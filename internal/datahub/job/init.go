@@ -2,22 +2,52 @@ package job
 
 import (
 	"front-office/configs/application"
+	"front-office/internal/core/audit"
+	"front-office/internal/core/log/operation"
 	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/masking"
+	"front-office/internal/core/member"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/role"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/decision"
 	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
 	"front-office/pkg/httpclient"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	memberRepo := member.NewRepository(cfg, client, nil)
+	controller := NewController(newService(cfg, client, memberRepo))
+	auditService := audit.NewService(operation.NewRepository(cfg, client, nil), memberRepo)
+	resolveMasking := masking.Resolve(masking.NewService(masking.NewRepository(cfg, client, nil), role.NewRepository(cfg, client)))
+
+	apiGroup.Get("/:product_slug/jobs", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJob)
+	apiGroup.Get("/:product_slug/jobs/:job_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, ""), controller.GetJobDetails)
+	apiGroup.Get("/:product_slug/jobs/:job_id/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, ""), controller.ExportJobDetails)
+	apiGroup.Get("/:product_slug/jobs/:job_id/stream", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.StreamJobProgress)
+	apiGroup.Get("/:product_slug/jobs/:job_id/diff", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, ""), controller.DiffJobs)
+	apiGroup.Get("/:product_slug/jobs/:job_id/diff/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, ""), controller.ExportJobDiff)
+	apiGroup.Get("/:product_slug/jobs/:job_id/backtest", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.BacktestJob)
+	apiGroup.Get("/:product_slug/jobs-summary", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.UnmaskedView(auditService, ""), controller.GetJobDetailsByDateRange)
+	apiGroup.Get("/:product_slug/jobs-summary/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventExportData, ""), controller.ExportJobDetailsByDateRange)
+}
+
+// SetupCompanyInit mounts the listing of a company's jobs across products.
+func SetupCompanyInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	controller := NewController(newService(cfg, client, member.NewRepository(cfg, client, nil)))
+
+	apiGroup.Get("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ListCompanyJobs)
+}
+
+func newService(cfg *application.Config, client httpclient.HTTPClient, memberRepo member.Repository) Service {
 	repository := NewRepository(cfg, client, nil)
 	transactionRepo := transaction.NewRepository(cfg, client, nil)
-	service := NewService(repository, transactionRepo)
-	controller := NewController(service)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	webhookRepo := webhook.NewRepository(cfg, client, nil)
+	quotaRepo := quota.NewRepository(cfg, client, nil)
 
-	apiGroup.Get("/:product_slug/jobs", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJob)
-	apiGroup.Get("/:product_slug/jobs/:job_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJobDetails)
-	apiGroup.Get("/:product_slug/jobs/:job_id/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ExportJobDetails)
-	apiGroup.Get("/:product_slug/jobs-summary", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetJobDetailsByDateRange)
-	apiGroup.Get("/:product_slug/jobs-summary/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ExportJobDetailsByDateRange)
+	return NewService(repository, transactionRepo, decision.NewService(decisionRepo), webhook.NewService(webhookRepo), quota.NewService(quotaRepo, memberRepo))
 }
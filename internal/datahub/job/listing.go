@@ -0,0 +1,193 @@
+package job
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultCompanyJobsSize = 10
+	maxCompanyJobsSize     = 100
+)
+
+var (
+	companyJobSortFields = []string{constant.CreatedAt, "total"}
+	companyJobStatuses   = []string{
+		constant.JobStatusPending,
+		constant.JobStatusInProgress,
+		constant.JobStatusDone,
+		constant.JobStatusFailed,
+		constant.JobStatusError,
+	}
+)
+
+// ListCompanyJobs lists the jobs of a company across every product, newest
+// first unless sorted otherwise. Pages are continued with the next_cursor of
+// the previous page, so jobs created while paging do not shift later pages.
+func (svc *service) ListCompanyJobs(filter *companyJobFilter) (*companyJobPage, error) {
+	if err := normalizeCompanyJobFilter(filter); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	size := filter.Size
+	filter.Size++ // one extra job tells whether another page follows
+	resp, err := svc.repo.GetCompanyJobsAPI(filter)
+	filter.Size = size
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch jobs")
+	}
+
+	if resp == nil {
+		resp = &companyJobsRespData{}
+	}
+
+	return pageCompanyJobs(resp, filter), nil
+}
+
+// normalizeCompanyJobFilter validates the filter, maps product route slugs to
+// product slugs and decodes the cursor, which must come from a listing with
+// the same sort.
+func normalizeCompanyJobFilter(filter *companyJobFilter) error {
+	if filter.SortBy == "" {
+		filter.SortBy = constant.CreatedAt
+	}
+	if filter.SortOrder == "" {
+		filter.SortOrder = constant.SortDesc
+	}
+	if err := helper.ValidateSortParams(filter.SortBy, filter.SortOrder, companyJobSortFields); err != nil {
+		return err
+	}
+
+	if filter.Size == 0 {
+		filter.Size = defaultCompanyJobsSize
+	}
+	if filter.Size < 0 || filter.Size > maxCompanyJobsSize {
+		return fmt.Errorf("size must be between 1 and %d", maxCompanyJobsSize)
+	}
+
+	for i, slug := range filter.ProductSlugs {
+		productSlug, err := mapProductSlug(slug)
+		if err != nil {
+			return fmt.Errorf("%s: %s", err.Error(), slug)
+		}
+		filter.ProductSlugs[i] = productSlug
+	}
+
+	for _, status := range filter.Statuses {
+		if !helper.IsValidTemplateHeader(companyJobStatuses, status) {
+			return fmt.Errorf("invalid status, allowed: %v", companyJobStatuses)
+		}
+	}
+
+	if filter.FilterMember != "" {
+		if _, err := strconv.ParseUint(filter.FilterMember, 10, 64); err != nil {
+			return errors.New("member_id must be a number")
+		}
+	}
+
+	for _, date := range []struct{ name, value string }{
+		{constant.StartDate, filter.StartDate},
+		{constant.EndDate, filter.EndDate},
+	} {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse(constant.FormatYYYYMMDD, date.value); err != nil {
+			return fmt.Errorf("%s must be formatted as YYYY-MM-DD", date.name)
+		}
+	}
+	if filter.StartDate != "" && filter.EndDate != "" && filter.EndDate < filter.StartDate {
+		return errors.New("end_date cannot be before start_date")
+	}
+
+	if filter.Cursor == "" {
+		return nil
+	}
+
+	cursor, err := decodeJobCursor(filter.Cursor)
+	if err != nil {
+		return err
+	}
+	if cursor.SortBy != filter.SortBy || cursor.SortOrder != filter.SortOrder {
+		return errors.New("cursor was issued for a different sort")
+	}
+	filter.After = cursor
+
+	return nil
+}
+
+// pageCompanyJobs trims the extra job fetched beyond the page size and
+// issues the cursor continuing after the last job kept.
+func pageCompanyJobs(resp *companyJobsRespData, filter *companyJobFilter) *companyJobPage {
+	page := &companyJobPage{
+		Jobs:         resp.Jobs,
+		StatusTotals: resp.StatusTotals,
+	}
+	if page.Jobs == nil {
+		page.Jobs = []*companyJob{}
+	}
+	if page.StatusTotals == nil {
+		page.StatusTotals = map[string]int64{}
+	}
+
+	for _, count := range page.StatusTotals {
+		page.Total += count
+	}
+
+	for _, job := range page.Jobs {
+		if handler, ok := registry.Get(job.ProductSlug); ok {
+			job.RouteSlug = handler.RouteSlug
+		}
+	}
+
+	if len(page.Jobs) <= filter.Size {
+		return page
+	}
+
+	page.Jobs = page.Jobs[:filter.Size]
+	page.HasMore = true
+	page.NextCursor = encodeJobCursor(&jobCursor{
+		SortBy:    filter.SortBy,
+		SortOrder: filter.SortOrder,
+		Value:     sortValue(page.Jobs[filter.Size-1], filter.SortBy),
+		Id:        page.Jobs[filter.Size-1].Id,
+	})
+
+	return page
+}
+
+func sortValue(job *companyJob, sortBy string) string {
+	if sortBy == "total" {
+		return strconv.Itoa(job.Total)
+	}
+
+	return job.CreatedAt.Format(time.RFC3339Nano)
+}
+
+func encodeJobCursor(cursor *jobCursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJobCursor(value string) (*jobCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	cursor := &jobCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.Id == 0 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return cursor, nil
+}
@@ -11,47 +11,16 @@ type logTransProductCatalog struct {
 	ProductID              uint           `json:"product_id"`
 	Status                 string         `json:"status"`
 	Message                *string        `json:"message"`
-	Input                  *logTransInput `json:"input"`
-	Data                   *logTransData  `json:"data"`
+	Input                  map[string]any `json:"input"`
+	Data                   map[string]any `json:"data"`
 	PricingStrategy        string         `json:"pricing_strategy"`
 	TransactionId          string         `json:"transaction_id"`
+	RowNumber              int            `json:"row_number"`
+	FileName               string         `json:"file_name"`
 	DateTime               string         `json:"datetime"`
 	RefTransProductCatalog any            `json:"ref_trans_product_catalog"`
 }
 
-type refTransProductCatalog struct {
-	Data struct {
-		NPWP string `json:"npwp"`
-	}
-	Input struct {
-		Name        string `json:"name"`
-		NIK         string `json:"nik"`
-		PhoneNumber string `json:"phone_number"`
-		NPWP        string `json:"npwp"`
-		NPWPOrNIK   string `json:"npwp_or_nik"`
-	} `json:"input"`
-}
-
-type logTransData struct {
-	Remarks          *string `json:"remarks,omitempty"`
-	Status           *string `json:"status,omitempty"`
-	QueryCount       *int    `json:"query_count,omitempty"`
-	Nama             *string `json:"nama,omitempty"`
-	Score            *string `json:"score,omitempty"`
-	Alamat           *string `json:"alamat,omitempty"`
-	NPWP             *string `json:"npwp,omitempty"`
-	NPWPVerification *string `json:"npwp_verification,omitempty"`
-	TaxCompliance    *string `json:"tax_compliance,omitempty"`
-}
-
-type logTransInput struct {
-	Name        *string `json:"name,omitempty"`
-	NIK         *string `json:"nik,omitempty"`
-	PhoneNumber *string `json:"phone_number,omitempty"`
-	NPWP        *string `json:"npwp,omitempty"`
-	NPWPOrNIK   *string `json:"npwp_or_nik,omitempty"`
-}
-
 type jobDetailResponse struct {
 	TotalData                  int64                     `json:"total_data"`
 	TotalDataPercentageSuccess int64                     `json:"total_data_percentage_success"`
@@ -65,6 +34,46 @@ type CreateJobRequest struct {
 	MemberId  string `json:"member_id" validate:"required~Field member id is required"`
 	CompanyId string `json:"company_id" validate:"required~Field company id is required"`
 	Total     int    `json:"total" validate:"required~Field total is required"`
+	// ReservationId is the quota reservation the job takes over, set by
+	// CreateJob.
+	ReservationId string `json:"quota_reservation_id,omitempty"`
+	// LegacyJobId is the job of a retired pipeline the job was migrated from.
+	LegacyJobId uint `json:"legacy_job_id,omitempty"`
+	// Calls is the quota each row takes, see registry.ProductHandler.Calls.
+	// Defaults to 1.
+	Calls int `json:"-"`
+}
+
+// JobSource is the uploaded CSV as received, header included, kept so results
+// can be exported back in the layout the client sent. Fields names the
+// request field each column holds, empty for columns no field is read from.
+type JobSource struct {
+	FileName string     `json:"file_name"`
+	Records  [][]string `json:"records"`
+	Fields   []string   `json:"fields,omitempty"`
+}
+
+// JobResults are the export rows of a job keyed by source row number, with
+// the columns that echo the request left out of Headers and Rows.
+type JobResults struct {
+	Headers    []string
+	Rows       map[int][]string
+	RuleValues map[int]map[string]string
+}
+
+type backtestResult struct {
+	RuleSetId uint           `json:"rule_set_id"`
+	Version   int            `json:"version"`
+	Total     int            `json:"total"`
+	Decisions map[string]int `json:"decisions"`
+	Rules     map[string]int `json:"rules"`
+	Rows      []*backtestRow `json:"rows"`
+}
+
+type backtestRow struct {
+	RowNumber int    `json:"row_number"`
+	Decision  string `json:"decision"`
+	Rule      string `json:"rule"`
 }
 
 type UpdateJobRequest struct {
@@ -79,6 +88,16 @@ type createJobRespData struct {
 	CompanyId uint `json:"company_id"`
 }
 
+type jobData struct {
+	Id           uint   `json:"id"`
+	ProductId    uint   `json:"product_id"`
+	MemberId     uint   `json:"member_id"`
+	CompanyId    uint   `json:"company_id"`
+	Status       string `json:"status"`
+	Total        int    `json:"total"`
+	SuccessCount uint   `json:"success_count"`
+}
+
 type logFilter struct {
 	Page        string
 	Size        string
@@ -91,5 +110,96 @@ type logFilter struct {
 	CompanyId   string
 	TierLevel   string
 	IsMasked    bool
+	DecodeNIK   bool
 	Keyword     string
+	SortBy      string
+	SortOrder   string
+	RowNumber   string
+	// MergedField, see withMergedField.
+	MergedField string
+}
+
+// companyJobFilter selects the jobs of a company across products. Empty
+// fields do not filter; Name matches part of the uploaded file name. Cursor
+// is the next_cursor of the previous page, After its decoded form.
+type companyJobFilter struct {
+	MemberId     string
+	CompanyId    string
+	TierLevel    string
+	ProductSlugs []string
+	Statuses     []string
+	FilterMember string
+	StartDate    string
+	EndDate      string
+	Name         string
+	SortBy       string
+	SortOrder    string
+	Size         int
+	Cursor       string
+	After        *jobCursor
+}
+
+// jobCursor is the position after the last job of a page: the value of the
+// sort field and the job ID that breaks ties between equal values.
+type jobCursor struct {
+	SortBy    string `json:"sort_by"`
+	SortOrder string `json:"sort_order"`
+	Value     string `json:"value"`
+	Id        uint   `json:"id"`
+}
+
+type companyJob struct {
+	Id           uint       `json:"id"`
+	ProductId    uint       `json:"product_id"`
+	ProductSlug  string     `json:"product_slug"`
+	RouteSlug    string     `json:"route_slug"`
+	MemberId     uint       `json:"member_id"`
+	CompanyId    uint       `json:"company_id"`
+	Status       string     `json:"status"`
+	Total        int        `json:"total"`
+	SuccessCount uint       `json:"success_count"`
+	FileName     string     `json:"file_name"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EndAt        *time.Time `json:"end_at"`
+}
+
+type companyJobsRespData struct {
+	Jobs         []*companyJob    `json:"jobs"`
+	StatusTotals map[string]int64 `json:"status_totals"`
+}
+
+// companyJobPage is one page of a company job listing. StatusTotals count
+// every job matching the filter, not only those on the page.
+type companyJobPage struct {
+	Jobs         []*companyJob    `json:"jobs"`
+	NextCursor   string           `json:"next_cursor"`
+	HasMore      bool             `json:"has_more"`
+	Total        int64            `json:"total"`
+	StatusTotals map[string]int64 `json:"status_totals"`
+}
+
+// jobDiff compares a job with a later run of the same product. Key holds the
+// input values identifying a subject, in KeyHeaders order.
+type jobDiff struct {
+	BaseJobId    string     `json:"base_job_id"`
+	CompareJobId string     `json:"compare_job_id"`
+	KeyHeaders   []string   `json:"key_headers"`
+	Added        int        `json:"added"`
+	Removed      int        `json:"removed"`
+	Changed      int        `json:"changed"`
+	Unchanged    int        `json:"unchanged"`
+	Rows         []*diffRow `json:"rows"`
+}
+
+type diffRow struct {
+	Key    []string       `json:"key"`
+	Change string         `json:"change"`
+	Fields []*fieldChange `json:"fields,omitempty"`
+}
+
+type fieldChange struct {
+	Field    string `json:"field"`
+	Header   string `json:"header"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
 }
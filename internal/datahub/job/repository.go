@@ -12,6 +12,8 @@ import (
 	"front-office/pkg/httpclient"
 	"front-office/pkg/jsonutil"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -36,9 +38,14 @@ type repository struct {
 type Repository interface {
 	CreateJobAPI(payload *CreateJobRequest) (*createJobRespData, error)
 	UpdateJobAPI(jobId string, req map[string]interface{}) error
+	GetJobByIdAPI(jobId, companyId string) (*jobData, error)
+	GetJobByLegacyIdAPI(productSlug, legacyJobId, companyId string) (*jobData, error)
 	GetJobsAPI(filter *logFilter) (*model.AifcoreAPIResponse[any], error)
+	GetCompanyJobsAPI(filter *companyJobFilter) (*companyJobsRespData, error)
 	GetJobDetailAPI(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error)
 	GetJobsSummaryAPI(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error)
+	SaveJobSourceAPI(jobId string, payload *JobSource) error
+	GetJobSourceAPI(jobId, memberId, companyId, tierLevel string) (*JobSource, error)
 }
 
 func (repo *repository) CreateJobAPI(payload *CreateJobRequest) (*createJobRespData, error) {
@@ -107,6 +114,64 @@ func (repo *repository) UpdateJobAPI(jobId string, payload map[string]interface{
 	return nil
 }
 
+func (repo *repository) GetJobByIdAPI(jobId, companyId string) (*jobData, error) {
+	url := fmt.Sprintf("%s/api/core/product/jobs/%s", repo.cfg.Env.AifcoreHost, jobId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XCompanyId, companyId)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[*jobData](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// GetJobByLegacyIdAPI returns the job of a product that was migrated from
+// legacyJobId, or nil when none was.
+func (repo *repository) GetJobByLegacyIdAPI(productSlug, legacyJobId, companyId string) (*jobData, error) {
+	url := fmt.Sprintf("%s/api/core/product/%s/jobs/legacy/%s", repo.cfg.Env.AifcoreHost, productSlug, legacyJobId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XCompanyId, companyId)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[*jobData](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
 func (repo *repository) GetJobsAPI(filter *logFilter) (*model.AifcoreAPIResponse[any], error) {
 	url := fmt.Sprintf("%s/api/core/product/%s/jobs", repo.cfg.Env.AifcoreHost, filter.ProductSlug)
 
@@ -144,6 +209,55 @@ func (repo *repository) GetJobsAPI(filter *logFilter) (*model.AifcoreAPIResponse
 	return apiResp, nil
 }
 
+// GetCompanyJobsAPI lists one page of the jobs of a company across products.
+// Aifcore pages by keyset: with after_id set it returns the jobs ordered
+// after (after_value, after_id) in the requested order.
+func (repo *repository) GetCompanyJobsAPI(filter *companyJobFilter) (*companyJobsRespData, error) {
+	url := fmt.Sprintf("%s/api/core/product/jobs", repo.cfg.Env.AifcoreHost)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XMemberId, filter.MemberId)
+	req.Header.Set(constant.XCompanyId, filter.CompanyId)
+	req.Header.Set(constant.XTierLevel, filter.TierLevel)
+
+	q := req.URL.Query()
+	q.Add("product_slug", strings.Join(filter.ProductSlugs, ","))
+	q.Add("status", strings.Join(filter.Statuses, ","))
+	q.Add("member_id", filter.FilterMember)
+	q.Add(constant.StartDate, filter.StartDate)
+	q.Add(constant.EndDate, filter.EndDate)
+	q.Add("name", filter.Name)
+	q.Add(constant.SortBy, filter.SortBy)
+	q.Add(constant.SortOrder, filter.SortOrder)
+	q.Add(constant.Size, strconv.Itoa(filter.Size))
+	if filter.After != nil {
+		q.Add("after_value", filter.After.Value)
+		q.Add("after_id", strconv.FormatUint(uint64(filter.After.Id), 10))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[*companyJobsRespData](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
 func (repo *repository) GetJobDetailAPI(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error) {
 	url := fmt.Sprintf("%s/api/core/product/%s/jobs/%s", repo.cfg.Env.AifcoreHost, filter.ProductSlug, filter.JobId)
 
@@ -163,6 +277,10 @@ func (repo *repository) GetJobDetailAPI(filter *logFilter) (*model.AifcoreAPIRes
 	q.Add(constant.Page, filter.Page)
 	q.Add(constant.Size, filter.Size)
 	q.Add(constant.Keyword, filter.Keyword)
+	q.Add(constant.SortBy, filter.SortBy)
+	q.Add(constant.SortOrder, filter.SortOrder)
+	q.Add(constant.RowNumber, filter.RowNumber)
+	q.Add(constant.MergedField, filter.MergedField)
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := repo.client.Do(req)
@@ -196,10 +314,14 @@ func (repo *repository) GetJobsSummaryAPI(filter *logFilter) (*model.AifcoreAPIR
 
 	q := req.URL.Query()
 	q.Add(constant.Keyword, filter.Keyword)
+	q.Add(constant.SortBy, filter.SortBy)
+	q.Add(constant.SortOrder, filter.SortOrder)
+	q.Add(constant.RowNumber, filter.RowNumber)
 	q.Add(constant.Page, filter.Page)
 	q.Add(constant.Size, filter.Size)
 	q.Add(constant.StartDate, filter.StartDate)
 	q.Add(constant.EndDate, filter.EndDate)
+	q.Add(constant.MergedField, filter.MergedField)
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := repo.client.Do(req)
@@ -215,3 +337,69 @@ func (repo *repository) GetJobsSummaryAPI(filter *logFilter) (*model.AifcoreAPIR
 
 	return apiResp, nil
 }
+
+// SaveJobSourceAPI stores the upload of a job. Uploads run up to 30MB, so it
+// allows longer than the other calls.
+func (repo *repository) SaveJobSourceAPI(jobId string, payload *JobSource) error {
+	url := fmt.Sprintf("%s/api/core/product/jobs/%s/source", repo.cfg.Env.AifcoreHost, jobId)
+
+	bodyBytes, err := repo.marshalFn(payload)
+	if err != nil {
+		return fmt.Errorf(constant.ErrMsgMarshalReqBody, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	_, err = helper.ParseAifcoreAPIResponse[any](resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetJobSourceAPI returns the upload of a job, scoped like its details to
+// what the member may see.
+func (repo *repository) GetJobSourceAPI(jobId, memberId, companyId, tierLevel string) (*JobSource, error) {
+	url := fmt.Sprintf("%s/api/core/product/jobs/%s/source", repo.cfg.Env.AifcoreHost, jobId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+
+	req.Header.Set(constant.HeaderContentType, constant.HeaderApplicationJSON)
+	req.Header.Set(constant.XMemberId, memberId)
+	req.Header.Set(constant.XCompanyId, companyId)
+	req.Header.Set(constant.XTierLevel, tierLevel)
+
+	resp, err := repo.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(constant.ErrMsgHTTPReqFailed, err)
+	}
+	defer resp.Body.Close()
+
+	apiResp, err := helper.ParseAifcoreAPIResponse[*JobSource](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
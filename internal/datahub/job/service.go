@@ -3,47 +3,102 @@ package job
 import (
 	"bytes"
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/progress"
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
 	"front-office/pkg/common/model"
 	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+	"front-office/pkg/utility/mask"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/rs/zerolog/log"
 )
 
-func NewService(repo Repository, transactionRepo transaction.Repository) Service {
+func NewService(repo Repository, transactionRepo transaction.Repository, decisionSvc decision.Service, webhookSvc webhook.Service, quotaSvc quota.Service) Service {
 	return &service{
 		repo,
 		transactionRepo,
+		decisionSvc,
+		webhookSvc,
+		quotaSvc,
 	}
 }
 
 type service struct {
 	repo            Repository
 	transactionRepo transaction.Repository
+	decisionSvc     decision.Service
+	webhookSvc      webhook.Service
+	quotaSvc        quota.Service
 }
 
 type Service interface {
 	CreateJob(req *CreateJobRequest) (*createJobRespData, error)
 	UpdateJobAPI(jobId string, req *UpdateJobRequest) error
 	GetJob(filter *logFilter) (*model.AifcoreAPIResponse[any], error)
+	ListCompanyJobs(filter *companyJobFilter) (*companyJobPage, error)
 	GetJobDetails(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error)
 	ExportJobDetails(filter *logFilter, buf *bytes.Buffer) (string, error)
 	GetJobDetailsByDateRange(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error)
 	ExportJobDetailsByDateRange(filter *logFilter, buf *bytes.Buffer) (string, error)
-	FinalizeJob(jobIdStr string) error
-	FinalizeFailedJob(jobIdStr string) error
+	ExportEnrichedJobDetails(filter *logFilter, buf *bytes.Buffer) (string, error)
+	SaveJobSource(jobId string, source *JobSource) error
+	GetJobSource(jobId, memberId, companyId, tierLevel string) (*JobSource, error)
+	GetJobResults(jobId, memberId, companyId, productSlug string, isMasked bool) (*JobResults, error)
+	BacktestJob(filter *logFilter, ruleSetId string) (*backtestResult, error)
+	DiffJobs(filter *logFilter, compareJobId string) (*jobDiff, error)
+	ExportJobDiff(filter *logFilter, compareJobId string, buf *bytes.Buffer) (string, error)
+	FinalizeJob(jobIdStr, companyId string) error
+	FinalizeFailedJob(jobIdStr, companyId string) error
+	SubscribeJobProgress(jobIdStr, companyId string) (*progress.Subscription, error)
+	MigratedJobId(productSlug, legacyJobId, companyId string) (string, error)
 }
 
+// CreateJob reserves the quota of req.Total rows and opens a job holding that
+// reservation until it is finalized. No job is created when the quota cannot
+// cover the rows.
 func (svc *service) CreateJob(req *CreateJobRequest) (*createJobRespData, error) {
+	calls := req.Calls
+	if calls < 1 {
+		calls = 1
+	}
+
+	reservation, err := svc.quotaSvc.Reserve(req.MemberId, req.CompanyId, req.ProductId, req.Total*calls)
+	if err != nil {
+		return nil, err
+	}
+	req.ReservationId = reservation.Id
+
 	result, err := svc.repo.CreateJobAPI(req)
 	if err != nil {
+		if err := svc.quotaSvc.CancelReservation(reservation.Id); err != nil {
+			log.Error().Err(err).Str("reservation_id", reservation.Id).Msg("failed to cancel quota reservation of uncreated job")
+		}
+
 		return nil, apperror.MapRepoError(err, constant.FailedCreateJob)
 	}
 
+	jobIdStr := helper.ConvertUintToString(result.JobId)
+	progress.Start(req.CompanyId, jobIdStr, req.Total)
+
+	svc.webhookSvc.Dispatch(req.CompanyId, webhook.EventJobCreated, &webhook.JobEventData{
+		JobId:     jobIdStr,
+		Status:    constant.JobStatusPending,
+		ProductId: req.ProductId,
+		Total:     req.Total,
+	})
+
 	return result, nil
 }
 
@@ -80,20 +135,28 @@ func (svc *service) GetJob(filter *logFilter) (*model.AifcoreAPIResponse[any], e
 }
 
 func (svc *service) GetJobDetails(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error) {
-	result, err := svc.repo.GetJobDetailAPI(filter)
+	result, err := svc.repo.GetJobDetailAPI(withMergedField(filter))
 	if err != nil {
 		return nil, apperror.MapRepoError(err, "failed to fetch job detail")
 	}
 
+	if filter.IsMasked && result.Data != nil {
+		maskJobDetails(filter.ProductSlug, result.Data.JobDetails)
+	}
+
 	return result, nil
 }
 
 func (svc *service) GetJobDetailsByDateRange(filter *logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error) {
-	result, err := svc.repo.GetJobsSummaryAPI(filter)
+	result, err := svc.repo.GetJobsSummaryAPI(withMergedField(filter))
 	if err != nil {
 		return nil, apperror.MapRepoError(err, "failed to fetch job detail")
 	}
 
+	if filter.IsMasked && result.Data != nil {
+		maskJobDetails(filter.ProductSlug, result.Data.JobDetails)
+	}
+
 	return result, nil
 }
 
@@ -111,40 +174,35 @@ func (svc *service) exportJobDetailsToCSV(
 	fetchFunc func(*logFilter) (*model.AifcoreAPIResponse[*jobDetailResponse], error),
 	includeDate bool,
 ) (string, error) {
-	resp, err := fetchFunc(filter)
+	resp, err := fetchFunc(withMergedField(filter))
 	if err != nil {
 		return "", apperror.MapRepoError(err, "failed to fetch job details")
 	}
 
-	headers := []string{}
-	var mapper func(*logTransProductCatalog) []string
+	headers, mapper, _ := exportLayout(filter.ProductSlug, filter.IsMasked)
 
-	switch filter.ProductSlug {
-	case constant.SlugLoanRecordChecker:
-		headers = []string{"Name", "NIK", "Phone Number", "Remarks", "Data Status", "Status", "Description"}
-		mapper = func(d *logTransProductCatalog) []string {
-			return mapLoanRecordCheckerRow(filter.IsMasked, d)
-		}
-	case constant.SlugMultipleLoan7Days, constant.SlugMultipleLoan30Days, constant.SlugMultipleLoan90Days:
-		headers = []string{"NIK", "Phone Number", "Query Count", "Status", "Description"}
-		mapper = func(d *logTransProductCatalog) []string {
-			return mapMultipleLoanRow(filter.IsMasked, d)
-		}
-	case constant.SlugTaxComplianceStatus:
-		headers = []string{"NPWP", "Nama", "Alamat", "Data Status", "Status", "Description"}
-		mapper = func(d *logTransProductCatalog) []string {
-			return mapTaxComplianceRow(filter.IsMasked, d)
-		}
-	case constant.SlugTaxScore:
-		headers = []string{"NPWP", "Nama", "Alamat", "Data Status", "Score", "Status", "Description"}
-		mapper = func(d *logTransProductCatalog) []string {
-			return mapTaxScoreRow(filter.IsMasked, d)
-		}
-	case constant.SlugTaxVerificationDetail:
-		headers = []string{"Nama", "Alamat", "NPWP", "NPWP Verification", "Data Status", "Tax Compliance", "Status", "Description"}
-		mapper = func(d *logTransProductCatalog) []string {
-			return mapTaxVerificationRow(filter.IsMasked, d)
+	ruleSet, err := svc.decisionSvc.GetActiveRuleSet(filter.CompanyId)
+	if err != nil {
+		return "", err
+	}
+
+	if ruleSet != nil && mapper != nil {
+		headers = append(headers, decision.Headers...)
+		mapper = withDecisionColumns(mapper, filter.ProductSlug, ruleSet)
+	}
+
+	if filter.DecodeNIK && mapper != nil {
+		if !hasNIKInput(filter.ProductSlug) {
+			return "", apperror.BadRequest(constant.NIKDecodingUnsupported)
 		}
+
+		headers = append(headers, identity.NIKHeaders...)
+		mapper = withNIKColumns(mapper, filter.IsMasked)
+	}
+
+	if filter.JobId != "" {
+		headers = append([]string{"Row Number"}, headers...)
+		mapper = withRowNumberColumn(mapper)
 	}
 
 	if includeDate {
@@ -161,24 +219,290 @@ func (svc *service) exportJobDetailsToCSV(
 	return filename, nil
 }
 
-func (svc *service) FinalizeJob(jobIdStr string) error {
-	count, err := svc.transactionRepo.ProcessedLogCountAPI(jobIdStr)
+func (svc *service) ExportEnrichedJobDetails(filter *logFilter, buf *bytes.Buffer) (string, error) {
+	source, err := svc.GetJobSource(filter.JobId, filter.MemberId, filter.CompanyId, filter.TierLevel)
 	if err != nil {
-		return apperror.MapRepoError(err, "failed to get success count")
+		return "", err
 	}
 
-	if err := svc.repo.UpdateJobAPI(jobIdStr, map[string]interface{}{
-		"success_count": helper.IntPtr(int(count.ProcessedCount)),
-		"status":        helper.StringPtr(constant.JobStatusDone),
-		"end_at":        helper.TimePtr(time.Now()),
-	}); err != nil {
-		return apperror.MapRepoError(err, "failed to update job status")
+	results, err := svc.GetJobResults(filter.JobId, filter.MemberId, filter.CompanyId, filter.ProductSlug, filter.IsMasked)
+	if err != nil {
+		return "", err
+	}
+
+	ruleSet, err := svc.decisionSvc.GetActiveRuleSet(filter.CompanyId)
+	if err != nil {
+		return "", err
+	}
+
+	headers, rows := results.Headers, results.Rows
+	if ruleSet != nil {
+		headers = append(headers, decision.Headers...)
+		for rowNumber, row := range rows {
+			outcome := decision.Evaluate(ruleSet, results.RuleValues[rowNumber])
+			rows[rowNumber] = append(row, outcome.Columns()...)
+		}
+	}
+
+	records := source.Records
+	if filter.IsMasked {
+		handler, _ := registry.Get(filter.ProductSlug)
+		records = source.MaskedRecords(handler)
+	}
+
+	if err := helper.WriteEnrichedCSV(buf, records, headers, rows); err != nil {
+		return "", apperror.Internal("failed to write CSV", err)
+	}
+
+	return formatEnrichedFileName(source.FileName, filter.JobId), nil
+}
+
+// GetJobResults returns the result columns of a job, without the columns that
+// echo the request, and the decision rule values of each row. Both are keyed
+// by the source row number of each log. Rule values are never masked.
+func (svc *service) GetJobResults(jobId, memberId, companyId, productSlug string, isMasked bool) (*JobResults, error) {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return nil, apperror.BadRequest(constant.UnsupportedProductSlug)
+	}
+
+	resp, err := svc.repo.GetJobDetailAPI(withMergedField(&logFilter{
+		JobId:       jobId,
+		MemberId:    memberId,
+		CompanyId:   companyId,
+		ProductSlug: productSlug,
+		SortBy:      constant.RowNumber,
+		SortOrder:   constant.SortAsc,
+		Size:        constant.SizeUnlimited,
+	}))
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job details")
+	}
+
+	return jobResults(handler, resp.Data.JobDetails, isMasked), nil
+}
+
+func jobResults(handler *registry.ProductHandler, details []*logTransProductCatalog, isMasked bool) *JobResults {
+	headers, inputColumns := handler.ExportHeaders()
+	results := &JobResults{
+		Headers:    dropColumns(headers, inputColumns),
+		Rows:       make(map[int][]string, len(details)),
+		RuleValues: make(map[int]map[string]string, len(details)),
+	}
+
+	for _, d := range details {
+		if d.RowNumber == 0 {
+			continue
+		}
+
+		row := mapExportRow(handler, false, d)
+		results.RuleValues[d.RowNumber] = handler.RuleValues(row)
+		if isMasked {
+			row = mapExportRow(handler, true, d)
+		}
+		results.Rows[d.RowNumber] = dropColumns(row, inputColumns)
+	}
+
+	return results
+}
+
+// MaskedRecords returns a copy of the source with the columns holding a
+// masked field of any of handlers masked. Columns not mapped to any field
+// may hold anything, so they are dropped. Sources saved without Fields are
+// matched by their header.
+func (s *JobSource) MaskedRecords(handlers ...*registry.ProductHandler) [][]string {
+	if len(s.Records) == 0 {
+		return s.Records
+	}
+
+	fields := s.Fields
+	if len(fields) == 0 {
+		var columns []helper.CSVColumn
+		for _, handler := range handlers {
+			if handler != nil {
+				columns = append(columns, handler.Columns...)
+			}
+		}
+		fields = helper.HeaderFields(s.Records[0], columns)
+	}
+
+	masked := make([]bool, len(fields))
+	for i, field := range fields {
+		for _, handler := range handlers {
+			if handler != nil && field != "" && handler.IsMaskedField(field) {
+				masked[i] = true
+			}
+		}
+	}
+
+	records := make([][]string, len(s.Records))
+	for i, record := range s.Records {
+		row := make([]string, 0, len(record))
+		for j, value := range record {
+			if j >= len(fields) || fields[j] == "" {
+				continue
+			}
+			if i > 0 && masked[j] {
+				value = mask.Field(fields[j], value)
+			}
+			row = append(row, value)
+		}
+		records[i] = row
+	}
+
+	return records
+}
+
+// BacktestJob applies a rule set to the results of a past job without
+// changing which rule set is active.
+func (svc *service) BacktestJob(filter *logFilter, ruleSetId string) (*backtestResult, error) {
+	ruleSet, err := svc.decisionSvc.GetRuleSet(filter.CompanyId, ruleSetId)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := svc.GetJobResults(filter.JobId, filter.MemberId, filter.CompanyId, filter.ProductSlug, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rowNumbers := make([]int, 0, len(results.RuleValues))
+	for rowNumber := range results.RuleValues {
+		rowNumbers = append(rowNumbers, rowNumber)
+	}
+	sort.Ints(rowNumbers)
+
+	backtest := &backtestResult{
+		RuleSetId: ruleSet.Id,
+		Version:   ruleSet.Version,
+		Total:     len(rowNumbers),
+		Decisions: map[string]int{},
+		Rules:     map[string]int{},
+		Rows:      make([]*backtestRow, 0, len(rowNumbers)),
+	}
+
+	for _, rowNumber := range rowNumbers {
+		outcome := decision.Evaluate(ruleSet, results.RuleValues[rowNumber])
+
+		backtest.Decisions[outcome.Decision]++
+		if outcome.Rule != "" {
+			backtest.Rules[outcome.Rule]++
+		}
+
+		backtest.Rows = append(backtest.Rows, &backtestRow{
+			RowNumber: rowNumber,
+			Decision:  outcome.Decision,
+			Rule:      outcome.Rule,
+		})
+	}
+
+	return backtest, nil
+}
+
+func (svc *service) SaveJobSource(jobId string, source *JobSource) error {
+	if err := svc.repo.SaveJobSourceAPI(jobId, source); err != nil {
+		return apperror.MapRepoError(err, "failed to save job source file")
 	}
 
 	return nil
 }
 
-func (svc *service) FinalizeFailedJob(jobIdStr string) error {
+func (svc *service) GetJobSource(jobId, memberId, companyId, tierLevel string) (*JobSource, error) {
+	source, err := svc.repo.GetJobSourceAPI(jobId, memberId, companyId, tierLevel)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job source file")
+	}
+
+	if source == nil || len(source.Records) == 0 {
+		return nil, apperror.NotFound("original upload is not available for this job")
+	}
+
+	return source, nil
+}
+
+// FinalizeJob marks a job done with its processed count.
+func (svc *service) FinalizeJob(jobIdStr, companyId string) error {
+	return svc.finalize(jobIdStr, companyId, constant.JobStatusDone, webhook.EventJobCompleted)
+}
+
+func (svc *service) FinalizeFailedJob(jobIdStr, companyId string) error {
+	return svc.finalize(jobIdStr, companyId, constant.JobStatusFailed, webhook.EventJobFailed)
+}
+
+// SubscribeJobProgress opens the progress stream of a job of the company. A
+// job that already finished gets a stream holding only its summary. The
+// subscription is opened before the status is read so a job finishing in
+// between still delivers its summary, and the stream polls the status for
+// jobs run by another instance.
+func (svc *service) SubscribeJobProgress(jobIdStr, companyId string) (*progress.Subscription, error) {
+	sub := progress.Subscribe(companyId, jobIdStr)
+
+	current, err := svc.jobProgress(jobIdStr, companyId)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	if progress.IsFinished(current.Status) {
+		sub.Close()
+		return progress.Completed(current), nil
+	}
+
+	sub.Poll(func() (*progress.Progress, error) {
+		return svc.jobProgress(jobIdStr, companyId)
+	})
+
+	return sub, nil
+}
+
+// jobProgress reads the persisted progress of a job. Rows are only counted
+// when the job is finalized, so it is complete for finished jobs only.
+func (svc *service) jobProgress(jobIdStr, companyId string) (*progress.Progress, error) {
+	job, err := svc.getJob(jobIdStr, companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &progress.Progress{
+		JobId:     jobIdStr,
+		CompanyId: companyId,
+		Status:    job.Status,
+		Total:     job.Total,
+		Processed: job.Total,
+		Success:   int(job.SuccessCount),
+		Failed:    job.Total - int(job.SuccessCount),
+	}, nil
+}
+
+// MigratedJobId returns the id of the job legacyJobId was migrated to, or
+// legacyJobId itself when no job of the product was migrated from it.
+func (svc *service) MigratedJobId(productSlug, legacyJobId, companyId string) (string, error) {
+	job, err := svc.repo.GetJobByLegacyIdAPI(productSlug, legacyJobId, companyId)
+	if err != nil {
+		return "", apperror.MapRepoError(err, "failed to fetch migrated job")
+	}
+
+	if job == nil || job.Id == 0 {
+		return legacyJobId, nil
+	}
+
+	return helper.ConvertUintToString(job.Id), nil
+}
+
+func (svc *service) getJob(jobIdStr, companyId string) (*jobData, error) {
+	job, err := svc.repo.GetJobByIdAPI(jobIdStr, companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job")
+	}
+
+	if job == nil {
+		return nil, apperror.NotFound("job not found")
+	}
+
+	return job, nil
+}
+
+func (svc *service) finalize(jobIdStr, companyId, status, event string) error {
 	count, err := svc.transactionRepo.ProcessedLogCountAPI(jobIdStr)
 	if err != nil {
 		return apperror.MapRepoError(err, "failed to get processed count request")
@@ -186,13 +510,23 @@ func (svc *service) FinalizeFailedJob(jobIdStr string) error {
 
 	if err := svc.repo.UpdateJobAPI(jobIdStr, map[string]interface{}{
 		"success_count": helper.IntPtr(int(count.ProcessedCount)),
-		"status":        helper.StringPtr(constant.JobStatusFailed),
+		"status":        helper.StringPtr(status),
 		"end_at":        helper.TimePtr(time.Now()),
 	}); err != nil {
 		return apperror.MapRepoError(err, "failed to update job status")
 	}
 
-	return nil
+	// the job is over either way; a failed release is still reported
+	releaseErr := svc.quotaSvc.Release(jobIdStr)
+	progress.Finish(companyId, jobIdStr, status, int(count.ProcessedCount))
+
+	svc.webhookSvc.Dispatch(companyId, event, &webhook.JobEventData{
+		JobId:        jobIdStr,
+		Status:       status,
+		SuccessCount: uint(count.ProcessedCount),
+	})
+
+	return releaseErr
 }
 
 func writeToCSV[T any](buf *bytes.Buffer, headers []string, data []T, mapRow func(T) []string) error {
@@ -225,213 +559,199 @@ func formatCSVFileName(base, startDate, endDate, jobId string) string {
 	return fmt.Sprintf("%s_%s.csv", base, startDate)
 }
 
-type rowMapper func(*logTransProductCatalog) []string
-
-func withDateColumn(mapper rowMapper) rowMapper {
-	return func(d *logTransProductCatalog) []string {
-		row := mapper(d)
-		date := d.DateTime
-
-		return append([]string{date}, row...)
+// formatEnrichedFileName names an enrichment export after the file the client
+// uploaded, falling back to the job id when the name was not recorded.
+func formatEnrichedFileName(fileName, jobId string) string {
+	base := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	if fileName == "" || base == "" || base == "." {
+		return fmt.Sprintf("job_detail_id_%s_enriched.csv", jobId)
 	}
+
+	return fmt.Sprintf("%s_enriched.csv", base)
 }
 
-func mapLoanRecordCheckerRow(isMasked bool, d *logTransProductCatalog) []string {
-	var (
-		description string
-		remarks     string
-		status      string
-		phoneNumber string
-		nik         string
-	)
+type rowMapper func(*logTransProductCatalog) []string
 
-	if d.Message != nil {
-		description = *d.Message
+// exportLayout returns the CSV headers and row mapper for a product, together
+// with the positions of the columns that only echo the request input.
+func exportLayout(productSlug string, isMasked bool) ([]string, rowMapper, []int) {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return []string{}, nil, nil
 	}
 
-	if d.Data != nil {
-		remarks = *d.Data.Remarks
-		status = *d.Data.Status
+	headers, inputColumns := handler.ExportHeaders()
+	mapper := func(d *logTransProductCatalog) []string {
+		return mapExportRow(handler, isMasked, d)
 	}
 
-	var ref refTransProductCatalog
-	if raw, err := json.Marshal(d.RefTransProductCatalog); err == nil {
-		_ = json.Unmarshal(raw, &ref)
-	}
+	return headers, mapper, inputColumns
+}
 
-	if isMasked {
-		nik = ref.Input.NIK
-		phoneNumber = ref.Input.PhoneNumber
-	} else {
-		phoneNumber = *d.Input.PhoneNumber
-		nik = *d.Input.NIK
-	}
+func mapExportRow(handler *registry.ProductHandler, isMasked bool, d *logTransProductCatalog) []string {
+	row := make([]string, len(handler.ExportColumns))
+	for i, column := range handler.ExportColumns {
+		var value string
+
+		switch column.Source {
+		case registry.SourceInput:
+			value = formatExportValue(d.Input[column.Field])
+		case registry.SourceData:
+			value = formatExportValue(d.Data[column.Field])
+		case registry.SourceStatus:
+			value = d.Status
+		case registry.SourceMessage:
+			if d.Message != nil {
+				value = *d.Message
+			}
+		}
 
-	return []string{
-		*d.Input.Name,
-		nik,
-		phoneNumber,
-		remarks,
-		status,
-		d.Status,
-		description,
-	}
-}
+		if value == "" && column.Fallback != nil {
+			value = column.Fallback(d.Input)
+		}
 
-func mapMultipleLoanRow(isMasked bool, d *logTransProductCatalog) []string {
-	var (
-		description string
-		queryCount  int
-		phoneNumber string
-		nik         string
-	)
+		if isMasked && column.Source != registry.SourceStatus && column.Source != registry.SourceMessage && handler.IsMaskedField(column.Field) {
+			value = mask.Field(column.Field, value)
+		}
 
-	if d.Message != nil {
-		description = *d.Message
-	}
+		if column.Format != nil {
+			value = column.Format(value)
+		}
 
-	if d.Data != nil {
-		queryCount = *d.Data.QueryCount
+		row[i] = value
 	}
 
-	var ref refTransProductCatalog
-	if raw, err := json.Marshal(d.RefTransProductCatalog); err == nil {
-		_ = json.Unmarshal(raw, &ref)
-	}
+	return row
+}
 
-	if isMasked {
-		nik = ref.Input.NIK
-		phoneNumber = ref.Input.PhoneNumber
-	} else {
-		phoneNumber = *d.Input.PhoneNumber
-		nik = *d.Input.NIK
+// withMergedField sets the MergedField of the product on filter. A request of
+// such a product logs a transaction per upstream call, each holding the merged
+// data, so Aifcore is asked to list, page and count one log per job row: the
+// first one holding the field, or the gateway's failure log of a row whose
+// request failed. Totals and pages then count rows rather than calls.
+func withMergedField(filter *logFilter) *logFilter {
+	if handler, ok := registry.Get(filter.ProductSlug); ok {
+		filter.MergedField = handler.MergedField
 	}
 
-	return []string{
-		nik,
-		phoneNumber,
-		strconv.Itoa(queryCount),
-		d.Status,
-		description,
-	}
+	return filter
 }
 
-func mapTaxComplianceRow(isMasked bool, d *logTransProductCatalog) []string {
-	var (
-		description, nama, alamat, status, npwp string
-	)
-
-	if d.Message != nil {
-		description = *d.Message
+// maskJobDetails masks in place the personal data fields of the product's
+// logs. The reference copy of the request is dropped as it is not masked
+// the same way.
+func maskJobDetails(productSlug string, details []*logTransProductCatalog) {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return
+	}
+
+	for _, d := range details {
+		for _, field := range handler.MaskedFields {
+			for _, values := range []map[string]any{d.Input, d.Data} {
+				if v, ok := values[field]; ok && v != nil {
+					values[field] = mask.Field(field, formatExportValue(v))
+				}
+			}
+		}
+		d.RefTransProductCatalog = nil
 	}
+}
 
-	if d.Data != nil {
-		nama = *d.Data.Nama
-		alamat = *d.Data.Alamat
-		status = *d.Data.Status
+func formatExportValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
 	}
+}
 
-	var ref refTransProductCatalog
-	if raw, err := json.Marshal(d.RefTransProductCatalog); err == nil {
-		_ = json.Unmarshal(raw, &ref)
+func dropColumns(row []string, columns []int) []string {
+	skip := make(map[int]bool, len(columns))
+	for _, c := range columns {
+		skip[c] = true
 	}
 
-	if isMasked {
-		npwp = ref.Input.NPWP
-	} else {
-		npwp = *d.Input.NPWP
+	result := make([]string, 0, len(row))
+	for i, v := range row {
+		if !skip[i] {
+			result = append(result, v)
+		}
 	}
 
-	return []string{
-		npwp,
-		nama,
-		alamat,
-		status,
-		d.Status,
-		description,
-	}
+	return result
 }
 
-func mapTaxScoreRow(isMasked bool, d *logTransProductCatalog) []string {
-	var (
-		description, nama, alamat, status, score, npwp string
-	)
-
-	if d.Message != nil {
-		description = *d.Message
+// withDecisionColumns appends the outcome of ruleSet to every row.
+func withDecisionColumns(mapper rowMapper, productSlug string, ruleSet *decision.RuleSet) rowMapper {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return mapper
 	}
 
-	if d.Data != nil {
-		nama = *d.Data.Nama
-		alamat = *d.Data.Alamat
-		status = *d.Data.Status
-		score = *d.Data.Score
-	}
+	return func(d *logTransProductCatalog) []string {
+		row := mapper(d)
+		outcome := decision.Evaluate(ruleSet, handler.RuleValues(row))
 
-	var ref refTransProductCatalog
-	if raw, err := json.Marshal(d.RefTransProductCatalog); err == nil {
-		_ = json.Unmarshal(raw, &ref)
+		return append(row, outcome.Columns()...)
 	}
+}
+
+// nikField is the input field holding the NIK of products that take one.
+const nikField = "nik"
 
+// withNIKColumns appends the demographics decoded from the row's unmasked
+// input NIK, with the birth date cut to its year when the export is masked.
+func withNIKColumns(mapper rowMapper, isMasked bool) rowMapper {
+	nikColumns := identity.NIKColumns
 	if isMasked {
-		npwp = ref.Input.NPWP
-	} else {
-		npwp = *d.Input.NPWP
+		nikColumns = identity.MaskedNIKColumns
 	}
 
-	return []string{
-		npwp,
-		nama,
-		alamat,
-		status,
-		score,
-		d.Status,
-		description,
+	return func(d *logTransProductCatalog) []string {
+		nik, _ := d.Input[nikField].(string)
+
+		return append(mapper(d), nikColumns(nik)...)
 	}
 }
 
-func mapTaxVerificationRow(isMasked bool, d *logTransProductCatalog) []string {
-	var (
-		description, nama, alamat, status, npwpVerification, taxCompliance, npwpOrNIK string
-	)
-
-	if d.Message != nil {
-		description = *d.Message
+func hasNIKInput(productSlug string) bool {
+	handler, ok := registry.Get(productSlug)
+	if !ok {
+		return false
 	}
 
-	if d.Data != nil {
-		nama = *d.Data.Nama
-		alamat = *d.Data.Alamat
-		npwpVerification = *d.Data.NPWPVerification
-		// npwp = *d.Data.NPWP
-		status = *d.Data.Status
-		taxCompliance = *d.Data.TaxCompliance
+	for _, column := range handler.ExportColumns {
+		if column.Source == registry.SourceInput && column.Field == nikField {
+			return true
+		}
 	}
 
-	var ref refTransProductCatalog
-	if raw, err := json.Marshal(d.RefTransProductCatalog); err == nil {
-		_ = json.Unmarshal(raw, &ref)
+	return false
+}
+
+func withDateColumn(mapper rowMapper) rowMapper {
+	return func(d *logTransProductCatalog) []string {
+		row := mapper(d)
+		date := d.DateTime
+
+		return append([]string{date}, row...)
 	}
+}
 
-	if isMasked {
-		// if ref.Data.NPWP != "" {
-		// 	npwp = ref.Data.NPWP
-		// }
-
-		npwpOrNIK = ref.Input.NPWPOrNIK
-	} else {
-		npwpOrNIK = *d.Input.NPWPOrNIK
-	}
-
-	return []string{
-		nama,
-		alamat,
-		npwpOrNIK,
-		// npwp,
-		npwpVerification,
-		status,
-		taxCompliance,
-		d.Status,
-		description,
+func withRowNumberColumn(mapper rowMapper) rowMapper {
+	return func(d *logTransProductCatalog) []string {
+		row := mapper(d)
+		rowNumber := ""
+		if d.RowNumber > 0 {
+			rowNumber = strconv.Itoa(d.RowNumber)
+		}
+
+		return append([]string{rowNumber}, row...)
 	}
 }
@@ -1,40 +1,42 @@
 package job
 
 import (
+	"bytes"
+	"front-office/internal/datahub/compliance/loanrecordchecker"
+	"front-office/internal/datahub/compliance/multipleloan"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/incometax/taxverificationdetail"
+	"front-office/internal/datahub/registry"
 	"front-office/pkg/common/constant"
 	"front-office/pkg/helper"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMapLoanRecordCheckerRow(t *testing.T) {
-	t.Run("should map all fields correctly when isMasked is true", func(t *testing.T) {
+func TestMapExportRow(t *testing.T) {
+	handler := loanrecordchecker.NewHandler(nil)
+
+	t.Run("should mask personal data when isMasked is true", func(t *testing.T) {
 		message := "Succeed"
-		result := mapLoanRecordCheckerRow(true, &logTransProductCatalog{
-			Input: &logTransInput{
-				Name:        helper.StringPtr(constant.DummyName),
-				NIK:         helper.StringPtr("unmasked-nik"),
-				PhoneNumber: helper.StringPtr("unmasked-phone"),
+		result := mapExportRow(handler, true, &logTransProductCatalog{
+			Input: map[string]any{
+				"name":         constant.DummyName,
+				"nik":          constant.DummyNIK,
+				"phone_number": "081234567890",
 			},
-			Data: &logTransData{
-				Remarks: helper.StringPtr("-"),
-				Status:  helper.StringPtr(""),
+			Data: map[string]any{
+				"remarks": "-",
+				"status":  "",
 			},
 			Message: &message,
-			RefTransProductCatalog: map[string]interface{}{
-				"input": map[string]interface{}{
-					"name":         constant.DummyName,
-					"nik":          constant.DummyNIK,
-					"phone_number": constant.DummyPhoneNumber,
-				},
-			},
 		})
 
 		expected := []string{
-			constant.DummyName,
-			constant.DummyNIK,
-			constant.DummyPhoneNumber,
+			"j*** d**",
+			"123456******3456",
+			"0812*****890",
 			"-",
 			"",
 			"",
@@ -45,15 +47,15 @@ func TestMapLoanRecordCheckerRow(t *testing.T) {
 
 	t.Run("should map all fields correctly when isMasked is false", func(t *testing.T) {
 		message := "Succeed"
-		result := mapLoanRecordCheckerRow(false, &logTransProductCatalog{
-			Input: &logTransInput{
-				Name:        helper.StringPtr(constant.DummyName),
-				NIK:         helper.StringPtr(constant.DummyNIK),
-				PhoneNumber: helper.StringPtr(constant.DummyPhoneNumber),
+		result := mapExportRow(handler, false, &logTransProductCatalog{
+			Input: map[string]any{
+				"name":         constant.DummyName,
+				"nik":          constant.DummyNIK,
+				"phone_number": constant.DummyPhoneNumber,
 			},
-			Data: &logTransData{
-				Remarks: helper.StringPtr("-"),
-				Status:  helper.StringPtr(""),
+			Data: map[string]any{
+				"remarks": "-",
+				"status":  "",
 			},
 			Message: &message,
 		})
@@ -69,4 +71,294 @@ func TestMapLoanRecordCheckerRow(t *testing.T) {
 		}
 		assert.Equal(t, expected, result)
 	})
+
+	t.Run("should format numeric data without decimals", func(t *testing.T) {
+		result := mapExportRow(multipleloan.NewHandlers(nil)[0], false, &logTransProductCatalog{
+			Input:  map[string]any{"nik": constant.DummyNIK, "phone_number": constant.DummyPhoneNumber},
+			Data:   map[string]any{"query_count": float64(3)},
+			Status: "success",
+		})
+
+		assert.Equal(t, []string{constant.DummyNIK, constant.DummyPhoneNumber, "3", "success", ""}, result)
+	})
+}
+
+func TestMapExportRowFallback(t *testing.T) {
+	handler := &registry.ProductHandler{
+		ExportColumns: []registry.ExportColumn{
+			{Header: "Operator", Source: registry.SourceData, Field: "operator", Fallback: func(input map[string]any) string {
+				return "detected"
+			}},
+		},
+	}
+
+	t.Run("should derive the value when the data leaves it empty", func(t *testing.T) {
+		result := mapExportRow(handler, false, &logTransProductCatalog{Data: map[string]any{}})
+		assert.Equal(t, []string{"detected"}, result)
+	})
+
+	t.Run("should keep the value returned by the partner", func(t *testing.T) {
+		result := mapExportRow(handler, false, &logTransProductCatalog{Data: map[string]any{"operator": "Indosat"}})
+		assert.Equal(t, []string{"Indosat"}, result)
+	})
+}
+
+func TestWithMergedField(t *testing.T) {
+	for _, handler := range multipleloan.NewHandlers(nil) {
+		registry.Register(handler)
+	}
+
+	t.Run("should ask for one log per row of a merged product", func(t *testing.T) {
+		filter := withMergedField(&logFilter{ProductSlug: constant.SlugMultipleLoanAll})
+		assert.Equal(t, "velocity", filter.MergedField)
+	})
+
+	t.Run("should leave other products untouched", func(t *testing.T) {
+		filter := withMergedField(&logFilter{ProductSlug: constant.SlugMultipleLoan7Days})
+		assert.Empty(t, filter.MergedField)
+	})
+}
+
+func TestWithRowNumberColumn(t *testing.T) {
+	mapper := withRowNumberColumn(func(d *logTransProductCatalog) []string {
+		return []string{d.Status}
+	})
+
+	t.Run("should prepend the source row number", func(t *testing.T) {
+		result := mapper(&logTransProductCatalog{Status: "success", RowNumber: 5})
+		assert.Equal(t, []string{"5", "success"}, result)
+	})
+
+	t.Run("should leave row number empty when it is not recorded", func(t *testing.T) {
+		result := mapper(&logTransProductCatalog{Status: "success"})
+		assert.Equal(t, []string{"", "success"}, result)
+	})
+}
+
+func TestWithNIKColumns(t *testing.T) {
+	mapper := withNIKColumns(func(d *logTransProductCatalog) []string {
+		return []string{d.Status}
+	}, false)
+
+	t.Run("should append the demographics of the input NIK", func(t *testing.T) {
+		result := mapper(&logTransProductCatalog{Input: map[string]any{"nik": "3578015205900001"}, Status: "success"})
+		assert.Equal(t, []string{"success", "Jawa Timur", "Kota Surabaya", "1990-05-12", "female"}, result)
+	})
+
+	t.Run("should leave the columns empty when the NIK cannot be decoded", func(t *testing.T) {
+		result := mapper(&logTransProductCatalog{Input: map[string]any{"nik": "-"}, Status: "success"})
+		assert.Equal(t, []string{"success", "", "", "", ""}, result)
+	})
+
+	t.Run("should keep only the birth year when the export is masked", func(t *testing.T) {
+		masked := withNIKColumns(func(d *logTransProductCatalog) []string {
+			return []string{d.Status}
+		}, true)
+
+		result := masked(&logTransProductCatalog{Input: map[string]any{"nik": "3578015205900001"}, Status: "success"})
+		assert.Equal(t, []string{"success", "Jawa Timur", "Kota Surabaya", "1990", "female"}, result)
+	})
+}
+
+func TestHasNIKInput(t *testing.T) {
+	registry.Register(loanrecordchecker.NewHandler(nil))
+	registry.Register(taxverificationdetail.NewHandler(nil))
+
+	assert.True(t, hasNIKInput(constant.SlugLoanRecordChecker))
+	assert.False(t, hasNIKInput(constant.SlugTaxVerificationDetail))
+}
+
+func TestWithDecisionColumns(t *testing.T) {
+	registry.Register(loanrecordchecker.NewHandler(nil))
+	_, mapper, _ := exportLayout(constant.SlugLoanRecordChecker, false)
+
+	mapper = withDecisionColumns(mapper, constant.SlugLoanRecordChecker, &decision.RuleSet{
+		Rules: []*decision.Rule{{
+			Name:       "bad record",
+			Decision:   "reject",
+			Conditions: []*decision.Condition{{Field: "loan-record-checker.status", Operator: decision.OpEqual, Value: "bad"}},
+		}},
+	})
+
+	result := mapper(&logTransProductCatalog{
+		Input:  map[string]any{"name": constant.DummyName},
+		Data:   map[string]any{"status": "bad"},
+		Status: "success",
+	})
+
+	assert.Equal(t, []string{"reject", "bad record"}, result[len(result)-2:])
+}
+
+func TestDropColumns(t *testing.T) {
+	registry.Register(taxverificationdetail.NewHandler(nil))
+	headers, _, inputColumns := exportLayout(constant.SlugTaxVerificationDetail, false)
+
+	assert.Equal(t, []string{"Nama", "Alamat", "NPWP Verification", "Data Status", "Tax Compliance", "Status", "Description"}, dropColumns(headers, inputColumns))
+}
+
+func TestFormatEnrichedFileName(t *testing.T) {
+	assert.Equal(t, "applicants_enriched.csv", formatEnrichedFileName("applicants.csv", "7"))
+	assert.Equal(t, "job_detail_id_7_enriched.csv", formatEnrichedFileName("", "7"))
+}
+
+func TestDiffJobRows(t *testing.T) {
+	handler := multipleloan.NewHandlers(nil)[0]
+	row := func(rowNumber int, nik string, queryCount float64) *logTransProductCatalog {
+		return &logTransProductCatalog{
+			Input:     map[string]any{"nik": nik, "phone_number": constant.DummyPhoneNumber},
+			Data:      map[string]any{"query_count": queryCount},
+			Status:    "success",
+			RowNumber: rowNumber,
+		}
+	}
+
+	base := []*logTransProductCatalog{row(1, "1", 1), row(2, "2", 2), row(3, "3", 3)}
+	compare := []*logTransProductCatalog{row(1, "1", 1), row(2, "2", 5), row(3, "4", 0)}
+
+	result := diffJobRows(handler, base, compare, false)
+
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Removed)
+	assert.Equal(t, 1, result.Changed)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Len(t, result.Rows, 3)
+
+	assert.Equal(t, DiffChanged, result.Rows[0].Change)
+	assert.Equal(t, []string{"2", constant.DummyPhoneNumber}, result.Rows[0].Key)
+	assert.Equal(t, "query_count", result.Rows[0].Fields[0].Field)
+	assert.Equal(t, "2", result.Rows[0].Fields[0].Previous)
+	assert.Equal(t, "5", result.Rows[0].Fields[0].Current)
+
+	assert.Equal(t, DiffAdded, result.Rows[1].Change)
+	assert.Equal(t, []string{"4", constant.DummyPhoneNumber}, result.Rows[1].Key)
+
+	assert.Equal(t, DiffRemoved, result.Rows[2].Change)
+	assert.Equal(t, []string{"3", constant.DummyPhoneNumber}, result.Rows[2].Key)
+}
+
+func TestNormalizeCompanyJobFilter(t *testing.T) {
+	for _, handler := range multipleloan.NewHandlers(nil) {
+		registry.Register(handler)
+	}
+
+	t.Run("should default the sort and size and map route slugs", func(t *testing.T) {
+		filter := &companyJobFilter{ProductSlugs: []string{"all-multiple-loan"}}
+
+		assert.NoError(t, normalizeCompanyJobFilter(filter))
+		assert.Equal(t, constant.CreatedAt, filter.SortBy)
+		assert.Equal(t, constant.SortDesc, filter.SortOrder)
+		assert.Equal(t, defaultCompanyJobsSize, filter.Size)
+		assert.Equal(t, []string{constant.SlugMultipleLoanAll}, filter.ProductSlugs)
+	})
+
+	t.Run("should reject unknown filters", func(t *testing.T) {
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{ProductSlugs: []string{"unknown"}}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{Statuses: []string{"unknown"}}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{SortBy: "status"}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{Size: maxCompanyJobsSize + 1}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{StartDate: "01-02-2024"}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{StartDate: "2024-02-02", EndDate: "2024-02-01"}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{FilterMember: "me"}))
+	})
+
+	t.Run("should decode a cursor issued for the same sort", func(t *testing.T) {
+		cursor := encodeJobCursor(&jobCursor{SortBy: "total", SortOrder: constant.SortAsc, Value: "5", Id: 3})
+
+		filter := &companyJobFilter{SortBy: "total", SortOrder: constant.SortAsc, Cursor: cursor}
+		assert.NoError(t, normalizeCompanyJobFilter(filter))
+		assert.Equal(t, &jobCursor{SortBy: "total", SortOrder: constant.SortAsc, Value: "5", Id: 3}, filter.After)
+
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{Cursor: cursor}))
+		assert.Error(t, normalizeCompanyJobFilter(&companyJobFilter{Cursor: "not-a-cursor"}))
+	})
+}
+
+func TestPageCompanyJobs(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	resp := func() *companyJobsRespData {
+		return &companyJobsRespData{
+			Jobs: []*companyJob{
+				{Id: 9, CreatedAt: createdAt.Add(2 * time.Hour)},
+				{Id: 8, CreatedAt: createdAt.Add(time.Hour)},
+				{Id: 7, CreatedAt: createdAt},
+			},
+			StatusTotals: map[string]int64{constant.JobStatusDone: 4, constant.JobStatusFailed: 1},
+		}
+	}
+	filter := &companyJobFilter{SortBy: constant.CreatedAt, SortOrder: constant.SortDesc, Size: 2}
+
+	t.Run("should trim the extra job and continue after the last kept", func(t *testing.T) {
+		page := pageCompanyJobs(resp(), filter)
+
+		assert.Len(t, page.Jobs, 2)
+		assert.True(t, page.HasMore)
+		assert.Equal(t, int64(5), page.Total)
+
+		cursor, err := decodeJobCursor(page.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), cursor.Id)
+		assert.Equal(t, createdAt.Add(time.Hour).Format(time.RFC3339Nano), cursor.Value)
+	})
+
+	t.Run("should not issue a cursor on the last page", func(t *testing.T) {
+		page := pageCompanyJobs(resp(), &companyJobFilter{SortBy: constant.CreatedAt, SortOrder: constant.SortDesc, Size: 3})
+
+		assert.Len(t, page.Jobs, 3)
+		assert.False(t, page.HasMore)
+		assert.Empty(t, page.NextCursor)
+	})
+}
+
+func TestMaskedEnrichedExport(t *testing.T) {
+	handler := loanrecordchecker.NewHandler(nil)
+	details := []*logTransProductCatalog{{
+		Input:     map[string]any{"name": constant.DummyName, "nik": constant.DummyNIK, "phone_number": constant.DummyPhoneNumber},
+		Data:      map[string]any{"remarks": "-", "status": "bad"},
+		Status:    "success",
+		RowNumber: 2,
+	}}
+
+	export := func(source *JobSource) string {
+		results := jobResults(handler, details, true)
+
+		var buf bytes.Buffer
+		err := helper.WriteEnrichedCSV(&buf, source.MaskedRecords(handler), results.Headers, results.Rows)
+		assert.NoError(t, err)
+
+		return buf.String()
+	}
+
+	t.Run("should mask the source columns mapped to masked fields and drop unmapped ones", func(t *testing.T) {
+		result := export(&JobSource{
+			Records: [][]string{
+				{"Ref", "KTP", "HP", "Nama"},
+				{"A-1", constant.DummyNIK, constant.DummyPhoneNumber, constant.DummyName},
+			},
+			Fields: []string{"", "nik", "phone_number", "name"},
+		})
+
+		assert.NotContains(t, result, constant.DummyNIK)
+		assert.NotContains(t, result, constant.DummyPhoneNumber)
+		assert.NotContains(t, result, constant.DummyName)
+		assert.NotContains(t, result, "Ref")
+		assert.NotContains(t, result, "A-1")
+		assert.Contains(t, result, "bad")
+	})
+
+	t.Run("should match template headers of sources saved without fields", func(t *testing.T) {
+		result := export(&JobSource{
+			Records: [][]string{
+				helper.CSVHeaders(handler.Columns),
+				{constant.DummyName, constant.DummyNIK, constant.DummyPhoneNumber},
+			},
+		})
+
+		assert.NotContains(t, result, constant.DummyNIK)
+		assert.NotContains(t, result, constant.DummyPhoneNumber)
+	})
+
+	t.Run("should keep decision rule values unmasked", func(t *testing.T) {
+		results := jobResults(handler, details, true)
+		assert.Equal(t, "bad", results.RuleValues[2]["loan-record-checker.status"])
+	})
 }
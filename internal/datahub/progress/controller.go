@@ -0,0 +1,26 @@
+package progress
+
+import (
+	"fmt"
+	"front-office/pkg/common/constant"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController() Controller {
+	return &controller{}
+}
+
+type controller struct{}
+
+type Controller interface {
+	StreamCompanyJobs(c *fiber.Ctx) error
+}
+
+// StreamCompanyJobs streams the progress of every running job of the
+// caller's company until the client disconnects.
+func (ctrl *controller) StreamCompanyJobs(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	return Stream(c, Subscribe(companyId, ""), false)
+}
@@ -0,0 +1,229 @@
+package progress
+
+import (
+	"front-office/pkg/common/constant"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind
+// before its oldest events are dropped.
+const subscriberBuffer = 64
+
+// hub keeps the progress of running jobs in memory and fans it out to the
+// subscribed streams. One hub is shared by every job service of the process,
+// so its events only reach streams opened on the instance running the job;
+// job streams also poll the persisted job status so they end on any instance.
+type hub struct {
+	mu          sync.Mutex
+	jobs        map[string]*Progress
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of one job, or of every job of a company
+// when it was opened without a job id.
+type Subscription struct {
+	companyId string
+	jobId     string
+	events    chan *Event
+	hub       *hub
+	status    func() (*Progress, error)
+}
+
+var defaultHub = newHub()
+
+func newHub() *hub {
+	return &hub{
+		jobs:        map[string]*Progress{},
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Start begins tracking a job of total rows.
+func Start(companyId, jobId string, total int) {
+	defaultHub.start(companyId, jobId, total)
+}
+
+// Record counts one processed row of a job.
+func Record(companyId, jobId string, success bool) {
+	defaultHub.record(companyId, jobId, success)
+}
+
+// Finish sends the summary of a job with its final status and stops
+// tracking it. successCount is the count persisted on the job.
+func Finish(companyId, jobId, status string, successCount int) {
+	defaultHub.finish(companyId, jobId, status, successCount)
+}
+
+// Subscribe opens a stream of the events of jobId, or of every job of
+// companyId when jobId is empty. A job stream starts with the job's current
+// progress when it is being tracked.
+func Subscribe(companyId, jobId string) *Subscription {
+	return defaultHub.subscribe(companyId, jobId)
+}
+
+// Completed returns a closed subscription holding only the summary of a job
+// that finished before the stream was opened.
+func Completed(summary *Progress) *Subscription {
+	sub := &Subscription{
+		companyId: summary.CompanyId,
+		jobId:     summary.JobId,
+		events:    make(chan *Event, 1),
+	}
+	sub.events <- &Event{Type: EventSummary, Progress: *summary}
+	close(sub.events)
+
+	return sub
+}
+
+// Poll makes the stream read the persisted progress of its job with status
+// every pollInterval and end with it as the summary once the job is finished,
+// for jobs run by another instance whose events never reach this hub.
+func (s *Subscription) Poll(status func() (*Progress, error)) {
+	s.status = status
+}
+
+// polledSummary reads the persisted progress of the job and returns it as a
+// summary event when the job is finished.
+func (s *Subscription) polledSummary() (*Event, bool) {
+	p, err := s.status()
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", s.jobId).Msg("failed to poll job progress")
+		return nil, false
+	}
+
+	if !IsFinished(p.Status) {
+		return nil, false
+	}
+
+	return &Event{Type: EventSummary, Progress: *p}, true
+}
+
+// IsFinished reports whether a job of status will not make progress anymore.
+func IsFinished(status string) bool {
+	return status == constant.JobStatusDone || status == constant.JobStatusFailed
+}
+
+// Events returns the channel the subscription's events are delivered on. It
+// is closed once the subscription is closed or, for a completed job, after
+// the summary.
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Close unsubscribes and releases the subscription.
+func (s *Subscription) Close() {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if _, ok := s.hub.subscribers[s]; ok {
+		delete(s.hub.subscribers, s)
+		close(s.events)
+	}
+}
+
+func (h *hub) start(companyId, jobId string, total int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p := &Progress{
+		JobId:     jobId,
+		CompanyId: companyId,
+		Status:    constant.JobStatusPending,
+		Total:     total,
+	}
+	h.jobs[jobId] = p
+	h.publish(&Event{Type: EventProgress, Progress: *p})
+}
+
+func (h *hub) record(companyId, jobId string, success bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, ok := h.jobs[jobId]
+	if !ok {
+		p = &Progress{JobId: jobId, CompanyId: companyId}
+		h.jobs[jobId] = p
+	}
+
+	p.Status = constant.JobStatusInProgress
+	p.Processed++
+	if success {
+		p.Success++
+	} else {
+		p.Failed++
+	}
+
+	h.publish(&Event{Type: EventProgress, Progress: *p})
+}
+
+func (h *hub) finish(companyId, jobId, status string, successCount int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, ok := h.jobs[jobId]
+	if !ok {
+		p = &Progress{JobId: jobId, CompanyId: companyId}
+	}
+	delete(h.jobs, jobId)
+
+	// Single requests are finalized without going through Record.
+	if p.Processed < successCount {
+		p.Processed = successCount
+	}
+	p.Status = status
+	p.Success = successCount
+	p.Failed = p.Processed - successCount
+
+	h.publish(&Event{Type: EventSummary, Progress: *p})
+}
+
+func (h *hub) subscribe(companyId, jobId string) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{
+		companyId: companyId,
+		jobId:     jobId,
+		events:    make(chan *Event, subscriberBuffer),
+		hub:       h,
+	}
+	h.subscribers[sub] = struct{}{}
+
+	if p, ok := h.jobs[jobId]; ok && p.CompanyId == companyId {
+		sub.events <- &Event{Type: EventProgress, Progress: *p}
+	}
+
+	return sub
+}
+
+// publish sends event to every matching subscriber without blocking the bulk
+// workers. A subscriber whose buffer is full loses its oldest event instead,
+// so the latest progress and the summary always get through; each progress
+// event supersedes the earlier ones. Callers hold h.mu.
+func (h *hub) publish(event *Event) {
+	for sub := range h.subscribers {
+		if sub.companyId != event.CompanyId {
+			continue
+		}
+		if sub.jobId != "" && sub.jobId != event.JobId {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// only publish sends, under h.mu, so the freed slot stays free
+			select {
+			case <-sub.events:
+			default:
+			}
+			sub.events <- event
+		}
+	}
+}
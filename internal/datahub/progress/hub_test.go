@@ -0,0 +1,150 @@
+package progress
+
+import (
+	"front-office/pkg/common/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func drain(sub *Subscription) []*Event {
+	var events []*Event
+	for {
+		select {
+		case event := <-sub.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestHubJobStream(t *testing.T) {
+	h := newHub()
+	h.start(constant.DummyCompanyId, constant.DummyJobId, 3)
+
+	sub := h.subscribe(constant.DummyCompanyId, constant.DummyJobId)
+	defer sub.Close()
+
+	h.record(constant.DummyCompanyId, constant.DummyJobId, true)
+	h.record(constant.DummyCompanyId, constant.DummyJobId, false)
+	h.record(constant.DummyCompanyId, "other-job", true)
+	h.finish(constant.DummyCompanyId, constant.DummyJobId, constant.JobStatusDone, 1)
+
+	events := drain(sub)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, EventProgress, events[0].Type)
+	assert.Equal(t, constant.JobStatusPending, events[0].Status)
+	assert.Equal(t, 3, events[0].Total)
+
+	assert.Equal(t, 2, events[2].Processed)
+	assert.Equal(t, 1, events[2].Success)
+	assert.Equal(t, 1, events[2].Failed)
+
+	assert.Equal(t, EventSummary, events[3].Type)
+	assert.Equal(t, constant.JobStatusDone, events[3].Status)
+	assert.Equal(t, 1, events[3].Failed)
+	assert.NotContains(t, h.jobs, constant.DummyJobId)
+}
+
+func TestHubCompanyStream(t *testing.T) {
+	h := newHub()
+
+	sub := h.subscribe(constant.DummyCompanyId, "")
+	defer sub.Close()
+
+	h.start(constant.DummyCompanyId, "1", 1)
+	h.start(constant.DummyCompanyId, "2", 1)
+	h.start("other-company", "3", 1)
+
+	events := drain(sub)
+	require.Len(t, events, 2)
+	assert.Equal(t, "1", events[0].JobId)
+	assert.Equal(t, "2", events[1].JobId)
+}
+
+func TestHubFinishSingleRequest(t *testing.T) {
+	h := newHub()
+	h.start(constant.DummyCompanyId, constant.DummyJobId, 1)
+
+	sub := h.subscribe(constant.DummyCompanyId, constant.DummyJobId)
+	defer sub.Close()
+	drain(sub)
+
+	h.finish(constant.DummyCompanyId, constant.DummyJobId, constant.JobStatusDone, 1)
+
+	events := drain(sub)
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, events[0].Processed)
+	assert.Equal(t, 0, events[0].Failed)
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	h := newHub()
+	h.start(constant.DummyCompanyId, constant.DummyJobId, subscriberBuffer*2)
+
+	sub := h.subscribe(constant.DummyCompanyId, constant.DummyJobId)
+	defer sub.Close()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		h.record(constant.DummyCompanyId, constant.DummyJobId, true)
+	}
+	h.finish(constant.DummyCompanyId, constant.DummyJobId, constant.JobStatusDone, subscriberBuffer*2)
+
+	events := drain(sub)
+	require.Len(t, events, subscriberBuffer)
+
+	assert.Equal(t, subscriberBuffer*2, events[len(events)-2].Processed)
+	assert.Equal(t, EventSummary, events[len(events)-1].Type)
+	assert.Equal(t, constant.JobStatusDone, events[len(events)-1].Status)
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	h := newHub()
+
+	sub := h.subscribe(constant.DummyCompanyId, constant.DummyJobId)
+	sub.Close()
+	sub.Close()
+
+	h.start(constant.DummyCompanyId, constant.DummyJobId, 1)
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.Empty(t, h.subscribers)
+}
+
+func TestCompleted(t *testing.T) {
+	sub := Completed(&Progress{JobId: constant.DummyJobId, Status: constant.JobStatusFailed})
+	sub.Close()
+
+	event, ok := <-sub.Events()
+	require.True(t, ok)
+	assert.Equal(t, EventSummary, event.Type)
+
+	_, ok = <-sub.Events()
+	assert.False(t, ok)
+}
+
+func TestSubscriptionPolledSummary(t *testing.T) {
+	h := newHub()
+
+	sub := h.subscribe(constant.DummyCompanyId, constant.DummyJobId)
+	defer sub.Close()
+
+	status := constant.JobStatusInProgress
+	sub.Poll(func() (*Progress, error) {
+		return &Progress{JobId: constant.DummyJobId, Status: status, Total: 2, Processed: 2, Success: 1, Failed: 1}, nil
+	})
+
+	_, ok := sub.polledSummary()
+	assert.False(t, ok)
+
+	status = constant.JobStatusDone
+	event, ok := sub.polledSummary()
+	require.True(t, ok)
+	assert.Equal(t, EventSummary, event.Type)
+	assert.Equal(t, constant.JobStatusDone, event.Status)
+	assert.Equal(t, 1, event.Failed)
+}
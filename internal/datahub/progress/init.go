@@ -0,0 +1,13 @@
+package progress
+
+import (
+	"front-office/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router) {
+	controller := NewController()
+
+	apiGroup.Get("/stream", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.StreamCompanyJobs)
+}
@@ -0,0 +1,26 @@
+package progress
+
+// Event types sent on a progress stream.
+const (
+	EventProgress = "progress"
+	EventSummary  = "summary"
+)
+
+// Progress is the running tally of one job. Failed counts rows that were
+// processed without a successful product response.
+type Progress struct {
+	JobId     string `json:"job_id"`
+	CompanyId string `json:"-"`
+	Status    string `json:"status"`
+	Total     int    `json:"total"`
+	Processed int    `json:"processed"`
+	Success   int    `json:"success"`
+	Failed    int    `json:"failed"`
+}
+
+// Event is one message of a progress stream. Summary events are sent once,
+// when the job is finalized.
+type Event struct {
+	Type string `json:"type"`
+	Progress
+}
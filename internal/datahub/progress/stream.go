@@ -0,0 +1,88 @@
+package progress
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// heartbeatInterval keeps idle streams from being closed by proxies.
+	heartbeatInterval = 15 * time.Second
+	// pollInterval is how often a polled job stream reads the job status.
+	pollInterval = 5 * time.Second
+)
+
+// Stream writes the events of sub to c as Server-Sent Events until the client
+// disconnects or, when untilSummary is set, the job's summary has been sent.
+// A polled stream also ends once its job is found finished. sub is closed
+// when the stream ends.
+func Stream(c *fiber.Ctx, sub *Subscription, untilSummary bool) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		var poll <-chan time.Time
+		if sub.status != nil {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			poll = ticker.C
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+
+				if err := writeEvent(w, event); err != nil {
+					return
+				}
+
+				if untilSummary && event.Type == EventSummary {
+					return
+				}
+			case <-poll:
+				if summary, ok := sub.polledSummary(); ok {
+					_ = writeEvent(w, summary)
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeEvent(w *bufio.Writer, event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", event.JobId).Msg("failed to encode progress event")
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
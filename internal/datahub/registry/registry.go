@@ -0,0 +1,351 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Route groups the datahub products are served under.
+const (
+	GroupCompliance = "compliance"
+	GroupIncomeTax  = "incometax"
+	GroupIdentity   = "identity"
+)
+
+// ColumnSource tells an export column where in a transaction log its value lives.
+type ColumnSource int
+
+const (
+	SourceInput ColumnSource = iota
+	SourceData
+	SourceStatus
+	SourceMessage
+)
+
+// ExportColumn is one column of a job export. Field is the JSON key inside the
+// log input or data; Format, when set, post-processes the raw value. Fallback,
+// when set, derives the value from the log input when the source left it
+// empty. Name is the column's key in decision rules and defaults to Field.
+type ExportColumn struct {
+	Header   string
+	Source   ColumnSource
+	Field    string
+	Name     string
+	Format   func(value string) string
+	Fallback func(input map[string]any) string
+}
+
+// CallParams identifies the caller and job of a single upstream request.
+type CallParams struct {
+	APIKey    string
+	JobId     string
+	MemberId  string
+	CompanyId string
+}
+
+// ProductHandler describes a datahub product so that the generic single,
+// bulk, job export and template endpoints can serve it without product code.
+type ProductHandler struct {
+	Slug        string
+	RouteSlug   string
+	Group       string
+	Name        string
+	TemplateDir string
+
+	// Columns are the request fields in CSV template order.
+	Columns []helper.CSVColumn
+	// NewRequest returns a pointer to an empty request of the product.
+	NewRequest func() any
+	// Call sends one request, as returned by NewRequest, to the product catalog.
+	Call func(params *CallParams, req any) (*model.ProCatAPIResponse[any], error)
+	// MapError converts a failed Call into an application error. Defaults to
+	// apperror.MapRepoError.
+	MapError func(err error, context string) error
+
+	ExportColumns []ExportColumn
+	// MaskedFields are the input and data fields holding personal data, masked
+	// with package mask when the masking policy masks the request.
+	MaskedFields []string
+	// MergedField is set for products whose request fans out to several
+	// product catalog calls, each logged as its own transaction. Call then
+	// returns data implementing MergedResult, which is stored on every one of
+	// those transactions; MergedField names a data field only it holds. Job
+	// details of the product are listed by Aifcore one log per row on it.
+	MergedField string
+	// Calls is the number of product catalog calls one request makes, each
+	// billed and charged to the quota on its own. Defaults to 1.
+	Calls int
+	// Distribution returns the dashboard bucket of a transaction's data, e.g.
+	// its remarks. Empty leaves the transaction out of the distribution.
+	Distribution func(data map[string]any) string
+}
+
+// FieldDistribution buckets transactions by the value of a data field.
+func FieldDistribution(field string) func(data map[string]any) string {
+	return func(data map[string]any) string {
+		if v, ok := data[field].(string); ok {
+			return v
+		}
+
+		return ""
+	}
+}
+
+// MergedResult is the data returned by the Call of a handler with a
+// MergedField.
+type MergedResult interface {
+	// TransactionIds are the transactions logged by the upstream calls.
+	TransactionIds() []string
+}
+
+var (
+	mu       sync.RWMutex
+	handlers = map[string]*ProductHandler{}
+)
+
+// Register adds h to the registry, replacing any handler with the same slug.
+func Register(h *ProductHandler) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	handlers[h.Slug] = h
+}
+
+// Get returns the handler registered for a product catalog slug.
+func Get(slug string) (*ProductHandler, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	h, ok := handlers[slug]
+	return h, ok
+}
+
+// GetByRouteSlug returns the handler served under routeSlug, e.g. "tax-score".
+func GetByRouteSlug(routeSlug string) (*ProductHandler, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, h := range handlers {
+		if h.RouteSlug == routeSlug {
+			return h, true
+		}
+	}
+
+	return nil, false
+}
+
+// All returns every registered handler ordered by slug.
+func All() []*ProductHandler {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	result := make([]*ProductHandler, 0, len(handlers))
+	for _, h := range handlers {
+		result = append(result, h)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Slug < result[j].Slug
+	})
+
+	return result
+}
+
+// RequestFromRow builds a product request from a CSV row laid out in
+// h.Columns order.
+func (h *ProductHandler) RequestFromRow(row []string) (any, error) {
+	fields := make(map[string]string, len(h.Columns))
+	for i, column := range h.Columns {
+		if i < len(row) {
+			fields[column.Field] = row[i]
+		}
+	}
+
+	return h.RequestFromFields(fields)
+}
+
+// HasInputs reports whether fields holds a value for every request column of
+// the product.
+func (h *ProductHandler) HasInputs(fields map[string]string) bool {
+	for _, column := range h.Columns {
+		if fields[column.Field] == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequestFromFields builds a product request from input values keyed by
+// field name. Fields the product does not take are ignored.
+func (h *ProductHandler) RequestFromFields(fields map[string]string) (any, error) {
+	values := make(map[string]string, len(h.Columns))
+	for _, column := range h.Columns {
+		if v, ok := fields[column.Field]; ok {
+			values[column.Field] = v
+		}
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	req := h.NewRequest()
+	if err := json.Unmarshal(raw, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ExportHeaders returns the export column headers and the positions of the
+// columns that only echo request input.
+func (h *ProductHandler) ExportHeaders() ([]string, []int) {
+	headers := make([]string, len(h.ExportColumns))
+	var inputColumns []int
+
+	for i, column := range h.ExportColumns {
+		headers[i] = column.Header
+		if column.Source == SourceInput {
+			inputColumns = append(inputColumns, i)
+		}
+	}
+
+	return headers, inputColumns
+}
+
+// RuleFields returns the decision rule key of every export column, in export
+// order, as "<route slug>.<name>". The request status is keyed
+// "request_status" so it cannot clash with a data field named status.
+// Columns without a key are left empty.
+func (h *ProductHandler) RuleFields() []string {
+	fields := make([]string, len(h.ExportColumns))
+	for i, column := range h.ExportColumns {
+		name := column.Name
+		switch {
+		case column.Source == SourceStatus:
+			name = "request_status"
+		case column.Source == SourceMessage:
+			continue
+		case name == "":
+			name = column.Field
+		}
+
+		fields[i] = h.RouteSlug + "." + name
+	}
+
+	return fields
+}
+
+// RuleValues keys an export row of the handler by decision rule field.
+func (h *ProductHandler) RuleValues(row []string) map[string]string {
+	values := make(map[string]string, len(row))
+	for i, field := range h.RuleFields() {
+		if field != "" && i < len(row) {
+			values[field] = row[i]
+		}
+	}
+
+	return values
+}
+
+// DataValues keys the data columns of a product response by decision rule
+// field, formatted as they are exported.
+func (h *ProductHandler) DataValues(data any) map[string]string {
+	var fields map[string]any
+	if raw, err := json.Marshal(data); err == nil {
+		_ = json.Unmarshal(raw, &fields)
+	}
+
+	ruleFields := h.RuleFields()
+	values := make(map[string]string, len(h.ExportColumns))
+	for i, column := range h.ExportColumns {
+		if column.Source != SourceData {
+			continue
+		}
+
+		value := ""
+		switch v := fields[column.Field].(type) {
+		case nil:
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			value = fmt.Sprintf("%v", v)
+		}
+
+		if column.Format != nil {
+			value = column.Format(value)
+		}
+
+		values[ruleFields[i]] = value
+	}
+
+	return values
+}
+
+// CallCount returns the number of product catalog calls one request makes.
+func (h *ProductHandler) CallCount() int {
+	if h.Calls > 0 {
+		return h.Calls
+	}
+
+	return 1
+}
+
+// IsMaskedField reports whether field holds personal data to be masked.
+func (h *ProductHandler) IsMaskedField(field string) bool {
+	for _, f := range h.MaskedFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WrapCall adapts a typed product catalog call to ProductHandler.Call.
+func WrapCall[Req any, Resp any](call func(params *CallParams, req *Req) (*model.ProCatAPIResponse[Resp], error)) func(*CallParams, any) (*model.ProCatAPIResponse[any], error) {
+	return func(params *CallParams, req any) (*model.ProCatAPIResponse[any], error) {
+		typed, ok := req.(*Req)
+		if !ok {
+			return nil, errors.New("unexpected request type")
+		}
+
+		result, err := call(params, typed)
+		if result == nil {
+			return nil, err
+		}
+
+		return &model.ProCatAPIResponse[any]{
+			Success:         result.Success,
+			Data:            result.Data,
+			Input:           result.Input,
+			Message:         result.Message,
+			StatusCode:      result.StatusCode,
+			PricingStrategy: result.PricingStrategy,
+			TransactionId:   result.TransactionId,
+			Date:            result.Date,
+		}, err
+	}
+}
+
+// MapPartnerError maps failures of products backed by external data partners,
+// which report partner outages with a dedicated status code.
+func MapPartnerError(err error, context string) error {
+	var apiErr *apperror.ExternalAPIError
+	if errors.As(err, &apiErr) {
+		return apperror.MapLoanError(apiErr)
+	}
+
+	return apperror.Internal(context, err)
+}
@@ -0,0 +1,125 @@
+package schedule
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	CreateSchedule(c *fiber.Ctx) error
+	GetSchedules(c *fiber.Ctx) error
+	GetSchedule(c *fiber.Ctx) error
+	PauseSchedule(c *fiber.Ctx) error
+	ResumeSchedule(c *fiber.Ctx) error
+	DeleteSchedule(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) CreateSchedule(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*createScheduleRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	result, err := ctrl.svc.CreateSchedule(memberId, companyId, reqBody, file)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to create job schedule",
+		result,
+	))
+}
+
+func (ctrl *controller) GetSchedules(c *fiber.Ctx) error {
+	filter := &scheduleFilter{
+		MemberId:  fmt.Sprintf("%v", c.Locals(constant.UserId)),
+		CompanyId: fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		Page:      c.Query(constant.Page, "1"),
+		Size:      c.Query(constant.Size, "10"),
+	}
+
+	result, err := ctrl.svc.GetSchedules(filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+func (ctrl *controller) GetSchedule(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetSchedule(memberId, companyId, c.Params("schedule_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get job schedule",
+		result,
+	))
+}
+
+func (ctrl *controller) PauseSchedule(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.PauseSchedule(memberId, companyId, c.Params("schedule_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to pause job schedule",
+		nil,
+	))
+}
+
+func (ctrl *controller) ResumeSchedule(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.ResumeSchedule(memberId, companyId, c.Params("schedule_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to resume job schedule",
+		nil,
+	))
+}
+
+func (ctrl *controller) DeleteSchedule(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.DeleteSchedule(memberId, companyId, c.Params("schedule_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to delete job schedule",
+		nil,
+	))
+}
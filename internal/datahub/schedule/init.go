@@ -0,0 +1,51 @@
+package schedule
+
+import (
+	"front-office/configs/application"
+	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/quota"
+	"front-office/internal/core/subscription"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/decision"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/job"
+	"front-office/internal/middleware"
+	"front-office/pkg/httpclient"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient.HTTPClient) {
+	repository := NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	productRepo := product.NewRepository(cfg, client)
+	jobRepo := job.NewRepository(cfg, client, nil)
+	transactionRepo := transaction.NewRepository(cfg, client, nil)
+	columnMappingRepo := columnmapping.NewRepository(cfg, client, nil)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	webhookRepo := webhook.NewRepository(cfg, client, nil)
+	quotaRepo := quota.NewRepository(cfg, client, nil)
+	subscriptionRepo := subscription.NewRepository(cfg, client)
+
+	decisionService := decision.NewService(decisionRepo)
+	webhookService := webhook.NewService(webhookRepo)
+	quotaService := quota.NewService(quotaRepo, memberRepo)
+	subscriptionService := subscription.NewService(subscriptionRepo, memberRepo)
+	jobService := job.NewService(jobRepo, transactionRepo, decisionService, webhookService, quotaService)
+	columnMappingService := columnmapping.NewService(columnMappingRepo)
+	gatewayService := gateway.NewService(productRepo, transactionRepo, jobService, columnMappingService, quotaService)
+	service := NewService(repository, memberRepo, gatewayService, columnMappingService, subscriptionService)
+	controller := NewController(service)
+
+	StartRunner(service)
+
+	apiGroup.Post("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(createScheduleRequest{}), controller.CreateSchedule)
+	apiGroup.Get("/", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetSchedules)
+	apiGroup.Get("/:schedule_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetSchedule)
+	apiGroup.Put("/:schedule_id/pause", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.PauseSchedule)
+	apiGroup.Put("/:schedule_id/resume", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ResumeSchedule)
+	apiGroup.Delete("/:schedule_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.DeleteSchedule)
+}
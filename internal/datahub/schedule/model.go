@@ -0,0 +1,84 @@
+package schedule
+
+import "time"
+
+// Schedule states. A paused schedule keeps its input set but is skipped by
+// the runner until it is resumed.
+const (
+	StatusActive = "active"
+	StatusPaused = "paused"
+)
+
+// defaultTimezone is used when a schedule is created without one.
+const defaultTimezone = "Asia/Jakarta"
+
+// Schedule re-runs a saved input set through a product as a new bulk job of
+// its member every time CronExpression fires in Timezone.
+type Schedule struct {
+	Id             uint       `json:"id"`
+	CompanyId      uint       `json:"company_id"`
+	MemberId       uint       `json:"member_id"`
+	Name           string     `json:"name"`
+	ProductSlug    string     `json:"product_slug"`
+	CronExpression string     `json:"cron_expression"`
+	Timezone       string     `json:"timezone"`
+	Status         string     `json:"status"`
+	FileName       string     `json:"file_name"`
+	Total          int        `json:"total"`
+	NextRunAt      *time.Time `json:"next_run_at"`
+	LastRunAt      *time.Time `json:"last_run_at"`
+	LastJobId      string     `json:"last_job_id"`
+	LastRunStatus  string     `json:"last_run_status"`
+	LastError      string     `json:"last_error"`
+	CreatedAt      string     `json:"created_at"`
+}
+
+// Input is the saved input set of a schedule: the upload as sent and its
+// rows in the product's column order.
+type Input struct {
+	FileName string     `json:"file_name"`
+	Source   [][]string `json:"source"`
+	Records  [][]string `json:"records"`
+	Fields   []string   `json:"fields,omitempty"`
+}
+
+// createScheduleRequest is sent as multipart form fields next to the file.
+type createScheduleRequest struct {
+	Name           string `json:"name" form:"name" validate:"required~name is required"`
+	ProductSlug    string `json:"product_slug" form:"product_slug" validate:"required~product_slug is required"`
+	CronExpression string `json:"cron_expression" form:"cron_expression" validate:"required~cron_expression is required"`
+	Timezone       string `json:"timezone" form:"timezone"`
+	MappingId      string `json:"mapping_id" form:"mapping_id"`
+	Mapping        string `json:"mapping" form:"mapping"`
+}
+
+type createSchedulePayload struct {
+	CompanyId      string     `json:"company_id"`
+	MemberId       string     `json:"member_id"`
+	Name           string     `json:"name"`
+	ProductSlug    string     `json:"product_slug"`
+	CronExpression string     `json:"cron_expression"`
+	Timezone       string     `json:"timezone"`
+	Status         string     `json:"status"`
+	Total          int        `json:"total"`
+	NextRunAt      *time.Time `json:"next_run_at"`
+	Input          *Input     `json:"input"`
+}
+
+// claimPayload moves a due schedule to its next run, provided no other
+// instance has moved it since it was read.
+type claimPayload struct {
+	ExpectedNextRunAt *time.Time             `json:"expected_next_run_at"`
+	Data              map[string]interface{} `json:"data"`
+}
+
+type claimResult struct {
+	Claimed bool `json:"claimed"`
+}
+
+type scheduleFilter struct {
+	MemberId  string
+	CompanyId string
+	Page      string
+	Size      string
+}
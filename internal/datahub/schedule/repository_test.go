@@ -0,0 +1,210 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallCreateScheduleAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Schedule{Id: 1, Status: StatusActive}), nil)
+
+		result, err := repo.CreateScheduleAPI(&createSchedulePayload{CompanyId: constant.DummyCompanyId})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), result.Id)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		result, err := repo.CreateScheduleAPI(&createSchedulePayload{})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		_, err := repo.CreateScheduleAPI(&createSchedulePayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseParseError, func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(constant.InvalidJSON)),
+		}
+		repo, mockClient := setupMockRepo(t, resp, nil)
+
+		result, err := repo.CreateScheduleAPI(&createSchedulePayload{})
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetScheduleInputAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Input{
+			FileName: "portfolio.csv",
+			Records:  [][]string{{"NIK"}, {"123"}},
+		}), nil)
+
+		result, err := repo.GetScheduleInputAPI(constant.DummyCompanyId, "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "portfolio.csv", result.FileName)
+		assert.Len(t, result.Records, 2)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetScheduleInputAPI(constant.DummyCompanyId, "1")
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallGetDueSchedulesAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		mockClient := new(MockClient)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("before") == "2024-02-01T00:00:00Z"
+		})).Return(mockResponse(t, []Schedule{{Id: 1}, {Id: 2}}), nil)
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, mockClient, nil)
+
+		result, err := repo.GetDueSchedulesAPI(before)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetDueSchedulesAPI(time.Now())
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallClaimScheduleAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, claimResult{Claimed: true}), nil)
+
+		claimed, err := repo.ClaimScheduleAPI(constant.DummyCompanyId, "1", &claimPayload{})
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("should report a schedule claimed by another instance", func(t *testing.T) {
+		repo, _ := setupMockRepo(t, mockResponse(t, claimResult{Claimed: false}), nil)
+
+		claimed, err := repo.ClaimScheduleAPI(constant.DummyCompanyId, "1", &claimPayload{})
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		claimed, err := repo.ClaimScheduleAPI(constant.DummyCompanyId, "1", &claimPayload{})
+
+		assert.False(t, claimed)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallUpdateScheduleAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.UpdateScheduleAPI(constant.DummyCompanyId, "1", map[string]interface{}{"status": StatusPaused})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.UpdateScheduleAPI(constant.DummyCompanyId, "1", map[string]interface{}{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"sync"
+	"time"
+)
+
+// pollInterval matches the one-minute resolution of cron expressions.
+const pollInterval = time.Minute
+
+var startOnce sync.Once
+
+// StartRunner polls for due schedules in the background. Only the first call
+// starts a runner, however many route groups set the package up.
+func StartRunner(svc Service) {
+	startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				svc.RunDueSchedules()
+			}
+		}()
+	})
+}
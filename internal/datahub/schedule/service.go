@@ -0,0 +1,313 @@
+package schedule
+
+import (
+	"fmt"
+	"front-office/internal/core/member"
+	"front-office/internal/core/subscription"
+	"front-office/internal/datahub/columnmapping"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/mailjet"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func NewService(repo Repository, memberRepo member.Repository, gatewaySvc gateway.Service, columnMappingSvc columnmapping.Service, subscriptionSvc subscription.Service) Service {
+	return &service{
+		repo:             repo,
+		memberRepo:       memberRepo,
+		gatewaySvc:       gatewaySvc,
+		columnMappingSvc: columnMappingSvc,
+		subscriptionSvc:  subscriptionSvc,
+		notify:           mailjet.SendEmailScheduledJobFinished,
+		now:              time.Now,
+	}
+}
+
+type service struct {
+	repo             Repository
+	memberRepo       member.Repository
+	gatewaySvc       gateway.Service
+	columnMappingSvc columnmapping.Service
+	subscriptionSvc  subscription.Service
+	notify           func(email, name, scheduleName, jobId, status string) error
+	now              func() time.Time
+}
+
+type Service interface {
+	CreateSchedule(memberId, companyId string, req *createScheduleRequest, file *multipart.FileHeader) (*Schedule, error)
+	GetSchedules(filter *scheduleFilter) (*model.AifcoreAPIResponse[[]*Schedule], error)
+	GetSchedule(memberId, companyId, scheduleId string) (*Schedule, error)
+	PauseSchedule(memberId, companyId, scheduleId string) error
+	ResumeSchedule(memberId, companyId, scheduleId string) error
+	DeleteSchedule(memberId, companyId, scheduleId string) error
+	RunDueSchedules()
+}
+
+func (svc *service) CreateSchedule(memberId, companyId string, req *createScheduleRequest, file *multipart.FileHeader) (*Schedule, error) {
+	handler, ok := registry.GetByRouteSlug(req.ProductSlug)
+	if !ok {
+		return nil, apperror.NotFound(constant.ProductNotFound)
+	}
+
+	if err := svc.subscriptionSvc.Authorize(companyId, memberId, handler.Slug); err != nil {
+		return nil, err
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+
+	nextRunAt, err := helper.NextCronTime(req.CronExpression, req.Timezone, svc.now())
+	if err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	if err := helper.ValidateUploadedFile(file, 30*1024*1024, []string{".csv"}); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	upload, err := svc.columnMappingSvc.ParseUpload(companyId, handler.Slug, file, &columnmapping.UploadMapping{
+		MappingId: req.MappingId,
+		Mapping:   req.Mapping,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(upload.Records) < 2 {
+		return nil, apperror.BadRequest("uploaded file has no rows")
+	}
+
+	result, err := svc.repo.CreateScheduleAPI(&createSchedulePayload{
+		CompanyId:      companyId,
+		MemberId:       memberId,
+		Name:           strings.TrimSpace(req.Name),
+		ProductSlug:    handler.RouteSlug,
+		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
+		Status:         StatusActive,
+		Total:          len(upload.Records) - 1,
+		NextRunAt:      &nextRunAt,
+		Input: &Input{
+			FileName: file.Filename,
+			Source:   upload.Source,
+			Records:  upload.Records,
+			Fields:   upload.Fields,
+		},
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to create job schedule")
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetSchedules(filter *scheduleFilter) (*model.AifcoreAPIResponse[[]*Schedule], error) {
+	result, err := svc.repo.GetSchedulesAPI(filter)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job schedules")
+	}
+
+	return result, nil
+}
+
+// GetSchedule returns the schedule only to the member who created it; the
+// schedules of other members of the company are reported as not found.
+func (svc *service) GetSchedule(memberId, companyId, scheduleId string) (*Schedule, error) {
+	result, err := svc.repo.GetScheduleAPI(companyId, scheduleId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch job schedule")
+	}
+
+	if result == nil || helper.ConvertUintToString(result.MemberId) != memberId {
+		return nil, apperror.NotFound("job schedule not found")
+	}
+
+	return result, nil
+}
+
+func (svc *service) PauseSchedule(memberId, companyId, scheduleId string) error {
+	if _, err := svc.GetSchedule(memberId, companyId, scheduleId); err != nil {
+		return err
+	}
+
+	if err := svc.repo.UpdateScheduleAPI(companyId, scheduleId, map[string]interface{}{
+		"status": StatusPaused,
+	}); err != nil {
+		return apperror.MapRepoError(err, "failed to pause job schedule")
+	}
+
+	return nil
+}
+
+// ResumeSchedule reactivates a schedule from its next fire time; runs missed
+// while it was paused are not caught up.
+func (svc *service) ResumeSchedule(memberId, companyId, scheduleId string) error {
+	current, err := svc.GetSchedule(memberId, companyId, scheduleId)
+	if err != nil {
+		return err
+	}
+
+	nextRunAt, err := helper.NextCronTime(current.CronExpression, current.Timezone, svc.now())
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	if err := svc.repo.UpdateScheduleAPI(companyId, scheduleId, map[string]interface{}{
+		"status":      StatusActive,
+		"next_run_at": nextRunAt,
+	}); err != nil {
+		return apperror.MapRepoError(err, "failed to resume job schedule")
+	}
+
+	return nil
+}
+
+func (svc *service) DeleteSchedule(memberId, companyId, scheduleId string) error {
+	if _, err := svc.GetSchedule(memberId, companyId, scheduleId); err != nil {
+		return err
+	}
+
+	if err := svc.repo.DeleteScheduleAPI(companyId, scheduleId); err != nil {
+		return apperror.MapRepoError(err, "failed to delete job schedule")
+	}
+
+	return nil
+}
+
+// RunDueSchedules starts a job for every schedule that is due. Each schedule
+// is claimed by moving it to its next fire time before its job starts, so a
+// run that outlasts the polling interval, or a schedule polled by several
+// instances, is not started twice.
+func (svc *service) RunDueSchedules() {
+	now := svc.now()
+
+	due, err := svc.repo.GetDueSchedulesAPI(now)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to fetch due job schedules")
+		return
+	}
+
+	for _, s := range due {
+		companyId := helper.ConvertUintToString(s.CompanyId)
+		scheduleId := helper.ConvertUintToString(s.Id)
+
+		data := map[string]interface{}{
+			"last_run_at": now,
+		}
+
+		nextRunAt, err := helper.NextCronTime(s.CronExpression, s.Timezone, now)
+		if err != nil {
+			data["status"] = StatusPaused
+			data["last_error"] = err.Error()
+		} else {
+			data["next_run_at"] = nextRunAt
+		}
+
+		claimed, err := svc.repo.ClaimScheduleAPI(companyId, scheduleId, &claimPayload{
+			ExpectedNextRunAt: s.NextRunAt,
+			Data:              data,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("schedule_id", scheduleId).Msg("failed to claim job schedule, run skipped")
+			continue
+		}
+
+		if !claimed {
+			log.Info().Str("schedule_id", scheduleId).Msg("job schedule already claimed, run skipped")
+			continue
+		}
+
+		if _, paused := data["status"]; paused {
+			continue
+		}
+
+		go svc.run(s)
+	}
+}
+
+// run processes the input set of s as a new job of its member and notifies
+// the member of the outcome.
+func (svc *service) run(s *Schedule) {
+	companyId := helper.ConvertUintToString(s.CompanyId)
+	scheduleId := helper.ConvertUintToString(s.Id)
+
+	owner, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{
+		Id:        helper.ConvertUintToString(s.MemberId),
+		CompanyId: companyId,
+	})
+	if err != nil || owner == nil || owner.MemberId == 0 || !owner.Active {
+		log.Warn().Err(err).Str("schedule_id", scheduleId).Msg("schedule owner is unavailable, schedule paused")
+		svc.pause(companyId, scheduleId, "schedule owner is no longer active")
+
+		return
+	}
+
+	// the subscription or the owner's permission may have been revoked since
+	// the schedule was created
+	if handler, ok := registry.GetByRouteSlug(s.ProductSlug); ok {
+		if err := svc.subscriptionSvc.Authorize(companyId, helper.ConvertUintToString(s.MemberId), handler.Slug); err != nil {
+			log.Warn().Err(err).Str("schedule_id", scheduleId).Msg("schedule owner is not authorized for the product, schedule paused")
+			svc.pause(companyId, scheduleId, err.Error())
+
+			return
+		}
+	}
+
+	jobId, runErr := svc.runJob(s, owner.Key)
+
+	status, lastError := constant.JobStatusDone, ""
+	if runErr != nil {
+		status, lastError = constant.JobStatusFailed, runErr.Error()
+		log.Error().Err(runErr).Str("schedule_id", scheduleId).Msg("scheduled job failed")
+	}
+
+	if err := svc.repo.UpdateScheduleAPI(companyId, scheduleId, map[string]interface{}{
+		"last_job_id":     jobId,
+		"last_run_status": status,
+		"last_error":      lastError,
+	}); err != nil {
+		log.Error().Err(err).Str("schedule_id", scheduleId).Msg("failed to record scheduled job run")
+	}
+
+	if err := svc.notify(owner.Email, owner.Name, s.Name, jobId, status); err != nil {
+		log.Warn().Err(err).Str("schedule_id", scheduleId).Msg("failed to send scheduled job notification")
+	}
+}
+
+// pause stops s from firing until its member resumes it, recording why.
+func (svc *service) pause(companyId, scheduleId, reason string) {
+	if err := svc.repo.UpdateScheduleAPI(companyId, scheduleId, map[string]interface{}{
+		"status":     StatusPaused,
+		"last_error": reason,
+	}); err != nil {
+		log.Error().Err(err).Str("schedule_id", scheduleId).Msg("failed to pause job schedule")
+	}
+}
+
+func (svc *service) runJob(s *Schedule, apiKey string) (string, error) {
+	handler, ok := registry.GetByRouteSlug(s.ProductSlug)
+	if !ok {
+		return "", fmt.Errorf("product %q is no longer available", s.ProductSlug)
+	}
+
+	input, err := svc.repo.GetScheduleInputAPI(helper.ConvertUintToString(s.CompanyId), helper.ConvertUintToString(s.Id))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch schedule input: %w", err)
+	}
+
+	return svc.gatewaySvc.RunBulk(handler, apiKey, s.MemberId, s.CompanyId, &gateway.BulkInput{
+		FileName: input.FileName,
+		Source:   input.Source,
+		Records:  input.Records,
+		Fields:   input.Fields,
+	})
+}
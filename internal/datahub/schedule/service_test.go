@@ -0,0 +1,259 @@
+package schedule
+
+import (
+	"errors"
+	"front-office/internal/core/member"
+	"front-office/internal/datahub/compliance/loanrecordchecker"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	Repository
+	mock.Mock
+}
+
+func (m *MockRepository) GetScheduleAPI(companyId, scheduleId string) (*Schedule, error) {
+	args := m.Called(companyId, scheduleId)
+	return args.Get(0).(*Schedule), args.Error(1)
+}
+
+func (m *MockRepository) DeleteScheduleAPI(companyId, scheduleId string) error {
+	args := m.Called(companyId, scheduleId)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetScheduleInputAPI(companyId, scheduleId string) (*Input, error) {
+	args := m.Called(companyId, scheduleId)
+	return args.Get(0).(*Input), args.Error(1)
+}
+
+func (m *MockRepository) GetDueSchedulesAPI(before time.Time) ([]*Schedule, error) {
+	args := m.Called(before)
+	return args.Get(0).([]*Schedule), args.Error(1)
+}
+
+func (m *MockRepository) UpdateScheduleAPI(companyId, scheduleId string, payload map[string]interface{}) error {
+	args := m.Called(companyId, scheduleId, payload)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ClaimScheduleAPI(companyId, scheduleId string, payload *claimPayload) (bool, error) {
+	args := m.Called(companyId, scheduleId, payload)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockMemberRepository struct {
+	member.Repository
+	mock.Mock
+}
+
+func (m *MockMemberRepository) GetMemberAPI(query *member.FindUserQuery) (*member.MstMember, error) {
+	args := m.Called(query)
+	return args.Get(0).(*member.MstMember), args.Error(1)
+}
+
+type MockGatewayService struct {
+	gateway.Service
+	mock.Mock
+}
+
+func (m *MockGatewayService) RunBulk(handler *registry.ProductHandler, apiKey string, memberId, companyId uint, input *gateway.BulkInput) (string, error) {
+	args := m.Called(handler, apiKey, memberId, companyId, input)
+	return args.String(0), args.Error(1)
+}
+
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionService) Authorize(companyId, memberId, productSlug string) error {
+	args := m.Called(companyId, memberId, productSlug)
+	return args.Error(0)
+}
+
+type notification struct {
+	email, scheduleName, jobId, status string
+}
+
+func newTestService(repo *MockRepository, memberRepo *MockMemberRepository, gatewaySvc *MockGatewayService, subscriptionSvc *MockSubscriptionService, now time.Time, sent *[]notification) *service {
+	return &service{
+		repo:            repo,
+		memberRepo:      memberRepo,
+		gatewaySvc:      gatewaySvc,
+		subscriptionSvc: subscriptionSvc,
+		notify: func(email, name, scheduleName, jobId, status string) error {
+			*sent = append(*sent, notification{email, scheduleName, jobId, status})
+			return nil
+		},
+		now: func() time.Time { return now },
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	now := time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should claim schedules at their next run before running them", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetDueSchedulesAPI", now).Return([]*Schedule{
+			{Id: 1, CompanyId: 1, CronExpression: "0 0 1 * *", Timezone: "UTC", NextRunAt: &dueAt},
+		}, nil)
+		repo.On("ClaimScheduleAPI", "1", "1", &claimPayload{
+			ExpectedNextRunAt: &dueAt,
+			Data: map[string]interface{}{
+				"last_run_at": now,
+				"next_run_at": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}).Return(false, errors.New("conflict"))
+
+		var sent []notification
+		newTestService(repo, new(MockMemberRepository), new(MockGatewayService), new(MockSubscriptionService), now, &sent).RunDueSchedules()
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should skip schedules claimed by another instance", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetDueSchedulesAPI", now).Return([]*Schedule{
+			{Id: 1, CompanyId: 1, CronExpression: "0 0 1 * *", Timezone: "UTC", NextRunAt: &dueAt},
+		}, nil)
+		repo.On("ClaimScheduleAPI", "1", "1", mock.Anything).Return(false, nil)
+		gatewaySvc := new(MockGatewayService)
+
+		var sent []notification
+		newTestService(repo, new(MockMemberRepository), gatewaySvc, new(MockSubscriptionService), now, &sent).RunDueSchedules()
+
+		repo.AssertExpectations(t)
+		gatewaySvc.AssertNotCalled(t, "RunBulk", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should pause schedules that can no longer fire", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetDueSchedulesAPI", now).Return([]*Schedule{
+			{Id: 2, CompanyId: 1, CronExpression: "0 0 1 * *", Timezone: "Mars/Olympus"},
+		}, nil)
+		repo.On("ClaimScheduleAPI", "1", "2", mock.MatchedBy(func(payload *claimPayload) bool {
+			return payload.Data["status"] == StatusPaused
+		})).Return(true, nil)
+
+		var sent []notification
+		newTestService(repo, new(MockMemberRepository), new(MockGatewayService), new(MockSubscriptionService), now, &sent).RunDueSchedules()
+
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestRun(t *testing.T) {
+	registry.Register(loanrecordchecker.NewHandler(nil))
+
+	s := &Schedule{Id: 3, CompanyId: 1, MemberId: 5, Name: "monthly portfolio", ProductSlug: "loan-record-checker"}
+	query := &member.FindUserQuery{Id: "5", CompanyId: "1"}
+	input := &Input{FileName: "portfolio.csv", Records: [][]string{{"Name"}, {"a"}}}
+
+	t.Run("should run the input set as the owner and notify them", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetScheduleInputAPI", "1", "3").Return(input, nil)
+		repo.On("UpdateScheduleAPI", "1", "3", map[string]interface{}{
+			"last_job_id":     "42",
+			"last_run_status": constant.JobStatusDone,
+			"last_error":      "",
+		}).Return(nil)
+
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 5, Email: "risk@example.com", Key: "api-key", Active: true}, nil)
+
+		gatewaySvc := new(MockGatewayService)
+		gatewaySvc.On("RunBulk", mock.Anything, "api-key", uint(5), uint(1), &gateway.BulkInput{
+			FileName: input.FileName,
+			Records:  input.Records,
+		}).Return("42", nil)
+
+		subscriptionSvc := new(MockSubscriptionService)
+		subscriptionSvc.On("Authorize", "1", "5", constant.SlugLoanRecordChecker).Return(nil)
+
+		var sent []notification
+		newTestService(repo, memberRepo, gatewaySvc, subscriptionSvc, time.Now(), &sent).run(s)
+
+		assert.Equal(t, []notification{{"risk@example.com", "monthly portfolio", "42", constant.JobStatusDone}}, sent)
+		repo.AssertExpectations(t)
+		gatewaySvc.AssertExpectations(t)
+	})
+
+	t.Run("should pause the schedule of an inactive owner", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("UpdateScheduleAPI", "1", "3", mock.MatchedBy(func(data map[string]interface{}) bool {
+			return data["status"] == StatusPaused
+		})).Return(nil)
+
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 5, Active: false}, nil)
+
+		gatewaySvc := new(MockGatewayService)
+
+		var sent []notification
+		newTestService(repo, memberRepo, gatewaySvc, new(MockSubscriptionService), time.Now(), &sent).run(s)
+
+		assert.Empty(t, sent)
+		repo.AssertExpectations(t)
+		gatewaySvc.AssertNotCalled(t, "RunBulk")
+	})
+
+	t.Run("should pause the schedule of an owner no longer authorized for the product", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("UpdateScheduleAPI", "1", "3", map[string]interface{}{
+			"status":     StatusPaused,
+			"last_error": "product is not subscribed",
+		}).Return(nil)
+
+		memberRepo := new(MockMemberRepository)
+		memberRepo.On("GetMemberAPI", query).Return(&member.MstMember{MemberId: 5, Key: "api-key", Active: true}, nil)
+
+		gatewaySvc := new(MockGatewayService)
+
+		subscriptionSvc := new(MockSubscriptionService)
+		subscriptionSvc.On("Authorize", "1", "5", constant.SlugLoanRecordChecker).Return(apperror.Forbidden("product is not subscribed"))
+
+		var sent []notification
+		newTestService(repo, memberRepo, gatewaySvc, subscriptionSvc, time.Now(), &sent).run(s)
+
+		assert.Empty(t, sent)
+		repo.AssertExpectations(t)
+		gatewaySvc.AssertNotCalled(t, "RunBulk")
+	})
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	t.Run("should delete a schedule of the member", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetScheduleAPI", "1", "3").Return(&Schedule{Id: 3, CompanyId: 1, MemberId: 5}, nil)
+		repo.On("DeleteScheduleAPI", "1", "3").Return(nil)
+
+		var sent []notification
+		err := newTestService(repo, new(MockMemberRepository), new(MockGatewayService), new(MockSubscriptionService), time.Now(), &sent).DeleteSchedule("5", "1", "3")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should not delete a schedule of another member", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetScheduleAPI", "1", "3").Return(&Schedule{Id: 3, CompanyId: 1, MemberId: 6}, nil)
+
+		var sent []notification
+		err := newTestService(repo, new(MockMemberRepository), new(MockGatewayService), new(MockSubscriptionService), time.Now(), &sent).DeleteSchedule("5", "1", "3")
+
+		var appErr *apperror.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		repo.AssertNotCalled(t, "DeleteScheduleAPI", "1", "3")
+	})
+}
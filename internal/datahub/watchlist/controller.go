@@ -0,0 +1,134 @@
+package watchlist
+
+import (
+	"fmt"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/helper"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// recentChangesDays is the window of the change listing when no dates are given.
+const recentChangesDays = 7
+
+func NewController(svc Service) Controller {
+	return &controller{svc}
+}
+
+type controller struct {
+	svc Service
+}
+
+type Controller interface {
+	AddSubject(c *fiber.Ctx) error
+	GetSubjects(c *fiber.Ctx) error
+	DeleteSubject(c *fiber.Ctx) error
+	GetSettings(c *fiber.Ctx) error
+	SaveSettings(c *fiber.Ctx) error
+	GetChangedSubjects(c *fiber.Ctx) error
+}
+
+func (ctrl *controller) AddSubject(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*subjectRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	result, err := ctrl.svc.AddSubject(memberId, companyId, reqBody)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helper.ResponseSuccess(
+		"succeed to add watchlist subject",
+		result,
+	))
+}
+
+func (ctrl *controller) GetSubjects(c *fiber.Ctx) error {
+	filter := &subjectFilter{
+		CompanyId: fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		Page:      c.Query(constant.Page, "1"),
+		Size:      c.Query(constant.Size, "10"),
+		Keyword:   c.Query(constant.Keyword),
+	}
+
+	result, err := ctrl.svc.GetSubjects(filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+func (ctrl *controller) DeleteSubject(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	if err := ctrl.svc.DeleteSubject(companyId, c.Params("subject_id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to delete watchlist subject",
+		nil,
+	))
+}
+
+func (ctrl *controller) GetSettings(c *fiber.Ctx) error {
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	result, err := ctrl.svc.GetSettings(companyId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to get watchlist settings",
+		result,
+	))
+}
+
+func (ctrl *controller) SaveSettings(c *fiber.Ctx) error {
+	memberId := fmt.Sprintf("%v", c.Locals(constant.UserId))
+	companyId := fmt.Sprintf("%v", c.Locals(constant.CompanyId))
+
+	reqBody, ok := c.Locals(constant.Request).(*settingsRequest)
+	if !ok {
+		return apperror.BadRequest(constant.InvalidRequestFormat)
+	}
+
+	if err := ctrl.svc.SaveSettings(memberId, companyId, reqBody); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(helper.ResponseSuccess(
+		"succeed to save watchlist settings",
+		nil,
+	))
+}
+
+// GetChangedSubjects lists the subjects with changes between start_date and
+// end_date, by default over the last recentChangesDays days.
+func (ctrl *controller) GetChangedSubjects(c *fiber.Ctx) error {
+	now := time.Now()
+
+	filter := &changeFilter{
+		CompanyId: fmt.Sprintf("%v", c.Locals(constant.CompanyId)),
+		StartDate: c.Query(constant.StartDate, now.AddDate(0, 0, -recentChangesDays).Format(constant.FormatYYYYMMDD)),
+		EndDate:   c.Query(constant.EndDate, now.Format(constant.FormatYYYYMMDD)),
+		Page:      c.Query(constant.Page, "1"),
+		Size:      c.Query(constant.Size, "10"),
+	}
+
+	result, err := ctrl.svc.GetChangedSubjects(filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
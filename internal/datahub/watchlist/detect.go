@@ -0,0 +1,85 @@
+package watchlist
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// changeRule raises a change of kind when the value of a snapshot field
+// moves from previous to current in a way the rule cares about.
+type changeRule struct {
+	kind    string
+	matches func(field string) bool
+	changed func(previous, current string) bool
+}
+
+var changeRules = []changeRule{
+	{
+		kind:    ChangeQueryCountIncreased,
+		matches: func(field string) bool { return strings.HasSuffix(field, "multiple-loan.query_count") },
+		changed: func(previous, current string) bool {
+			prev, err := strconv.Atoi(previous)
+			if err != nil {
+				return false
+			}
+
+			curr, err := strconv.Atoi(current)
+			if err != nil {
+				return false
+			}
+
+			return curr > prev
+		},
+	},
+	{
+		kind:    ChangeNumberDisconnected,
+		matches: func(field string) bool { return field == "phone-live-status.subscriber_status" },
+		changed: func(previous, current string) bool {
+			return previous != "" && !isDisconnected(previous) && isDisconnected(current)
+		},
+	},
+}
+
+func isDisconnected(subscriberStatus string) bool {
+	return strings.Contains(strings.ToLower(subscriberStatus), "disconnect")
+}
+
+// detectChanges compares two snapshots of a subject. Fields missing from
+// either snapshot, e.g. on the first check or after a failed request, never
+// raise a change.
+func detectChanges(subjectId uint, previous, current map[string]string, detectedAt time.Time) []*Change {
+	fields := make([]string, 0, len(current))
+	for field := range current {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []*Change
+
+	for _, rule := range changeRules {
+		for _, field := range fields {
+			if !rule.matches(field) {
+				continue
+			}
+
+			curr := current[field]
+			prev, ok := previous[field]
+			if !ok || !rule.changed(prev, curr) {
+				continue
+			}
+
+			changes = append(changes, &Change{
+				SubjectId:  subjectId,
+				Kind:       rule.kind,
+				Field:      field,
+				Previous:   prev,
+				Current:    curr,
+				DetectedAt: detectedAt,
+			})
+		}
+	}
+
+	return changes
+}
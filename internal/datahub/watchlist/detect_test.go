@@ -0,0 +1,94 @@
+package watchlist
+
+import (
+	"front-office/internal/datahub/compliance/multipleloan"
+	"front-office/internal/datahub/identity/phonelivestatus"
+	"front-office/internal/datahub/registry"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectChanges(t *testing.T) {
+	detectedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	previous := map[string]string{
+		"7d-multiple-loan.query_count":        "1",
+		"30d-multiple-loan.query_count":       "4",
+		"90d-multiple-loan.query_count":       "9",
+		"phone-live-status.subscriber_status": "ACTIVE",
+	}
+
+	t.Run("should raise increased query counts and disconnections", func(t *testing.T) {
+		current := map[string]string{
+			"7d-multiple-loan.query_count":        "3",
+			"30d-multiple-loan.query_count":       "4",
+			"90d-multiple-loan.query_count":       "8",
+			"phone-live-status.subscriber_status": "DISCONNECTED",
+		}
+
+		changes := detectChanges(7, previous, current, detectedAt)
+
+		require.Len(t, changes, 2)
+		assert.Equal(t, &Change{
+			SubjectId:  7,
+			Kind:       ChangeQueryCountIncreased,
+			Field:      "7d-multiple-loan.query_count",
+			Previous:   "1",
+			Current:    "3",
+			DetectedAt: detectedAt,
+		}, changes[0])
+		assert.Equal(t, ChangeNumberDisconnected, changes[1].Kind)
+	})
+
+	t.Run("should not raise changes on the first check", func(t *testing.T) {
+		changes := detectChanges(7, nil, map[string]string{
+			"7d-multiple-loan.query_count":        "3",
+			"phone-live-status.subscriber_status": "DISCONNECTED",
+		}, detectedAt)
+
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should not raise changes for missing or unparsable values", func(t *testing.T) {
+		changes := detectChanges(7, previous, map[string]string{
+			"7d-multiple-loan.query_count": "",
+		}, detectedAt)
+
+		assert.Empty(t, changes)
+	})
+
+	t.Run("should not raise an already disconnected number again", func(t *testing.T) {
+		changes := detectChanges(7, map[string]string{
+			"phone-live-status.subscriber_status": "DISCONNECTED",
+		}, map[string]string{
+			"phone-live-status.subscriber_status": "DISCONNECTED",
+		}, detectedAt)
+
+		assert.Empty(t, changes)
+	})
+}
+
+func TestMonitoredProductValues(t *testing.T) {
+	for _, h := range multipleloan.NewHandlers(nil) {
+		registry.Register(h)
+	}
+	registry.Register(phonelivestatus.NewHandler(nil))
+
+	for _, slug := range monitoredProducts {
+		_, ok := registry.Get(slug)
+		require.True(t, ok, slug)
+	}
+
+	loan, _ := registry.Get(monitoredProducts[0])
+	assert.Equal(t, map[string]string{
+		"7d-multiple-loan.query_count": "5",
+	}, loan.DataValues(map[string]any{"query_count": 5}))
+
+	phone, _ := registry.Get(monitoredProducts[3])
+	assert.Equal(t, "DISCONNECTED", phone.DataValues(map[string]any{
+		"live_status": "DISCONNECTED, UNREACHABLE",
+	})["phone-live-status.subscriber_status"])
+}
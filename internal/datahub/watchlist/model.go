@@ -0,0 +1,153 @@
+package watchlist
+
+import (
+	"errors"
+	"front-office/pkg/utility/identity"
+	"time"
+)
+
+// Monitoring states of a company watchlist.
+const (
+	StatusActive = "active"
+	StatusPaused = "paused"
+)
+
+// Kinds of change raised between two checks of a subject.
+const (
+	ChangeQueryCountIncreased = "query_count_increased"
+	ChangeNumberDisconnected  = "number_disconnected"
+)
+
+const defaultTimezone = "Asia/Jakarta"
+
+// Settings control when the watchlist of a company is re-checked and who is
+// alerted. Checks run under MemberId, the member who last saved the settings.
+type Settings struct {
+	CompanyId      uint       `json:"company_id"`
+	MemberId       uint       `json:"member_id"`
+	CronExpression string     `json:"cron_expression"`
+	Timezone       string     `json:"timezone"`
+	Status         string     `json:"status"`
+	AlertEmails    []string   `json:"alert_emails"`
+	NextRunAt      *time.Time `json:"next_run_at"`
+	LastRunAt      *time.Time `json:"last_run_at"`
+}
+
+// Subject is one monitored person. Snapshot holds the values of the last
+// check keyed by decision rule field, e.g. "7d-multiple-loan.query_count".
+type Subject struct {
+	Id            uint              `json:"id"`
+	CompanyId     uint              `json:"company_id"`
+	Nik           string            `json:"nik"`
+	PhoneNumber   string            `json:"phone_number"`
+	Label         string            `json:"label"`
+	Snapshot      map[string]string `json:"snapshot"`
+	LastCheckedAt *time.Time        `json:"last_checked_at"`
+	LastChangedAt *time.Time        `json:"last_changed_at"`
+	CreatedAt     string            `json:"created_at"`
+}
+
+// inputs returns the product request fields the subject can be checked with.
+func (s *Subject) inputs() map[string]string {
+	return map[string]string{
+		"nik":          s.Nik,
+		"phone_number": s.PhoneNumber,
+	}
+}
+
+// Change is a difference between two checks of a subject that alerts were
+// raised for.
+type Change struct {
+	Id         uint      `json:"id"`
+	SubjectId  uint      `json:"subject_id"`
+	Kind       string    `json:"kind"`
+	Field      string    `json:"field"`
+	Previous   string    `json:"previous"`
+	Current    string    `json:"current"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// ChangedSubject is a subject listed with its recent changes.
+type ChangedSubject struct {
+	Subject
+	Changes []*Change `json:"changes"`
+}
+
+// ChangeEventData is the data of watchlist.changed webhook events.
+type ChangeEventData struct {
+	Subjects int       `json:"subjects"`
+	Changes  []*Change `json:"changes"`
+}
+
+// subjectRequest adds a subject by NIK, phone number or both. Each monitored
+// product only checks the subjects that have all of its request fields.
+type subjectRequest struct {
+	Nik         string `json:"nik"`
+	PhoneNumber string `json:"phone_number"`
+	Label       string `json:"label"`
+}
+
+// Normalize checks the NIK and brings the phone number to canonical form.
+func (r *subjectRequest) Normalize() error {
+	if r.Nik == "" && r.PhoneNumber == "" {
+		return errors.New("NIK or Phone Number is required")
+	}
+
+	if r.Nik != "" {
+		nik, err := identity.NormalizeNIK(r.Nik)
+		if err != nil {
+			return err
+		}
+		r.Nik = nik
+	}
+
+	if r.PhoneNumber != "" {
+		phoneNumber, err := identity.NormalizePhone(r.PhoneNumber)
+		if err != nil {
+			return err
+		}
+		r.PhoneNumber = phoneNumber
+	}
+
+	return nil
+}
+
+type settingsRequest struct {
+	CronExpression string   `json:"cron_expression" validate:"required~cron_expression is required"`
+	Timezone       string   `json:"timezone"`
+	IsActive       *bool    `json:"is_active"`
+	AlertEmails    []string `json:"alert_emails"`
+}
+
+// claimPayload moves a due watchlist to its next check, provided no other
+// instance has moved it since it was read.
+type claimPayload struct {
+	ExpectedNextRunAt *time.Time             `json:"expected_next_run_at"`
+	Data              map[string]interface{} `json:"data"`
+}
+
+type claimResult struct {
+	Claimed bool `json:"claimed"`
+}
+
+type createSubjectPayload struct {
+	CompanyId   string `json:"company_id"`
+	Nik         string `json:"nik"`
+	PhoneNumber string `json:"phone_number"`
+	Label       string `json:"label"`
+}
+
+type subjectFilter struct {
+	CompanyId string
+	Page      string
+	Size      string
+	Keyword   string
+}
+
+type changeFilter struct {
+	CompanyId string
+	StartDate string
+	EndDate   string
+	Page      string
+	Size      string
+}
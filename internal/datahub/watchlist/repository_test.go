@@ -0,0 +1,192 @@
+package watchlist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"front-office/configs/application"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func setupMockRepo(t *testing.T, response *http.Response, err error) (Repository, *MockClient) {
+	t.Helper()
+
+	mockClient := new(MockClient)
+	mockClient.On("Do", mock.Anything).Return(response, err)
+
+	repo := NewRepository(&application.Config{
+		Env: &application.Environment{AifcoreHost: constant.MockHost},
+	}, mockClient, nil)
+
+	return repo, mockClient
+}
+
+func mockResponse(t *testing.T, data any) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(model.AifcoreAPIResponse[any]{
+		Success: true,
+		Data:    data,
+	})
+	require.NoError(t, err)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func TestCallClaimSettingsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, claimResult{Claimed: true}), nil)
+
+		claimed, err := repo.ClaimSettingsAPI(constant.DummyCompanyId, &claimPayload{})
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		claimed, err := repo.ClaimSettingsAPI(constant.DummyCompanyId, &claimPayload{})
+
+		assert.False(t, claimed)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallCreateSubjectAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, Subject{Id: 1, Nik: "3201010101010001"}), nil)
+
+		result, err := repo.CreateSubjectAPI(&createSubjectPayload{CompanyId: constant.DummyCompanyId})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), result.Id)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseMarshalError, func(t *testing.T) {
+		fakeMarshal := func(v any) ([]byte, error) {
+			return nil, errors.New(constant.ErrFailedMarshalReq)
+		}
+
+		repo := NewRepository(&application.Config{
+			Env: &application.Environment{AifcoreHost: constant.MockHost},
+		}, &MockClient{}, fakeMarshal)
+
+		result, err := repo.CreateSubjectAPI(&createSubjectPayload{})
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrFailedMarshalReq)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		_, err := repo.CreateSubjectAPI(&createSubjectPayload{})
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestCallGetAllSubjectsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Subject{
+			{Id: 1, Snapshot: map[string]string{"7d-multiple-loan.query_count": "2"}},
+			{Id: 2},
+		}), nil)
+
+		result, err := repo.GetAllSubjectsAPI(constant.DummyCompanyId)
+
+		assert.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "2", result[0].Snapshot["7d-multiple-loan.query_count"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetAllSubjectsAPI(constant.DummyCompanyId)
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallGetDueSettingsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []Settings{{CompanyId: 1, Status: StatusActive}}), nil)
+
+		result, err := repo.GetDueSettingsAPI(time.Now())
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		result, err := repo.GetDueSettingsAPI(time.Now())
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallCreateChangesAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, nil), nil)
+
+		err := repo.CreateChangesAPI(constant.DummyCompanyId, []*Change{{SubjectId: 1, Kind: ChangeNumberDisconnected}})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run(constant.TestCaseHTTPRequestError, func(t *testing.T) {
+		repo, _ := setupMockRepo(t, nil, errors.New(constant.ErrHTTPReqFailed))
+
+		err := repo.CreateChangesAPI(constant.DummyCompanyId, nil)
+
+		assert.Contains(t, err.Error(), constant.ErrHTTPReqFailed)
+	})
+}
+
+func TestCallGetChangedSubjectsAPI(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		repo, mockClient := setupMockRepo(t, mockResponse(t, []ChangedSubject{
+			{Subject: Subject{Id: 1}, Changes: []*Change{{Kind: ChangeQueryCountIncreased}}},
+		}), nil)
+
+		result, err := repo.GetChangedSubjectsAPI(&changeFilter{CompanyId: constant.DummyCompanyId})
+
+		assert.NoError(t, err)
+		require.Len(t, result.Data, 1)
+		assert.Len(t, result.Data[0].Changes, 1)
+		mockClient.AssertExpectations(t)
+	})
+}
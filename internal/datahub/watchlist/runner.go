@@ -0,0 +1,26 @@
+package watchlist
+
+import (
+	"sync"
+	"time"
+)
+
+// pollInterval matches the one-minute resolution of cron expressions.
+const pollInterval = time.Minute
+
+var startOnce sync.Once
+
+// StartRunner polls for due watchlist checks in the background. Only the
+// first call starts a runner.
+func StartRunner(svc Service) {
+	startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				svc.RunDueChecks()
+			}
+		}()
+	})
+}
@@ -0,0 +1,501 @@
+package watchlist
+
+import (
+	"fmt"
+	"front-office/internal/core/member"
+	"front-office/internal/core/product"
+	"front-office/internal/core/subscription"
+	"front-office/internal/core/webhook"
+	"front-office/internal/datahub/gateway"
+	"front-office/internal/datahub/job"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"front-office/pkg/common/model"
+	"front-office/pkg/helper"
+	"front-office/pkg/utility/mailjet"
+	"net/http"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// monitoredProducts are re-checked for every subject of a watchlist.
+var monitoredProducts = []string{
+	constant.SlugMultipleLoan7Days,
+	constant.SlugMultipleLoan30Days,
+	constant.SlugMultipleLoan90Days,
+	constant.SlugPhoneLiveStatus,
+}
+
+// checkFileName is recorded as the file name of the rows of watchlist jobs.
+const checkFileName = "watchlist"
+
+func NewService(
+	repo Repository,
+	memberRepo member.Repository,
+	productRepo product.Repository,
+	jobService job.Service,
+	gatewaySvc gateway.Service,
+	webhookSvc webhook.Service,
+	subscriptionSvc subscription.Service,
+) Service {
+	return &service{
+		repo:            repo,
+		memberRepo:      memberRepo,
+		productRepo:     productRepo,
+		jobService:      jobService,
+		gatewaySvc:      gatewaySvc,
+		webhookSvc:      webhookSvc,
+		subscriptionSvc: subscriptionSvc,
+		notify:          mailjet.SendEmailWatchlistChanges,
+		now:             time.Now,
+	}
+}
+
+type service struct {
+	repo            Repository
+	memberRepo      member.Repository
+	productRepo     product.Repository
+	jobService      job.Service
+	gatewaySvc      gateway.Service
+	webhookSvc      webhook.Service
+	subscriptionSvc subscription.Service
+	notify          func(email string, subjects, changes int) error
+	now             func() time.Time
+}
+
+type Service interface {
+	AddSubject(memberId, companyId string, req *subjectRequest) (*Subject, error)
+	GetSubjects(filter *subjectFilter) (*model.AifcoreAPIResponse[[]*Subject], error)
+	DeleteSubject(companyId, subjectId string) error
+	GetSettings(companyId string) (*Settings, error)
+	SaveSettings(memberId, companyId string, req *settingsRequest) error
+	GetChangedSubjects(filter *changeFilter) (*model.AifcoreAPIResponse[[]*ChangedSubject], error)
+	RunDueChecks()
+}
+
+func (svc *service) AddSubject(memberId, companyId string, req *subjectRequest) (*Subject, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	handlers, err := svc.authorizedProducts(companyId, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := &Subject{Nik: req.Nik, PhoneNumber: req.PhoneNumber}
+	if len(checkableBy(handlers, subject)) == 0 {
+		return nil, apperror.BadRequest("no monitored product can check a subject with only these fields")
+	}
+
+	result, err := svc.repo.CreateSubjectAPI(&createSubjectPayload{
+		CompanyId:   companyId,
+		Nik:         req.Nik,
+		PhoneNumber: req.PhoneNumber,
+		Label:       strings.TrimSpace(req.Label),
+	})
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to add watchlist subject")
+	}
+
+	return result, nil
+}
+
+func (svc *service) GetSubjects(filter *subjectFilter) (*model.AifcoreAPIResponse[[]*Subject], error) {
+	result, err := svc.repo.GetSubjectsAPI(filter)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch watchlist subjects")
+	}
+
+	return result, nil
+}
+
+func (svc *service) DeleteSubject(companyId, subjectId string) error {
+	if err := svc.repo.DeleteSubjectAPI(companyId, subjectId); err != nil {
+		return apperror.MapRepoError(err, "failed to delete watchlist subject")
+	}
+
+	return nil
+}
+
+func (svc *service) GetSettings(companyId string) (*Settings, error) {
+	result, err := svc.repo.GetSettingsAPI(companyId)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch watchlist settings")
+	}
+
+	if result == nil {
+		return nil, apperror.NotFound("watchlist monitoring is not set up")
+	}
+
+	return result, nil
+}
+
+// SaveSettings sets when the watchlist is checked and who is alerted. Checks
+// run under the member saving the settings, who must be allowed to use at
+// least one of the monitored products.
+func (svc *service) SaveSettings(memberId, companyId string, req *settingsRequest) error {
+	if _, err := svc.authorizedProducts(companyId, memberId); err != nil {
+		return err
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+
+	nextRunAt, err := helper.NextCronTime(req.CronExpression, req.Timezone, svc.now())
+	if err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	for _, email := range req.AlertEmails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return apperror.BadRequest(fmt.Sprintf("invalid alert email %s", email))
+		}
+	}
+
+	status := StatusActive
+	if req.IsActive != nil && !*req.IsActive {
+		status = StatusPaused
+	}
+
+	if err := svc.repo.SaveSettingsAPI(companyId, map[string]interface{}{
+		"member_id":       memberId,
+		"cron_expression": req.CronExpression,
+		"timezone":        req.Timezone,
+		"status":          status,
+		"alert_emails":    req.AlertEmails,
+		"next_run_at":     nextRunAt,
+	}); err != nil {
+		return apperror.MapRepoError(err, "failed to save watchlist settings")
+	}
+
+	return nil
+}
+
+func (svc *service) GetChangedSubjects(filter *changeFilter) (*model.AifcoreAPIResponse[[]*ChangedSubject], error) {
+	result, err := svc.repo.GetChangedSubjectsAPI(filter)
+	if err != nil {
+		return nil, apperror.MapRepoError(err, "failed to fetch watchlist changes")
+	}
+
+	return result, nil
+}
+
+// RunDueChecks starts a check of every watchlist that is due, claiming it by
+// moving it to its next check time first so a long check, or a watchlist
+// polled by several instances, is not started twice.
+func (svc *service) RunDueChecks() {
+	now := svc.now()
+
+	due, err := svc.repo.GetDueSettingsAPI(now)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to fetch due watchlists")
+		return
+	}
+
+	for _, s := range due {
+		companyId := helper.ConvertUintToString(s.CompanyId)
+
+		data := map[string]interface{}{
+			"last_run_at": now,
+		}
+
+		nextRunAt, err := helper.NextCronTime(s.CronExpression, s.Timezone, now)
+		if err != nil {
+			data["status"] = StatusPaused
+		} else {
+			data["next_run_at"] = nextRunAt
+		}
+
+		claimed, err := svc.repo.ClaimSettingsAPI(companyId, &claimPayload{
+			ExpectedNextRunAt: s.NextRunAt,
+			Data:              data,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("company_id", companyId).Msg("failed to claim watchlist check, check skipped")
+			continue
+		}
+
+		if !claimed {
+			log.Info().Str("company_id", companyId).Msg("watchlist check already claimed, check skipped")
+			continue
+		}
+
+		if _, paused := data["status"]; paused {
+			continue
+		}
+
+		go svc.check(s)
+	}
+}
+
+// check re-checks every subject of a company against the monitored products
+// and records and alerts the changes since the previous check.
+func (svc *service) check(s *Settings) {
+	companyId := helper.ConvertUintToString(s.CompanyId)
+
+	owner, err := svc.memberRepo.GetMemberAPI(&member.FindUserQuery{
+		Id:        helper.ConvertUintToString(s.MemberId),
+		CompanyId: companyId,
+	})
+	if err != nil || owner == nil || owner.MemberId == 0 || !owner.Active {
+		log.Warn().Err(err).Str("company_id", companyId).Msg("watchlist owner is unavailable, monitoring paused")
+
+		if err := svc.repo.SaveSettingsAPI(companyId, map[string]interface{}{
+			"status": StatusPaused,
+		}); err != nil {
+			log.Error().Err(err).Str("company_id", companyId).Msg("failed to pause watchlist monitoring")
+		}
+
+		return
+	}
+
+	// the subscriptions or the owner's permissions may have changed since the
+	// settings were saved; products no longer allowed are skipped
+	handlers, err := svc.authorizedProducts(companyId, helper.ConvertUintToString(s.MemberId))
+	if err != nil {
+		log.Warn().Err(err).Str("company_id", companyId).Msg("watchlist owner is not authorized for any monitored product, check skipped")
+		return
+	}
+
+	subjects, err := svc.repo.GetAllSubjectsAPI(companyId)
+	if err != nil {
+		log.Error().Err(err).Str("company_id", companyId).Msg("failed to fetch watchlist subjects")
+		return
+	}
+
+	if len(subjects) == 0 {
+		return
+	}
+
+	results := make([]map[string]string, len(subjects))
+	for i := range results {
+		results[i] = map[string]string{}
+	}
+
+	for _, handler := range handlers {
+		if err := svc.checkProduct(handler, owner, subjects, results); err != nil {
+			log.Error().Err(err).Str("company_id", companyId).Str("product", handler.Slug).Msg("watchlist check failed")
+		}
+	}
+
+	checkedAt := svc.now()
+
+	var changes []*Change
+	changedSubjects := 0
+	for i, subject := range subjects {
+		subjectChanges := detectChanges(subject.Id, subject.Snapshot, results[i], checkedAt)
+
+		snapshot := make(map[string]string, len(subject.Snapshot)+len(results[i]))
+		for field, value := range subject.Snapshot {
+			snapshot[field] = value
+		}
+		for field, value := range results[i] {
+			snapshot[field] = value
+		}
+
+		data := map[string]interface{}{
+			"snapshot":        snapshot,
+			"last_checked_at": checkedAt,
+		}
+		if len(subjectChanges) > 0 {
+			data["last_changed_at"] = checkedAt
+			changedSubjects++
+			changes = append(changes, subjectChanges...)
+		}
+
+		if err := svc.repo.UpdateSubjectAPI(companyId, helper.ConvertUintToString(subject.Id), data); err != nil {
+			log.Error().Err(err).Uint("subject_id", subject.Id).Msg("failed to save watchlist snapshot")
+		}
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	if err := svc.repo.CreateChangesAPI(companyId, changes); err != nil {
+		log.Error().Err(err).Str("company_id", companyId).Msg("failed to record watchlist changes")
+	}
+
+	svc.alert(s, owner, changedSubjects, changes)
+}
+
+// authorizedProducts returns the monitored products the company is subscribed
+// to and the member is permitted to use. It fails with the subscription error
+// when there are none.
+func (svc *service) authorizedProducts(companyId, memberId string) ([]*registry.ProductHandler, error) {
+	var (
+		handlers []*registry.ProductHandler
+		denied   error
+	)
+
+	for _, slug := range monitoredProducts {
+		handler, ok := registry.Get(slug)
+		if !ok {
+			continue
+		}
+
+		if err := svc.subscriptionSvc.Authorize(companyId, memberId, slug); err != nil {
+			var appErr *apperror.AppError
+			if !apperror.AsAppError(err, &appErr) || appErr.StatusCode != http.StatusForbidden {
+				return nil, err
+			}
+
+			denied = err
+			continue
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	if len(handlers) == 0 {
+		if denied == nil {
+			denied = apperror.NotFound(constant.ProductNotFound)
+		}
+
+		return nil, denied
+	}
+
+	return handlers, nil
+}
+
+// checkableBy returns the handlers that have every request field of subject.
+func checkableBy(handlers []*registry.ProductHandler, subject *Subject) []*registry.ProductHandler {
+	var checkable []*registry.ProductHandler
+	for _, handler := range handlers {
+		if handler.HasInputs(subject.inputs()) {
+			checkable = append(checkable, handler)
+		}
+	}
+
+	return checkable
+}
+
+// checkProduct sends every subject that has the product's request fields to
+// the product as a job of the owner and collects the data values of the
+// successful responses into results.
+func (svc *service) checkProduct(handler *registry.ProductHandler, owner *member.MstMember, subjects []*Subject, results []map[string]string) error {
+	// subjects without the product's request fields are not sent at all, so
+	// they neither fail rows nor make up the job
+	var rows []int
+	for i, subject := range subjects {
+		if handler.HasInputs(subject.inputs()) {
+			rows = append(rows, i)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	product, err := svc.productRepo.GetProductAPI(handler.Slug)
+	if err != nil {
+		return err
+	}
+	if product.ProductId == 0 {
+		return fmt.Errorf(constant.ProductNotFound)
+	}
+
+	memberIdStr := helper.ConvertUintToString(owner.MemberId)
+	companyIdStr := helper.ConvertUintToString(owner.CompanyId)
+
+	jobRes, err := svc.jobService.CreateJob(&job.CreateJobRequest{
+		ProductId: product.ProductId,
+		MemberId:  memberIdStr,
+		CompanyId: companyIdStr,
+		Total:     len(rows),
+		Calls:     handler.CallCount(),
+	})
+	if err != nil {
+		return err
+	}
+	jobIdStr := helper.ConvertUintToString(jobRes.JobId)
+
+	params := &registry.CallParams{
+		APIKey:    owner.Key,
+		JobId:     jobIdStr,
+		MemberId:  memberIdStr,
+		CompanyId: companyIdStr,
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		batchCount = 0
+	)
+
+	for rowNumber, i := range rows {
+		req, err := handler.RequestFromFields(subjects[i].inputs())
+		if err != nil {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i, rowNumber int, req any) {
+			defer wg.Done()
+
+			resp, err := svc.gatewaySvc.ProcessRow(&gateway.RowContext{
+				Handler:        handler,
+				Params:         params,
+				MemberId:       owner.MemberId,
+				CompanyId:      owner.CompanyId,
+				ProductId:      product.ProductId,
+				ProductGroupId: product.ProductGroupId,
+				JobId:          jobRes.JobId,
+				RowNumber:      rowNumber,
+				FileName:       checkFileName,
+				Request:        req,
+			})
+			if err != nil || resp == nil {
+				return
+			}
+
+			values := handler.DataValues(resp.Data)
+
+			mu.Lock()
+			for field, value := range values {
+				results[i][field] = value
+			}
+			mu.Unlock()
+		}(i, rowNumber+1, req)
+
+		batchCount++
+		if batchCount == 100 {
+			time.Sleep(time.Second)
+			batchCount = 0
+		}
+	}
+
+	wg.Wait()
+
+	return svc.jobService.FinalizeJob(jobIdStr, companyIdStr)
+}
+
+// alert emails the alert addresses, or the owner when none are set, and
+// sends the watchlist.changed webhook event.
+func (svc *service) alert(s *Settings, owner *member.MstMember, changedSubjects int, changes []*Change) {
+	companyId := helper.ConvertUintToString(s.CompanyId)
+
+	recipients := s.AlertEmails
+	if len(recipients) == 0 {
+		recipients = []string{owner.Email}
+	}
+
+	for _, email := range recipients {
+		if err := svc.notify(email, changedSubjects, len(changes)); err != nil {
+			log.Warn().Err(err).Str("company_id", companyId).Msg("failed to send watchlist alert email")
+		}
+	}
+
+	svc.webhookSvc.Dispatch(companyId, webhook.EventWatchlistChanged, &ChangeEventData{
+		Subjects: changedSubjects,
+		Changes:  changes,
+	})
+}
@@ -0,0 +1,98 @@
+package watchlist
+
+import (
+	"errors"
+	"front-office/internal/datahub/compliance/multipleloan"
+	"front-office/internal/datahub/identity/phonelivestatus"
+	"front-office/internal/datahub/registry"
+	"front-office/pkg/apperror"
+	"front-office/pkg/common/constant"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionService) Authorize(companyId, memberId, productSlug string) error {
+	args := m.Called(companyId, memberId, productSlug)
+	return args.Error(0)
+}
+
+func registerMonitoredProducts() {
+	for _, handler := range multipleloan.NewHandlers(nil) {
+		registry.Register(handler)
+	}
+	registry.Register(phonelivestatus.NewHandler(nil))
+}
+
+func TestAuthorizedProducts(t *testing.T) {
+	registerMonitoredProducts()
+
+	forbidden := apperror.Forbidden("product is not subscribed")
+
+	t.Run("should skip the products the member may not use", func(t *testing.T) {
+		subscriptionSvc := new(MockSubscriptionService)
+		subscriptionSvc.On("Authorize", "1", "5", constant.SlugPhoneLiveStatus).Return(nil)
+		subscriptionSvc.On("Authorize", "1", "5", mock.Anything).Return(forbidden)
+
+		svc := &service{subscriptionSvc: subscriptionSvc}
+		handlers, err := svc.authorizedProducts("1", "5")
+
+		require.NoError(t, err)
+		require.Len(t, handlers, 1)
+		assert.Equal(t, constant.SlugPhoneLiveStatus, handlers[0].Slug)
+	})
+
+	t.Run("should refuse a member allowed none of the products", func(t *testing.T) {
+		subscriptionSvc := new(MockSubscriptionService)
+		subscriptionSvc.On("Authorize", "1", "5", mock.Anything).Return(forbidden)
+
+		svc := &service{subscriptionSvc: subscriptionSvc}
+		_, err := svc.authorizedProducts("1", "5")
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("should fail when the subscriptions cannot be read", func(t *testing.T) {
+		subscriptionSvc := new(MockSubscriptionService)
+		subscriptionSvc.On("Authorize", "1", "5", mock.Anything).Return(errors.New("connection refused"))
+
+		svc := &service{subscriptionSvc: subscriptionSvc}
+		_, err := svc.authorizedProducts("1", "5")
+
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestCheckableBy(t *testing.T) {
+	registerMonitoredProducts()
+
+	var handlers []*registry.ProductHandler
+	for _, slug := range monitoredProducts {
+		handler, _ := registry.Get(slug)
+		handlers = append(handlers, handler)
+	}
+
+	t.Run("should check a subject with both fields against every product", func(t *testing.T) {
+		checkable := checkableBy(handlers, &Subject{Nik: "3201010101010001", PhoneNumber: "6281234567890"})
+		assert.Len(t, checkable, len(monitoredProducts))
+	})
+
+	t.Run("should only check a phone only subject against phone live status", func(t *testing.T) {
+		checkable := checkableBy(handlers, &Subject{PhoneNumber: "6281234567890"})
+		require.Len(t, checkable, 1)
+		assert.Equal(t, constant.SlugPhoneLiveStatus, checkable[0].Slug)
+	})
+
+	t.Run("should not check a NIK only subject against any product", func(t *testing.T) {
+		assert.Empty(t, checkableBy(handlers, &Subject{Nik: "3201010101010001"}))
+	})
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"front-office/pkg/common/constant"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ForwardDeprecated serves requests under a deprecated route prefix with the
+// routes under its successor, which must have the same paths below the
+// prefix. Responses are marked deprecated and link to the successor route.
+// The successor handlers see the request as deprecated, see IsDeprecated, so
+// they can keep the ids and response shapes of the deprecated routes.
+func ForwardDeprecated(prefix, successor string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := strings.Replace(c.Path(), prefix, successor, 1)
+
+		c.Set(constant.HeaderDeprecation, "true")
+		c.Set(constant.HeaderLink, fmt.Sprintf(`<%s>; rel="successor-version"`, path))
+		c.Locals(constant.Forwarded, true)
+
+		c.Path(path)
+
+		return c.RestartRouting()
+	}
+}
+
+// IsDeprecated reports whether the request was forwarded from a deprecated
+// route by ForwardDeprecated.
+func IsDeprecated(c *fiber.Ctx) bool {
+	deprecated, _ := c.Locals(constant.Forwarded).(bool)
+	return deprecated
+}
@@ -3,6 +3,7 @@ package genretail
 import (
 	"bytes"
 	"fmt"
+	"front-office/internal/core/masking"
 	"front-office/pkg/apperror"
 	"front-office/pkg/helper"
 	"time"
@@ -143,6 +144,8 @@ func (ctrl *controller) ExportJobDetails(c *fiber.Ctx) error {
 		StartDate: c.Query(constant.StartDate),
 		EndDate:   c.Query(constant.EndDate),
 		Size:      constant.SizeUnlimited,
+		IsMasked:  masking.IsMasked(c),
+		DecodeNIK: c.QueryBool(constant.DecodeNIK),
 	}
 
 	if filter.StartDate == "" || filter.EndDate == "" {
@@ -2,11 +2,18 @@ package genretail
 
 import (
 	"front-office/configs/application"
+	"front-office/internal/core/audit"
 	"front-office/internal/core/grade"
 	"front-office/internal/core/log/operation"
 	"front-office/internal/core/log/transaction"
+	"front-office/internal/core/masking"
+	"front-office/internal/core/member"
 	"front-office/internal/core/product"
+	"front-office/internal/core/role"
+	"front-office/internal/core/subscription"
+	"front-office/internal/datahub/decision"
 	"front-office/internal/middleware"
+	"front-office/pkg/common/constant"
 	"front-office/pkg/httpclient"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,17 +25,23 @@ func SetupInit(apiGroup fiber.Router, cfg *application.Config, client httpclient
 	transRepo := transaction.NewRepository(cfg, client, nil)
 	productRepo := product.NewRepository(cfg, client)
 	logRepo := operation.NewRepository(cfg, client, nil)
+	decisionRepo := decision.NewRepository(cfg, client, nil)
+	memberRepo := member.NewRepository(cfg, client, nil)
+	subscriptionRepo := subscription.NewRepository(cfg, client)
 
-	service := NewService(repo, gradeRepo, transRepo, productRepo, logRepo)
+	service := NewService(repo, gradeRepo, transRepo, productRepo, logRepo, decision.NewService(decisionRepo))
 
 	controller := NewController(service)
+	resolveMasking := masking.Resolve(masking.NewService(masking.NewRepository(cfg, client, nil), role.NewRepository(cfg, client)))
+	auditService := audit.NewService(logRepo, memberRepo)
+	requireGenRetail := subscription.RequireProduct(subscription.NewService(subscriptionRepo, memberRepo), constant.SlugGenRetailV3)
 
 	genRetailGroup := apiGroup.Group("gen-retail")
 	genRetailGroup.Post("/dummy-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(genRetailRequest{}), controller.DummyRequestScore)
-	genRetailGroup.Post("/single-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), middleware.IsRequestValid(genRetailRequest{}), controller.SingleRequest)
-	genRetailGroup.Post("/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.BulkRequest)
+	genRetailGroup.Post("/single-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), requireGenRetail, middleware.IsRequestValid(genRetailRequest{}), controller.SingleRequest)
+	genRetailGroup.Post("/bulk-request", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), requireGenRetail, controller.BulkRequest)
 	genRetailGroup.Get("/logs", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetLogsScoreezy)
-	genRetailGroup.Get("/logs/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.ExportJobDetails)
+	genRetailGroup.Get("/logs/export", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), resolveMasking, audit.Export(auditService, constant.EventDownloadScoreHistory, constant.SlugGenRetailV3), controller.ExportJobDetails)
 	genRetailGroup.Get("/logs/:trx_id", middleware.Auth(), middleware.GetJWTPayloadFromCookie(), controller.GetLogScoreezy)
 	// genRetailAPI.Put("/upload-scoring-template", middleware.Auth(), middleware.IsRequestValid(UploadScoringRequest{}), middleware.GetJWTPayloadFromCookie(), middleware.DocUpload(), controller.UploadCSV)
 }
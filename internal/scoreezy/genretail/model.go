@@ -3,6 +3,7 @@ package genretail
 import (
 	"front-office/internal/core/company"
 	"front-office/internal/core/member"
+	"front-office/pkg/utility/identity"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,11 +11,28 @@ import (
 
 type genRetailRequest struct {
 	Name     string `json:"name" validate:"required~Name cannot be empty."`
-	IdCardNo string `json:"id_card_no" validate:"required~ID Card No cannot be empty., numeric~ID Card No is only number, length(16)~ID Card No must be 16 digit number."`
-	PhoneNo  string `json:"phone_no" validate:"required~Phone number cannot be empty, phone~Phone No only allow number and (+)plus sign with minimum 10 maximum 15 digit.,bytelength(10|15)~Phone No only allow number and (+)plus sign with minimum 10 maximum 15 digit."`
+	IdCardNo string `json:"id_card_no" validate:"required~ID Card No cannot be empty."`
+	PhoneNo  string `json:"phone_no" validate:"required~Phone number cannot be empty."`
 	LoanNo   string `json:"loan_no" validate:"required~Loan No cannot be empty."`
 }
 
+// Normalize checks the ID card number and brings the phone number to canonical form.
+func (r *genRetailRequest) Normalize() error {
+	idCardNo, err := identity.NormalizeNIK(r.IdCardNo)
+	if err != nil {
+		return err
+	}
+
+	phoneNo, err := identity.NormalizePhone(r.PhoneNo)
+	if err != nil {
+		return err
+	}
+
+	r.IdCardNo, r.PhoneNo = idCardNo, phoneNo
+
+	return nil
+}
+
 type GenRetailV3ModelResponse struct {
 	Message      string           `json:"message"`
 	ErrorMessage string           `json:"error_message"`
@@ -47,6 +65,8 @@ type filterLogs struct {
 	CompanyId string
 	Grade     string
 	Size      string
+	IsMasked  bool
+	DecodeNIK bool
 }
 
 type GenRetailV3ClientReturnSuccess struct {
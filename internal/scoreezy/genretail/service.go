@@ -8,10 +8,13 @@ import (
 	"front-office/internal/core/log/operation"
 	"front-office/internal/core/log/transaction"
 	"front-office/internal/core/product"
+	"front-office/internal/datahub/decision"
 	"front-office/pkg/apperror"
 	"front-office/pkg/common/constant"
 	"front-office/pkg/common/model"
 	"front-office/pkg/helper"
+	"front-office/pkg/utility/identity"
+	"front-office/pkg/utility/mask"
 	"log"
 	"mime/multipart"
 	"strconv"
@@ -29,8 +32,9 @@ func NewService(
 	transRepo transaction.Repository,
 	productRepo product.Repository,
 	logRepo operation.Repository,
+	decisionSvc decision.Service,
 ) Service {
-	return &service{repo, gradeRepo, transRepo, productRepo, logRepo}
+	return &service{repo, gradeRepo, transRepo, productRepo, logRepo, decisionSvc}
 }
 
 type service struct {
@@ -39,6 +43,7 @@ type service struct {
 	transRepo   transaction.Repository
 	productRepo product.Repository
 	logRepo     operation.Repository
+	decisionSvc decision.Service
 }
 
 type Service interface {
@@ -53,6 +58,10 @@ type Service interface {
 }
 
 func (svc *service) GenRetailV3(memberId, companyId uint, payload *genRetailRequest) (*model.ScoreezyAPIResponse[dataGenRetailV3], error) {
+	if err := payload.Normalize(); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
 	// make sure parameter settings are set
 	productSlug := constant.SlugGenRetailV3
 	grade, err := svc.gradeRepo.GetGradesAPI(productSlug, strconv.FormatUint(uint64(companyId), 10))
@@ -227,7 +236,12 @@ func (svc *service) ExportJobDetails(filter *filterLogs, buf *bytes.Buffer) (str
 	var mappedDetails []*logTransScoreezy
 	mappedDetails = append(mappedDetails, result.Data...)
 
-	if err := writeToCSV(buf, mappedDetails); err != nil {
+	ruleSet, err := svc.decisionSvc.GetActiveRuleSet(filter.CompanyId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeToCSV(buf, mappedDetails, ruleSet, filter.IsMasked, filter.DecodeNIK); err != nil {
 		return "", apperror.Internal("failed to write CSV", err)
 	}
 
@@ -236,16 +250,42 @@ func (svc *service) ExportJobDetails(filter *filterLogs, buf *bytes.Buffer) (str
 	return filename, nil
 }
 
-func writeToCSV(buf *bytes.Buffer, logs []*logTransScoreezy) error {
+func writeToCSV(buf *bytes.Buffer, logs []*logTransScoreezy, ruleSet *decision.RuleSet, isMasked, decodeNIK bool) error {
+	nikColumns := identity.NIKColumns
+	if isMasked {
+		nikColumns = identity.MaskedNIKColumns
+	}
+
 	w := csv.NewWriter(buf)
 	headers := []string{"Date Created", "Name", "Loan ID", "ID Card Number", "Phone Number", "Probability To Default", "Grade", "Description"}
+	if ruleSet != nil {
+		headers = append(headers, decision.Headers...)
+	}
+	if decodeNIK {
+		headers = append(headers, identity.NIKHeaders...)
+	}
 
 	if err := w.Write(headers); err != nil {
 		return err
 	}
 
 	for _, log := range logs {
-		row := []string{log.CreatedAt.Format(constant.FormatDateAndTime), log.Data.Name, log.Data.LoanNo, log.Data.IdCardNo, log.Data.PhoneNumber, log.ProbabilityToDefault, log.Grade, log.Message}
+		name, idCardNo, phoneNumber := log.Data.Name, log.Data.IdCardNo, log.Data.PhoneNumber
+		if isMasked {
+			name, idCardNo, phoneNumber = mask.Name(name), mask.NIK(idCardNo), mask.Phone(phoneNumber)
+		}
+
+		row := []string{log.CreatedAt.Format(constant.FormatDateAndTime), name, log.Data.LoanNo, idCardNo, phoneNumber, log.ProbabilityToDefault, log.Grade, log.Message}
+		if ruleSet != nil {
+			outcome := decision.Evaluate(ruleSet, map[string]string{
+				decision.FieldGenRetailGrade:                log.Grade,
+				decision.FieldGenRetailProbabilityToDefault: log.ProbabilityToDefault,
+			})
+			row = append(row, outcome.Columns()...)
+		}
+		if decodeNIK {
+			row = append(row, nikColumns(log.Data.IdCardNo)...)
+		}
 		if err := w.Write(row); err != nil {
 			return err
 		}
@@ -264,7 +304,12 @@ func formatCSVFileName(base, startDate, endDate string) string {
 }
 
 func (svc *service) processSingleGenRetail(params *genRetailContext) error {
-	if err := validator.ValidateStruct(params.Request); err != nil {
+	err := validator.ValidateStruct(params.Request)
+	if err == nil {
+		err = params.Request.Normalize()
+	}
+
+	if err != nil {
 		_ = svc.transRepo.CreateLogScoreezyAPI(&transaction.LogTransScoreezy{
 			TrxId:     uuid.NewString(),
 			MemberId:  params.MemberId,
@@ -278,7 +323,7 @@ func (svc *service) processSingleGenRetail(params *genRetailContext) error {
 		return apperror.BadRequest(err.Error())
 	}
 
-	_, err := svc.repo.GenRetailV3API(strconv.FormatUint(uint64(params.MemberId), 10), params.Request)
+	_, err = svc.repo.GenRetailV3API(strconv.FormatUint(uint64(params.MemberId), 10), params.Request)
 	if err != nil {
 		return apperror.MapRepoError(err, "failed to process gen retail v3")
 	}
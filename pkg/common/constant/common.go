@@ -6,6 +6,10 @@ const (
 	JobStatusDone       = "done"
 	JobStatusFailed     = "failed"
 	JobStatusError      = "error"
+	JobStatusMigrated   = "migrated"
+
+	PricingStrategyPay  = "PAY"
+	PricingStrategyFree = "FREE"
 
 	FormatDateAndTime = "2006-01-02 15:04:05"
 	FormatYYYYMMDD    = "2006-01-02"
@@ -47,6 +47,9 @@ const (
 	ErrorReadingCSVRecords = "error reading CSV records"
 	ErrorUploadDataCSV     = "error upload data CSV file"
 	FailedParseCSV         = "failed to parse csv"
+	InvalidExportMode      = "mode must be one of standard, enrichment"
+	UnsupportedProductSlug = "unsupported product slug"
+	NIKDecodingUnsupported = "decode_nik is only available for products with a NIK input"
 
 	//parameter settings
 	ParamSettingIsNotSet = "parameter settings is not set"
@@ -56,7 +59,9 @@ const (
 	InvalidStatusValue = "invalid value for 'status'"
 	SendEmailFailed    = "send email failed"
 
-	ProductNotFound = "product not found"
+	ProductNotFound      = "product not found"
+	ProductNotSubscribed = "company is not subscribed to this product"
+	ProductNotPermitted  = "member is not permitted to use this product"
 
 	ErrFailedMarshalReq   = "failed to marshal request body"
 	ErrHTTPReqFailed      = "failed to make HTTP request"
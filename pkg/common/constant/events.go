@@ -16,4 +16,6 @@ const (
 	EventChangeBillingInformation  = "change billing information"
 	EventTopupBalance              = "topup balance"
 	EventSubmitPaymentConfirmation = "submit payment confirmation"
+	EventExportData                = "export data"
+	EventViewUnmaskedData          = "view unmasked data"
 )
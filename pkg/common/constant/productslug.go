@@ -9,6 +9,7 @@ const (
 	SlugMultipleLoan7Days  = "COMPLIANCE_7d_multiple_loan"
 	SlugMultipleLoan30Days = "COMPLIANCE_30d_multiple_loan"
 	SlugMultipleLoan90Days = "COMPLIANCE_90d_multiple_loan"
+	SlugMultipleLoanAll    = "COMPLIANCE_all_multiple_loan"
 
 	SlugTaxComplianceStatus   = "INCOMETAX_tax_compliance_status"
 	SlugTaxScore              = "INCOMETAX_tax_score"
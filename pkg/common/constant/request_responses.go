@@ -3,6 +3,8 @@ package constant
 const (
 	HeaderContentType        = "Content-Type"
 	HeaderContentDisposition = "Content-Disposition"
+	HeaderDeprecation        = "Deprecation"
+	HeaderLink               = "Link"
 	HeaderApplicationJSON    = "application/json"
 	XAPIKey                  = "X-API-KEY"
 	XUIDKey                  = "X-UID-KEY"
@@ -10,6 +12,10 @@ const (
 	XCompanyId               = "X-Company-ID"
 	XTierLevel               = "X-Tier-Level"
 	TextOrCSVContentType     = "text/csv"
+	PDFContentType           = "application/pdf"
+	XWebhookEvent            = "X-Webhook-Event"
+	XWebhookDelivery         = "X-Webhook-Delivery"
+	XWebhookSignature        = "X-Webhook-Signature"
 
 	SizeUnlimited = "-1"
 
@@ -18,12 +24,33 @@ const (
 	UserId    = "userId"
 	CompanyId = "companyId"
 	RoleId    = "roleId"
+	Masked    = "masked"
+	Forwarded = "forwarded"
 	Page      = "page"
 	Size      = "size"
 	JobId     = "job_id"
 	StartDate = "start_date"
 	EndDate   = "end_date"
 	Keyword   = "keyword"
+	SortBy    = "sort_by"
+	SortOrder = "sort_order"
+	RowNumber = "row_number"
+	CreatedAt = "created_at"
+	Cursor    = "cursor"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	MappingId = "mapping_id"
+	Mapping   = "mapping"
+
+	MergedField = "merged_field"
+
+	ExportMode           = "mode"
+	ExportModeStandard   = "standard"
+	ExportModeEnrichment = "enrichment"
+
+	DecodeNIK = "decode_nik"
 
 	MockHost        = "http://mock-host"
 	MockInvalidHost = "http://[::1]:namedport"
@@ -0,0 +1,161 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CSVColumn is a product input field and the template header it is read from.
+type CSVColumn struct {
+	Field  string `json:"field"`
+	Header string `json:"header"`
+}
+
+// CSVHeaders returns the template headers of columns in order.
+func CSVHeaders(columns []CSVColumn) []string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	return headers
+}
+
+// ValidateColumnMapping checks that mapping, keyed by source header or
+// zero-based column index, targets known fields and covers every column once.
+func ValidateColumnMapping(columns []CSVColumn, mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return errors.New("mapping is required")
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column.Field] = true
+	}
+
+	mapped := make(map[string]string, len(mapping))
+	for source, field := range mapping {
+		if strings.TrimSpace(source) == "" {
+			return fmt.Errorf("mapping source for field %s is empty", field)
+		}
+
+		if !known[field] {
+			return fmt.Errorf("unknown field: %s", field)
+		}
+
+		if prev, ok := mapped[field]; ok {
+			return fmt.Errorf("field %s is mapped from both %s and %s", field, prev, source)
+		}
+
+		mapped[field] = source
+	}
+
+	for _, column := range columns {
+		if _, ok := mapped[column.Field]; !ok {
+			return fmt.Errorf("required field %s is not mapped", column.Field)
+		}
+	}
+
+	return nil
+}
+
+// ResolveColumnMapping validates mapping and returns, for each column, the
+// index of the source column it is read from. A mapping key that matches a
+// header is preferred over reading it as an index.
+func ResolveColumnMapping(header []string, columns []CSVColumn, mapping map[string]string) ([]int, error) {
+	if err := ValidateColumnMapping(columns, mapping); err != nil {
+		return nil, err
+	}
+
+	byField := make(map[string]int, len(mapping))
+	for source, field := range mapping {
+		index, err := resolveSourceColumn(header, source)
+		if err != nil {
+			return nil, err
+		}
+
+		byField[field] = index
+	}
+
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = byField[column.Field]
+	}
+
+	return indexes, nil
+}
+
+func resolveSourceColumn(header []string, source string) (int, error) {
+	for i, h := range header {
+		if strings.TrimSpace(h) == strings.TrimSpace(source) {
+			return i, nil
+		}
+	}
+
+	index, err := strconv.Atoi(source)
+	if err != nil {
+		return 0, fmt.Errorf("column %s not found in uploaded file", source)
+	}
+
+	if index < 0 || index >= len(header) {
+		return 0, fmt.Errorf("column index %d is out of range", index)
+	}
+
+	return index, nil
+}
+
+// ProjectCSVRecords rearranges records into template order using the indexes
+// from ResolveColumnMapping. The header row is replaced with the template
+// headers; cells missing from short rows are left empty.
+func ProjectCSVRecords(records [][]string, columns []CSVColumn, indexes []int) [][]string {
+	projected := make([][]string, len(records))
+	for i, record := range records {
+		if i == 0 {
+			projected[i] = CSVHeaders(columns)
+			continue
+		}
+
+		row := make([]string, len(indexes))
+		for j, index := range indexes {
+			if index < len(record) {
+				row[j] = record[index]
+			}
+		}
+		projected[i] = row
+	}
+
+	return projected
+}
+
+// SourceFields names the field each column of an uploaded source is read
+// from, given the header of the source and the indexes from
+// ResolveColumnMapping. Columns no field is read from are left empty.
+func SourceFields(header []string, columns []CSVColumn, indexes []int) []string {
+	fields := make([]string, len(header))
+	for j, index := range indexes {
+		if j < len(columns) && index >= 0 && index < len(fields) {
+			fields[index] = columns[j].Field
+		}
+	}
+
+	return fields
+}
+
+// HeaderFields names the field of each header cell matching a column's
+// template header or field name, ignoring case.
+func HeaderFields(header []string, columns []CSVColumn) []string {
+	fields := make([]string, len(header))
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		for _, column := range columns {
+			if strings.EqualFold(h, column.Header) || strings.EqualFold(h, column.Field) {
+				fields[i] = column.Field
+				break
+			}
+		}
+	}
+
+	return fields
+}
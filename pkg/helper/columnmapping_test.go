@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"front-office/pkg/common/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []CSVColumn{
+	{Field: "nik", Header: "ID Card Number"},
+	{Field: "phone_number", Header: "Phone Number"},
+}
+
+func TestValidateColumnMapping(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		err := ValidateColumnMapping(testColumns, map[string]string{"KTP": "nik", "2": "phone_number"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Empty Mapping", func(t *testing.T) {
+		err := ValidateColumnMapping(testColumns, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		err := ValidateColumnMapping(testColumns, map[string]string{"KTP": "nik", "HP": "phone_number", "NPWP": "npwp"})
+		assert.EqualError(t, err, "unknown field: npwp")
+	})
+
+	t.Run("Missing Required Field", func(t *testing.T) {
+		err := ValidateColumnMapping(testColumns, map[string]string{"KTP": "nik"})
+		assert.EqualError(t, err, "required field phone_number is not mapped")
+	})
+
+	t.Run("Field Mapped Twice", func(t *testing.T) {
+		err := ValidateColumnMapping(testColumns, map[string]string{"KTP": "nik", "NIK": "nik", "HP": "phone_number"})
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveColumnMapping(t *testing.T) {
+	header := []string{"Customer", "KTP", "Branch", "HP"}
+
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		indexes, err := ResolveColumnMapping(header, testColumns, map[string]string{"KTP": "nik", "3": "phone_number"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, indexes)
+	})
+
+	t.Run("Header Not Found", func(t *testing.T) {
+		_, err := ResolveColumnMapping(header, testColumns, map[string]string{"NIK": "nik", "HP": "phone_number"})
+		assert.EqualError(t, err, "column NIK not found in uploaded file")
+	})
+
+	t.Run("Index Out Of Range", func(t *testing.T) {
+		_, err := ResolveColumnMapping(header, testColumns, map[string]string{"KTP": "nik", "4": "phone_number"})
+		assert.EqualError(t, err, "column index 4 is out of range")
+	})
+}
+
+func TestProjectCSVRecords(t *testing.T) {
+	records := [][]string{
+		{"Customer", "KTP", "Branch", "HP"},
+		{"Budi", "3201", "Jakarta", "0812"},
+		{"Sari", "3202"},
+	}
+
+	projected := ProjectCSVRecords(records, testColumns, []int{1, 3})
+
+	assert.Equal(t, [][]string{
+		{"ID Card Number", "Phone Number"},
+		{"3201", "0812"},
+		{"3202", ""},
+	}, projected)
+}
+
+func TestSourceFields(t *testing.T) {
+	header := []string{"Ref", "HP", "KTP"}
+
+	t.Run("should name the columns the fields are read from", func(t *testing.T) {
+		assert.Equal(t, []string{"", "phone_number", "nik"}, SourceFields(header, testColumns, []int{2, 1}))
+	})
+
+	t.Run("should name the columns of a template upload", func(t *testing.T) {
+		template := CSVHeaders(testColumns)
+		assert.Equal(t, []string{"nik", "phone_number"}, SourceFields(template, testColumns, []int{0, 1}))
+	})
+}
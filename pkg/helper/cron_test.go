@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{"* * * * *", "*/15 0-6 1,15 * 1-5", "0 9 * * 7", "@monthly", "30 8 1-7/2 */3 *"}
+	for _, expr := range valid {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"}
+	for _, expr := range invalid {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	from := time.Date(2024, 1, 31, 10, 30, 0, 0, jakarta) // Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 31, 10, 31, 0, 0, jakarta)},
+		{"*/15 * * * *", time.Date(2024, 1, 31, 10, 45, 0, 0, jakarta)},
+		{"0 9 * * *", time.Date(2024, 2, 1, 9, 0, 0, 0, jakarta)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta)},
+		{"0 0 31 * *", time.Date(2024, 3, 31, 0, 0, 0, 0, jakarta)},
+		{"0 8 * * 1", time.Date(2024, 2, 5, 8, 0, 0, 0, jakarta)},
+		{"0 8 * * 7", time.Date(2024, 2, 4, 8, 0, 0, 0, jakarta)},
+		{"0 8 15 * 5", time.Date(2024, 2, 2, 8, 0, 0, 0, jakarta)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, jakarta)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.expected, schedule.Next(from), tt.expr)
+	}
+
+	never, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(from).IsZero())
+}
+
+func TestNextCronTime(t *testing.T) {
+	now := time.Date(2024, 1, 31, 2, 30, 0, 0, time.UTC) // 09:30 in Jakarta
+
+	t.Run("should fire in the given timezone", func(t *testing.T) {
+		next, err := NextCronTime("0 8 1 * *", "Asia/Jakarta", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("should reject unknown timezones", func(t *testing.T) {
+		_, err := NextCronTime("0 8 1 * *", "Mars/Olympus", now)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject expressions that never fire", func(t *testing.T) {
+		_, err := NextCronTime("0 0 30 2 *", "UTC", now)
+
+		assert.Error(t, err)
+	})
+}
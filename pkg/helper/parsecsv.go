@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"bytes"
 	"encoding/csv"
 	"errors"
 	"front-office/pkg/common/constant"
@@ -9,6 +10,26 @@ import (
 )
 
 func ParseCSVFile(file *multipart.FileHeader, expectedHeaders []string) ([][]string, error) {
+	csvData, err := ReadCSVFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	header := csvData[0]
+	if len(header) < len(expectedHeaders) {
+		return nil, errors.New(constant.HeaderTemplateNotValid)
+	}
+	for i, expectedHeader := range expectedHeaders {
+		if header[i] != expectedHeader {
+			return nil, errors.New(constant.HeaderTemplateNotValid)
+		}
+	}
+
+	return csvData, nil
+}
+
+// ReadCSVFile reads every record of an uploaded CSV without checking its header.
+func ReadCSVFile(file *multipart.FileHeader) ([][]string, error) {
 	f, err := file.Open()
 	if err != nil {
 		return nil, err
@@ -30,15 +51,46 @@ func ParseCSVFile(file *multipart.FileHeader, expectedHeaders []string) ([][]str
 		return nil, errors.New("empty csv file")
 	}
 
-	header := csvData[0]
-	if len(header) < len(expectedHeaders) {
-		return nil, errors.New(constant.HeaderTemplateNotValid)
+	return csvData, nil
+}
+
+// CSVRowNumber converts an index into the records returned by ParseCSVFile
+// into the row number shown by spreadsheet tools, where the header is row 1.
+func CSVRowNumber(recordIndex int) int {
+	return recordIndex + 1
+}
+
+// WriteEnrichedCSV re-emits records, header first, in their original order with
+// resultHeaders appended. results is keyed by CSVRowNumber. Short rows and rows
+// without a result are padded with empty cells so the result columns stay aligned.
+func WriteEnrichedCSV(buf *bytes.Buffer, records [][]string, resultHeaders []string, results map[int][]string) error {
+	if len(records) == 0 {
+		return errors.New("empty csv file")
 	}
-	for i, expectedHeader := range expectedHeaders {
-		if header[i] != expectedHeader {
-			return nil, errors.New(constant.HeaderTemplateNotValid)
+
+	width := 0
+	for _, record := range records {
+		if len(record) > width {
+			width = len(record)
 		}
 	}
 
-	return csvData, nil
+	writer := csv.NewWriter(buf)
+	for i, record := range records {
+		row := make([]string, width+len(resultHeaders))
+		copy(row, record)
+
+		if i == 0 {
+			copy(row[width:], resultHeaders)
+		} else {
+			copy(row[width:], results[CSVRowNumber(i)])
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
 }
@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"bytes"
+	"front-office/pkg/common/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteEnrichedCSV(t *testing.T) {
+	t.Run(constant.TestCaseSuccess, func(t *testing.T) {
+		records := [][]string{
+			{"NIK", "Phone Number", "Branch"},
+			{"3201", "0812", "Jakarta"},
+			{"3202", "0813"},
+			{"3203", "0814", "Bandung"},
+		}
+		results := map[int][]string{
+			2: {"5", "success"},
+			4: {"0", "fail"},
+		}
+
+		var buf bytes.Buffer
+		err := WriteEnrichedCSV(&buf, records, []string{"Query Count", "Status"}, results)
+
+		require.NoError(t, err)
+		assert.Equal(t, "NIK,Phone Number,Branch,Query Count,Status\n"+
+			"3201,0812,Jakarta,5,success\n"+
+			"3202,0813,,,\n"+
+			"3203,0814,Bandung,0,fail\n", buf.String())
+	})
+
+	t.Run("Empty Records", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteEnrichedCSV(&buf, nil, []string{"Status"}, nil)
+
+		assert.Error(t, err)
+	})
+}
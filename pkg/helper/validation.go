@@ -1,9 +1,12 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
+	"front-office/pkg/common/constant"
 	"mime/multipart"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -21,3 +24,28 @@ func ValidateUploadedFile(file *multipart.FileHeader, maxSize int64, allowedExte
 
 	return fmt.Errorf("invalid file type, allowed: %v", allowedExtensions)
 }
+
+func ValidateSortParams(sortBy, sortOrder string, allowedFields []string) error {
+	if sortBy != "" && !IsValidTemplateHeader(allowedFields, sortBy) {
+		return fmt.Errorf("invalid sort_by, allowed: %v", allowedFields)
+	}
+
+	if sortOrder != "" && sortOrder != constant.SortAsc && sortOrder != constant.SortDesc {
+		return fmt.Errorf("invalid sort_order, allowed: [%s %s]", constant.SortAsc, constant.SortDesc)
+	}
+
+	return nil
+}
+
+func ValidateRowNumber(rowNumber string) error {
+	if rowNumber == "" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(rowNumber)
+	if err != nil || n < 1 {
+		return errors.New("row_number must be a positive number")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned when a public client is asked to connect
+// to a loopback, private, link-local or otherwise internal address.
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// NewPublicClient returns a client for user supplied URLs. It only connects
+// to public addresses, checked on the address actually dialed so a host name
+// cannot be re-resolved to an internal one, and it does not follow
+// redirects.
+func NewPublicClient(timeout time.Duration) *DefaultClient {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("dial %s: %w", address, ErrNonPublicAddress)
+			}
+
+			return nil
+		},
+	}
+
+	return &DefaultClient{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is not
+// reachable from the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP reports whether ip is routable on the internet, i.e. not a
+// loopback, private, carrier-grade NAT, link-local, multicast or unspecified
+// address.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!sharedAddressSpace.Contains(ip) &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
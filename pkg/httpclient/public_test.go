@@ -0,0 +1,36 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := NewPublicClient(5 * time.Second).Do(req)
+
+	assert.ErrorIs(t, err, ErrNonPublicAddress)
+	assert.Nil(t, resp)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	assert.True(t, IsPublicIP(net.ParseIP("93.184.216.34")))
+	assert.True(t, IsPublicIP(net.ParseIP("2606:2800:220:1::248")))
+	assert.True(t, IsPublicIP(net.ParseIP("100.128.0.1")))
+
+	for _, ip := range []string{"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "100.64.0.1", "100.127.255.254", "169.254.169.254", "fe80::1", "fc00::1", "0.0.0.0", "224.0.0.1"} {
+		assert.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -0,0 +1,164 @@
+// Package identity normalizes, validates and decodes Indonesian identity
+// numbers sent to data partners: mobile phone numbers, NIK (ID card numbers)
+// and NPWP (tax numbers).
+package identity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrInvalidPhone    = errors.New("phone number must be an Indonesian mobile number, e.g. 081234567890")
+	ErrUnknownOperator = errors.New("phone number prefix is not assigned to any operator")
+	ErrInvalidNIK      = errors.New("NIK must be 16 digits")
+	ErrNIKProvince     = errors.New("NIK has an unknown province code")
+	ErrNIKRegion       = errors.New("NIK has an invalid regency or district code")
+	ErrNIKBirthDate    = errors.New("NIK has an invalid birth date")
+	ErrNIKSerial       = errors.New("NIK has an invalid serial number")
+	ErrInvalidNPWP     = errors.New("NPWP must be 15 or 16 digits")
+)
+
+// Normalizer is a request that can bring its identity fields to canonical
+// form in place, failing when one of them is invalid.
+type Normalizer interface {
+	Normalize() error
+}
+
+// Normalize normalizes req when it is a Normalizer.
+func Normalize(req any) error {
+	if n, ok := req.(Normalizer); ok {
+		return n.Normalize()
+	}
+
+	return nil
+}
+
+// NormalizePhone returns a mobile phone number in the local 08xxxxxxxxx form.
+// Numbers starting with +62, 62, 0 or the bare 8 are accepted; spaces, dots,
+// dashes and brackets are ignored. Numbers whose prefix no operator is
+// assigned are rejected so they never reach a paid partner call.
+func NormalizePhone(value string) (string, error) {
+	number := localNumber(value)
+
+	if !isDigits(number) || !strings.HasPrefix(number, "08") || len(number) < 10 || len(number) > 13 {
+		return "", ErrInvalidPhone
+	}
+
+	if _, ok := mobilePrefixes[number[:4]]; !ok {
+		return "", ErrUnknownOperator
+	}
+
+	return number, nil
+}
+
+// NormalizeNIK returns a NIK without separators after checking its structure:
+// a known province, non-zero regency and district codes, a real birth date
+// (day plus 40 for women) and a non-zero serial number.
+func NormalizeNIK(value string) (string, error) {
+	nik := stripSeparators(value)
+
+	if len(nik) != 16 || !isDigits(nik) {
+		return "", ErrInvalidNIK
+	}
+
+	if _, ok := provinces[nik[0:2]]; !ok {
+		return "", ErrNIKProvince
+	}
+
+	if nik[2:4] == "00" || nik[4:6] == "00" {
+		return "", ErrNIKRegion
+	}
+
+	if _, _, err := nikBirthDate(nik, time.Now()); err != nil {
+		return "", err
+	}
+
+	if nik[12:16] == "0000" {
+		return "", ErrNIKSerial
+	}
+
+	return nik, nil
+}
+
+// NormalizeNPWP returns an NPWP in the 16 digit format. A 15 digit NPWP is
+// converted by prefixing it with 0; dots and dashes of the printed format are
+// ignored.
+func NormalizeNPWP(value string) (string, error) {
+	npwp := stripSeparators(value)
+
+	if !isDigits(npwp) {
+		return "", ErrInvalidNPWP
+	}
+
+	switch len(npwp) {
+	case 15:
+		return "0" + npwp, nil
+	case 16:
+		return npwp, nil
+	default:
+		return "", ErrInvalidNPWP
+	}
+}
+
+// nikBirthDate decodes the birth date of a NIK and whether it belongs to a
+// woman. The two digit year is placed in the latest century not after now.
+func nikBirthDate(nik string, now time.Time) (time.Time, bool, error) {
+	day := atoi(nik[6:8])
+	month := atoi(nik[8:10])
+	year := atoi(nik[10:12])
+
+	female := day > 40
+	if female {
+		day -= 40
+	}
+
+	year += now.Year() / 100 * 100
+	if year > now.Year() {
+		year -= 100
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || month < 1 || month > 12 || date.Day() != day || date.Month() != time.Month(month) {
+		return time.Time{}, false, ErrNIKBirthDate
+	}
+
+	return date, female, nil
+}
+
+func stripSeparators(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r), r == '.', r == '-', r == '(', r == ')':
+			return -1
+		default:
+			return r
+		}
+	}, value)
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// atoi parses a string already checked to be digits.
+func atoi(value string) int {
+	n := 0
+	for _, r := range value {
+		n = n*10 + int(r-'0')
+	}
+
+	return n
+}
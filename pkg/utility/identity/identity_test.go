@@ -0,0 +1,166 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	for _, input := range []string{"+6281234567890", "6281234567890", "081234567890", "81234567890", "0812-3456-7890", "+62 812 3456 7890"} {
+		result, err := NormalizePhone(input)
+
+		assert.NoError(t, err, input)
+		assert.Equal(t, "081234567890", result, input)
+	}
+
+	for _, input := range []string{"", "0212345678", "08123", "0812345678901234", "08abc4567890"} {
+		_, err := NormalizePhone(input)
+
+		assert.ErrorIs(t, err, ErrInvalidPhone, input)
+	}
+
+	_, err := NormalizePhone("0801234567890")
+	assert.ErrorIs(t, err, ErrUnknownOperator)
+}
+
+func TestDetectPhone(t *testing.T) {
+	tests := []struct {
+		input, number, operator, phoneType string
+	}{
+		{"+62 812 3456 7890", "081234567890", "Telkomsel", PhoneTypeMobile},
+		{"085612345678", "085612345678", "Indosat", PhoneTypeMobile},
+		{"0838-1234-5678", "083812345678", "XL", PhoneTypeMobile},
+		{"0881234567890", "0881234567890", "Smartfren", PhoneTypeMobile},
+		{"089612345678", "089612345678", "Tri", PhoneTypeMobile},
+		{"(021) 5551234", "0215551234", "Telkom", PhoneTypeFixedLine},
+		{"+62 274 512345", "0274512345", "Telkom", PhoneTypeFixedLine},
+	}
+
+	for _, tt := range tests {
+		result, err := DetectPhone(tt.input)
+
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, &PhoneLine{Number: tt.number, Operator: tt.operator, Type: tt.phoneType}, result, tt.input)
+	}
+
+	_, err := DetectPhone("0801234567890")
+	assert.ErrorIs(t, err, ErrUnknownOperator)
+
+	_, err = DetectPhone("0991234567")
+	assert.ErrorIs(t, err, ErrUnknownOperator)
+
+	_, err = DetectPhone("021123")
+	assert.ErrorIs(t, err, ErrInvalidPhone)
+}
+
+func TestPhoneOperator(t *testing.T) {
+	operator, phoneType := PhoneOperator("081234567890")
+	assert.Equal(t, "Telkomsel", operator)
+	assert.Equal(t, PhoneTypeMobile, phoneType)
+
+	operator, phoneType = PhoneOperator("0812****890")
+	assert.Empty(t, operator)
+	assert.Empty(t, phoneType)
+}
+
+func TestNormalizeNIK(t *testing.T) {
+	t.Run("should accept a valid NIK", func(t *testing.T) {
+		result, err := NormalizeNIK("3201 0112 0590 0001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3201011205900001", result)
+	})
+
+	t.Run("should accept the female birth day offset", func(t *testing.T) {
+		_, err := NormalizeNIK("3201015205900001")
+
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name, nik string
+		expected  error
+	}{
+		{"should reject wrong length", "320101120590001", ErrInvalidNIK},
+		{"should reject unknown province", "2001011205900001", ErrNIKProvince},
+		{"should reject zero regency", "3200011205900001", ErrNIKRegion},
+		{"should reject impossible birth date", "3201013102900001", ErrNIKBirthDate},
+		{"should reject zero serial", "3201011205900000", ErrNIKSerial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeNIK(tt.nik)
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNIKBirthDate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	date, female, err := nikBirthDate("3201015205900001", now)
+	assert.NoError(t, err)
+	assert.True(t, female)
+	assert.Equal(t, time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC), date)
+
+	date, _, err = nikBirthDate("3201011205100001", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2010, date.Year())
+}
+
+func TestNormalizeNPWP(t *testing.T) {
+	result, err := NormalizeNPWP("01.234.567.8-901.000")
+	assert.NoError(t, err)
+	assert.Equal(t, "0012345678901000", result)
+
+	result, err = NormalizeNPWP("3201011205900001")
+	assert.NoError(t, err)
+	assert.Equal(t, "3201011205900001", result)
+
+	_, err = NormalizeNPWP("12345")
+	assert.ErrorIs(t, err, ErrInvalidNPWP)
+}
+
+func TestDecodeNIK(t *testing.T) {
+	t.Run("should decode region, birth date and gender", func(t *testing.T) {
+		result, err := DecodeNIK("3273015205900001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3273015205900001", result.Number)
+		assert.Equal(t, "Jawa Barat", result.Province)
+		assert.Equal(t, "3273", result.RegencyCode)
+		assert.Equal(t, "Kota Bandung", result.Regency)
+		assert.Equal(t, "327301", result.DistrictCode)
+		assert.Equal(t, time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC), result.BirthDate)
+		assert.Equal(t, GenderFemale, result.Gender)
+	})
+
+	t.Run("should leave regency empty when it is not in the table", func(t *testing.T) {
+		result, err := DecodeNIK("3299011205900001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Jawa Barat", result.Province)
+		assert.Empty(t, result.Regency)
+		assert.Equal(t, GenderMale, result.Gender)
+	})
+
+	t.Run("should reject an invalid NIK", func(t *testing.T) {
+		_, err := DecodeNIK("3201013102900001")
+
+		assert.ErrorIs(t, err, ErrNIKBirthDate)
+	})
+}
+
+func TestNIKColumns(t *testing.T) {
+	assert.Equal(t, []string{"DKI Jakarta", "Kota Jakarta Selatan", "1990-05-12", GenderMale}, NIKColumns("3171011205900001"))
+	assert.Equal(t, []string{"", "", "", ""}, NIKColumns("not a nik"))
+}
+
+func TestMaskedNIKColumns(t *testing.T) {
+	assert.Equal(t, []string{"DKI Jakarta", "Kota Jakarta Selatan", "1990", GenderMale}, MaskedNIKColumns("3171011205900001"))
+	assert.Equal(t, []string{"", "", "", ""}, MaskedNIKColumns("not a nik"))
+}
@@ -0,0 +1,81 @@
+package identity
+
+import "time"
+
+// Genders decoded from a NIK.
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// NIKHeaders are the export column headers for the values of NIKColumns.
+var NIKHeaders = []string{"NIK Province", "NIK City", "NIK Birth Date", "NIK Gender"}
+
+const birthDateLayout = "2006-01-02"
+
+// NIK is the demographic data encoded in a NIK. Regency is empty when the
+// regency code is not in the embedded region table.
+type NIK struct {
+	Number       string    `json:"nik"`
+	ProvinceCode string    `json:"province_code"`
+	Province     string    `json:"province"`
+	RegencyCode  string    `json:"regency_code"`
+	Regency      string    `json:"regency"`
+	DistrictCode string    `json:"district_code"`
+	BirthDate    time.Time `json:"birth_date"`
+	Gender       string    `json:"gender"`
+}
+
+// DecodeNIK validates a NIK like NormalizeNIK and returns the region, birth
+// date and gender it encodes. It works offline from the embedded region table.
+func DecodeNIK(value string) (*NIK, error) {
+	nik, err := NormalizeNIK(value)
+	if err != nil {
+		return nil, err
+	}
+
+	birthDate, female, err := nikBirthDate(nik, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	gender := GenderMale
+	if female {
+		gender = GenderFemale
+	}
+
+	return &NIK{
+		Number:       nik,
+		ProvinceCode: nik[0:2],
+		Province:     provinces[nik[0:2]],
+		RegencyCode:  nik[0:4],
+		Regency:      regencies[nik[0:4]],
+		DistrictCode: nik[0:6],
+		BirthDate:    birthDate,
+		Gender:       gender,
+	}, nil
+}
+
+// NIKColumns returns the decoded province, city, birth date and gender of a
+// NIK as export values in the order of NIKHeaders. All values are empty when
+// the NIK cannot be decoded.
+func NIKColumns(value string) []string {
+	decoded, err := DecodeNIK(value)
+	if err != nil {
+		return make([]string, len(NIKHeaders))
+	}
+
+	return []string{decoded.Province, decoded.Regency, decoded.BirthDate.Format(birthDateLayout), decoded.Gender}
+}
+
+// MaskedNIKColumns is NIKColumns for exports that mask the NIK. The birth
+// date is cut to its year: the masked digits of a NIK are the birth date, so
+// the full date would undo the masking.
+func MaskedNIKColumns(value string) []string {
+	columns := NIKColumns(value)
+	if columns[2] != "" {
+		columns[2] = columns[2][:4]
+	}
+
+	return columns
+}
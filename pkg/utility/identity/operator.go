@@ -0,0 +1,75 @@
+package identity
+
+import "strings"
+
+// Phone line types, in the values phone live status reports.
+const (
+	PhoneTypeMobile    = "mobile"
+	PhoneTypeFixedLine = "fixed_line"
+)
+
+// PhoneLine is the operator and line type of a phone number as derived from
+// its prefix, without asking any partner.
+type PhoneLine struct {
+	Number   string
+	Operator string
+	Type     string
+}
+
+// DetectPhone normalizes a mobile or fixed-line number and derives its
+// operator and line type from the embedded prefix tables. Mobile numbers are
+// returned in the 08xxxxxxxxx form and fixed-line numbers with their area
+// code, e.g. 0211234567.
+func DetectPhone(value string) (*PhoneLine, error) {
+	number := localNumber(value)
+	if !isDigits(number) || !strings.HasPrefix(number, "0") {
+		return nil, ErrInvalidPhone
+	}
+
+	if strings.HasPrefix(number, "08") {
+		mobile, err := NormalizePhone(number)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PhoneLine{Number: mobile, Operator: mobilePrefixes[mobile[:4]], Type: PhoneTypeMobile}, nil
+	}
+
+	if len(number) < 9 || len(number) > 12 {
+		return nil, ErrInvalidPhone
+	}
+
+	for _, n := range []int{4, 3} {
+		if _, ok := areaCodes[number[:n]]; ok {
+			return &PhoneLine{Number: number, Operator: fixedLineOperator, Type: PhoneTypeFixedLine}, nil
+		}
+	}
+
+	return nil, ErrUnknownOperator
+}
+
+// PhoneOperator returns the operator and line type of a phone number, or
+// empty strings when its prefix is unknown.
+func PhoneOperator(value string) (operator, phoneType string) {
+	line, err := DetectPhone(value)
+	if err != nil {
+		return "", ""
+	}
+
+	return line.Operator, line.Type
+}
+
+// localNumber strips separators and turns the +62 and 62 country code into
+// the domestic 0 prefix. A bare leading 8 is read as a mobile number.
+func localNumber(value string) string {
+	number := strings.TrimPrefix(stripSeparators(value), "+")
+
+	switch {
+	case strings.HasPrefix(number, "62"):
+		return "0" + number[2:]
+	case strings.HasPrefix(number, "8"):
+		return "0" + number
+	default:
+		return number
+	}
+}
@@ -0,0 +1,85 @@
+package identity
+
+// fixedLineOperator runs every fixed line reachable through an area code.
+const fixedLineOperator = "Telkom"
+
+// mobilePrefixes are the mobile number prefixes assigned to each operator.
+// Axis prefixes are listed under XL, which operates the brand.
+var mobilePrefixes = map[string]string{
+	"0811": "Telkomsel",
+	"0812": "Telkomsel",
+	"0813": "Telkomsel",
+	"0821": "Telkomsel",
+	"0822": "Telkomsel",
+	"0823": "Telkomsel",
+	"0851": "Telkomsel",
+	"0852": "Telkomsel",
+	"0853": "Telkomsel",
+
+	"0814": "Indosat",
+	"0815": "Indosat",
+	"0816": "Indosat",
+	"0855": "Indosat",
+	"0856": "Indosat",
+	"0857": "Indosat",
+	"0858": "Indosat",
+
+	"0817": "XL",
+	"0818": "XL",
+	"0819": "XL",
+	"0859": "XL",
+	"0877": "XL",
+	"0878": "XL",
+	"0831": "XL",
+	"0832": "XL",
+	"0833": "XL",
+	"0838": "XL",
+
+	"0881": "Smartfren",
+	"0882": "Smartfren",
+	"0883": "Smartfren",
+	"0884": "Smartfren",
+	"0885": "Smartfren",
+	"0886": "Smartfren",
+	"0887": "Smartfren",
+	"0888": "Smartfren",
+	"0889": "Smartfren",
+
+	"0895": "Tri",
+	"0896": "Tri",
+	"0897": "Tri",
+	"0898": "Tri",
+	"0899": "Tri",
+}
+
+// areaCodes are the fixed-line area codes, with the domestic 0 prefix, of the
+// cities served.
+var areaCodes = map[string]string{
+	"021":  "Jakarta",
+	"022":  "Bandung",
+	"024":  "Semarang",
+	"031":  "Surabaya",
+	"061":  "Medan",
+	"0231": "Cirebon",
+	"0251": "Bogor",
+	"0254": "Serang",
+	"0271": "Surakarta",
+	"0274": "Yogyakarta",
+	"0341": "Malang",
+	"0361": "Denpasar",
+	"0370": "Mataram",
+	"0380": "Kupang",
+	"0411": "Makassar",
+	"0431": "Manado",
+	"0511": "Banjarmasin",
+	"0541": "Samarinda",
+	"0542": "Balikpapan",
+	"0561": "Pontianak",
+	"0711": "Palembang",
+	"0721": "Bandar Lampung",
+	"0751": "Padang",
+	"0761": "Pekanbaru",
+	"0778": "Batam",
+	"0911": "Ambon",
+	"0967": "Jayapura",
+}
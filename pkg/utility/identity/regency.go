@@ -0,0 +1,171 @@
+package identity
+
+// regencies are the regency (kabupaten) and city (kota) codes that follow the
+// province code in a NIK, as set by the Ministry of Home Affairs. City codes
+// start at 71. Regencies missing from this table still decode, without a
+// name.
+var regencies = map[string]string{
+	"1171": "Kota Banda Aceh",
+	"1271": "Kota Medan",
+	"1371": "Kota Padang",
+	"1471": "Kota Pekanbaru",
+	"1571": "Kota Jambi",
+	"1671": "Kota Palembang",
+	"1771": "Kota Bengkulu",
+	"1871": "Kota Bandar Lampung",
+	"1971": "Kota Pangkal Pinang",
+	"2171": "Kota Batam",
+	"2172": "Kota Tanjung Pinang",
+
+	"3101": "Kabupaten Kepulauan Seribu",
+	"3171": "Kota Jakarta Selatan",
+	"3172": "Kota Jakarta Timur",
+	"3173": "Kota Jakarta Pusat",
+	"3174": "Kota Jakarta Barat",
+	"3175": "Kota Jakarta Utara",
+
+	"3201": "Kabupaten Bogor",
+	"3202": "Kabupaten Sukabumi",
+	"3203": "Kabupaten Cianjur",
+	"3204": "Kabupaten Bandung",
+	"3205": "Kabupaten Garut",
+	"3206": "Kabupaten Tasikmalaya",
+	"3207": "Kabupaten Ciamis",
+	"3208": "Kabupaten Kuningan",
+	"3209": "Kabupaten Cirebon",
+	"3210": "Kabupaten Majalengka",
+	"3211": "Kabupaten Sumedang",
+	"3212": "Kabupaten Indramayu",
+	"3213": "Kabupaten Subang",
+	"3214": "Kabupaten Purwakarta",
+	"3215": "Kabupaten Karawang",
+	"3216": "Kabupaten Bekasi",
+	"3217": "Kabupaten Bandung Barat",
+	"3218": "Kabupaten Pangandaran",
+	"3271": "Kota Bogor",
+	"3272": "Kota Sukabumi",
+	"3273": "Kota Bandung",
+	"3274": "Kota Cirebon",
+	"3275": "Kota Bekasi",
+	"3276": "Kota Depok",
+	"3277": "Kota Cimahi",
+	"3278": "Kota Tasikmalaya",
+	"3279": "Kota Banjar",
+
+	"3301": "Kabupaten Cilacap",
+	"3302": "Kabupaten Banyumas",
+	"3303": "Kabupaten Purbalingga",
+	"3304": "Kabupaten Banjarnegara",
+	"3305": "Kabupaten Kebumen",
+	"3306": "Kabupaten Purworejo",
+	"3307": "Kabupaten Wonosobo",
+	"3308": "Kabupaten Magelang",
+	"3309": "Kabupaten Boyolali",
+	"3310": "Kabupaten Klaten",
+	"3311": "Kabupaten Sukoharjo",
+	"3312": "Kabupaten Wonogiri",
+	"3313": "Kabupaten Karanganyar",
+	"3314": "Kabupaten Sragen",
+	"3315": "Kabupaten Grobogan",
+	"3316": "Kabupaten Blora",
+	"3317": "Kabupaten Rembang",
+	"3318": "Kabupaten Pati",
+	"3319": "Kabupaten Kudus",
+	"3320": "Kabupaten Jepara",
+	"3321": "Kabupaten Demak",
+	"3322": "Kabupaten Semarang",
+	"3323": "Kabupaten Temanggung",
+	"3324": "Kabupaten Kendal",
+	"3325": "Kabupaten Batang",
+	"3326": "Kabupaten Pekalongan",
+	"3327": "Kabupaten Pemalang",
+	"3328": "Kabupaten Tegal",
+	"3329": "Kabupaten Brebes",
+	"3371": "Kota Magelang",
+	"3372": "Kota Surakarta",
+	"3373": "Kota Salatiga",
+	"3374": "Kota Semarang",
+	"3375": "Kota Pekalongan",
+	"3376": "Kota Tegal",
+
+	"3401": "Kabupaten Kulon Progo",
+	"3402": "Kabupaten Bantul",
+	"3403": "Kabupaten Gunungkidul",
+	"3404": "Kabupaten Sleman",
+	"3471": "Kota Yogyakarta",
+
+	"3501": "Kabupaten Pacitan",
+	"3502": "Kabupaten Ponorogo",
+	"3503": "Kabupaten Trenggalek",
+	"3504": "Kabupaten Tulungagung",
+	"3505": "Kabupaten Blitar",
+	"3506": "Kabupaten Kediri",
+	"3507": "Kabupaten Malang",
+	"3508": "Kabupaten Lumajang",
+	"3509": "Kabupaten Jember",
+	"3510": "Kabupaten Banyuwangi",
+	"3511": "Kabupaten Bondowoso",
+	"3512": "Kabupaten Situbondo",
+	"3513": "Kabupaten Probolinggo",
+	"3514": "Kabupaten Pasuruan",
+	"3515": "Kabupaten Sidoarjo",
+	"3516": "Kabupaten Mojokerto",
+	"3517": "Kabupaten Jombang",
+	"3518": "Kabupaten Nganjuk",
+	"3519": "Kabupaten Madiun",
+	"3520": "Kabupaten Magetan",
+	"3521": "Kabupaten Ngawi",
+	"3522": "Kabupaten Bojonegoro",
+	"3523": "Kabupaten Tuban",
+	"3524": "Kabupaten Lamongan",
+	"3525": "Kabupaten Gresik",
+	"3526": "Kabupaten Bangkalan",
+	"3527": "Kabupaten Sampang",
+	"3528": "Kabupaten Pamekasan",
+	"3529": "Kabupaten Sumenep",
+	"3571": "Kota Kediri",
+	"3572": "Kota Blitar",
+	"3573": "Kota Malang",
+	"3574": "Kota Probolinggo",
+	"3575": "Kota Pasuruan",
+	"3576": "Kota Mojokerto",
+	"3577": "Kota Madiun",
+	"3578": "Kota Surabaya",
+	"3579": "Kota Batu",
+
+	"3601": "Kabupaten Pandeglang",
+	"3602": "Kabupaten Lebak",
+	"3603": "Kabupaten Tangerang",
+	"3604": "Kabupaten Serang",
+	"3671": "Kota Tangerang",
+	"3672": "Kota Cilegon",
+	"3673": "Kota Serang",
+	"3674": "Kota Tangerang Selatan",
+
+	"5101": "Kabupaten Jembrana",
+	"5102": "Kabupaten Tabanan",
+	"5103": "Kabupaten Badung",
+	"5104": "Kabupaten Gianyar",
+	"5105": "Kabupaten Klungkung",
+	"5106": "Kabupaten Bangli",
+	"5107": "Kabupaten Karangasem",
+	"5108": "Kabupaten Buleleng",
+	"5171": "Kota Denpasar",
+
+	"5271": "Kota Mataram",
+	"5371": "Kota Kupang",
+	"6171": "Kota Pontianak",
+	"6271": "Kota Palangka Raya",
+	"6371": "Kota Banjarmasin",
+	"6471": "Kota Balikpapan",
+	"6472": "Kota Samarinda",
+	"6571": "Kota Tarakan",
+	"7171": "Kota Manado",
+	"7271": "Kota Palu",
+	"7371": "Kota Makassar",
+	"7471": "Kota Kendari",
+	"7571": "Kota Gorontalo",
+	"8171": "Kota Ambon",
+	"8271": "Kota Ternate",
+	"9171": "Kota Jayapura",
+}
@@ -3,6 +3,7 @@ package mailjet
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/mailjet/mailjet-apiv3-go"
 )
@@ -114,3 +115,71 @@ func SendConfirmationEmailUserEmailChangeSuccess(name, oldEmail, newEmail, forma
 
 	return nil
 }
+
+func SendEmailScheduledJobFinished(email, name, scheduleName, jobId, status string) error {
+	baseURL := os.Getenv("FRONTEND_BASE_URL")
+
+	templateId, err := strconv.Atoi(os.Getenv("MAILJET_SCHEDULED_JOB_TEMPLATE_ID"))
+	if err != nil {
+		return fmt.Errorf("scheduled job email template is not configured: %w", err)
+	}
+
+	variables := map[string]interface{}{
+		"name":         name,
+		"scheduleName": scheduleName,
+		"status":       status,
+		"link":         fmt.Sprintf("%s/jobs/%s", baseURL, jobId),
+	}
+
+	err = createMailjet(email, int32(templateId), variables)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func SendEmailWatchlistChanges(email string, subjects, changes int) error {
+	baseURL := os.Getenv("FRONTEND_BASE_URL")
+
+	templateId, err := strconv.Atoi(os.Getenv("MAILJET_WATCHLIST_TEMPLATE_ID"))
+	if err != nil {
+		return fmt.Errorf("watchlist email template is not configured: %w", err)
+	}
+
+	variables := map[string]interface{}{
+		"subjects": subjects,
+		"changes":  changes,
+		"link":     fmt.Sprintf("%s/watchlist/changes", baseURL),
+	}
+
+	err = createMailjet(email, int32(templateId), variables)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func SendEmailExportVolumeAlert(email, name string, exports, rows int) error {
+	baseURL := os.Getenv("FRONTEND_BASE_URL")
+
+	templateId, err := strconv.Atoi(os.Getenv("MAILJET_EXPORT_ALERT_TEMPLATE_ID"))
+	if err != nil {
+		return fmt.Errorf("export alert email template is not configured: %w", err)
+	}
+
+	variables := map[string]interface{}{
+		"name":    name,
+		"exports": exports,
+		"rows":    rows,
+		"link":    fmt.Sprintf("%s/logs/operation?event=export_data", baseURL),
+	}
+
+	err = createMailjet(email, int32(templateId), variables)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package mask hides personal data such as ID card numbers, phone numbers,
+// tax numbers and names, keeping just enough of each value to tell records
+// apart.
+package mask
+
+import (
+	"strings"
+	"unicode"
+)
+
+const maskChar = '*'
+
+// NIK keeps the region code and the last four digits of an ID card number.
+func NIK(value string) string {
+	return keepEnds(value, 6, 4)
+}
+
+// Phone keeps the prefix and the last three digits of a phone number.
+func Phone(value string) string {
+	return keepEnds(value, 4, 3)
+}
+
+// NPWP keeps the first two and last three digits of a tax number. Separators
+// are left in place.
+func NPWP(value string) string {
+	return keepEnds(value, 2, 3)
+}
+
+// Name keeps the first letter of every word of a name.
+func Name(value string) string {
+	words := strings.Fields(value)
+	for i, word := range words {
+		runes := []rune(word)
+		for j := 1; j < len(runes); j++ {
+			runes[j] = maskChar
+		}
+		words[i] = string(runes)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Field masks value according to the kind of personal data field holds.
+// Fields of unknown kind are masked in full but for the last four characters.
+func Field(field, value string) string {
+	switch field {
+	case "nik", "id_card_no":
+		return NIK(value)
+	case "phone_number", "phone":
+		return Phone(value)
+	case "npwp":
+		return NPWP(value)
+	case "npwp_or_nik":
+		if len(digits(value)) == 16 {
+			return NIK(value)
+		}
+		return NPWP(value)
+	case "name", "nama":
+		return Name(value)
+	default:
+		return keepEnds(value, 0, 4)
+	}
+}
+
+// keepEnds masks the letters and digits of value except the first head and
+// last tail of them. Values too short to keep both ends are masked in full.
+func keepEnds(value string, head, tail int) string {
+	runes := []rune(value)
+
+	total := 0
+	for _, r := range runes {
+		if isMaskable(r) {
+			total++
+		}
+	}
+
+	if total <= head+tail {
+		head, tail = 0, 0
+	}
+
+	pos := 0
+	for i, r := range runes {
+		if !isMaskable(r) {
+			continue
+		}
+
+		if pos >= head && pos < total-tail {
+			runes[i] = maskChar
+		}
+		pos++
+	}
+
+	return string(runes)
+}
+
+func isMaskable(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func digits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
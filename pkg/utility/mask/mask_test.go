@@ -0,0 +1,28 @@
+package mask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		name, field, value, expected string
+	}{
+		{"should keep region code and last digits of NIK", "nik", "3201012345670001", "320101******0001"},
+		{"should keep prefix and last digits of phone", "phone_number", "081234567890", "0812*****890"},
+		{"should leave NPWP separators in place", "npwp", "01.234.567.8-901.000", "01.***.***.*-***.000"},
+		{"should mask a 16 digit npwp_or_nik as NIK", "npwp_or_nik", "3201012345670001", "320101******0001"},
+		{"should keep first letter of each name word", "name", "John  Doe", "J*** D**"},
+		{"should mask short values in full", "phone_number", "0812", "****"},
+		{"should keep last characters of unknown fields", "account", "ABC12345", "****2345"},
+		{"should leave empty values empty", "nik", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Field(tt.field, tt.value))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentBytes(t *testing.T) {
+	t.Run("should render a blank page when empty", func(t *testing.T) {
+		result := New().Bytes()
+
+		assert.True(t, bytes.HasPrefix(result, []byte("%PDF-1.4")))
+		assert.True(t, bytes.HasSuffix(result, []byte("%%EOF\n")))
+		assert.Contains(t, string(result), "/Count 1")
+	})
+
+	t.Run("should break pages once full", func(t *testing.T) {
+		doc := New()
+		for i := 0; i < linesPerPage+1; i++ {
+			doc.AddLine(fmt.Sprintf("line %d", i))
+		}
+
+		result := string(doc.Bytes())
+
+		assert.Contains(t, result, "/Count 2")
+		assert.Contains(t, result, fmt.Sprintf("(line %d) Tj", linesPerPage))
+	})
+
+	t.Run("should point the xref table at every object", func(t *testing.T) {
+		doc := New()
+		doc.AddLines("a", "b")
+		result := doc.Bytes()
+
+		xref := bytes.Index(result, []byte("xref\n"))
+		entries := strings.Split(string(result[xref:]), "\n")[3:8]
+		for i, entry := range entries {
+			var offset int
+			_, err := fmt.Sscanf(entry, "%010d", &offset)
+			assert.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(result[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))))
+		}
+	})
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `Total \(IDR\) \\ ok?`, escape("Total (IDR) \\ oké"))
+}